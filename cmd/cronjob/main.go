@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"timble/internal/config"
+)
+
+func main() {
+	cronjob, err := config.NewCronjob()
+	if err != nil {
+		log.Fatalf("failed to create cronjob %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	log.Printf("Cronjob running with %d jobs", len(cronjob.Jobs))
+	cronjob.Run(ctx)
+	log.Println("Received terminate command, graceful shutdown")
+}
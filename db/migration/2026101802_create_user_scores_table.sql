@@ -0,0 +1,11 @@
+CREATE TABLE user_scores (
+  user_id INTEGER NOT NULL PRIMARY KEY REFERENCES users (id),
+  elo_score DOUBLE PRECISION NOT NULL DEFAULT 1500,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON user_scores
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
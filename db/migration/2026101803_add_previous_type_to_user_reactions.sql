@@ -0,0 +1,4 @@
+ALTER TABLE user_reactions
+  ADD COLUMN previous_type INTEGER;
+
+CREATE INDEX user_reaction_user_id_updated_at ON user_reactions (user_id,updated_at);
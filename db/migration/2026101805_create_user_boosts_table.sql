@@ -0,0 +1,13 @@
+ALTER TABLE users
+  ADD COLUMN boost_balance INTEGER NOT NULL DEFAULT 0 CHECK (boost_balance >= 0);
+
+CREATE TABLE user_boosts (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users (id),
+  started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  ends_at TIMESTAMPTZ NOT NULL,
+  impressions INTEGER NOT NULL DEFAULT 0,
+  likes INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX user_boost_user_id_ends_at ON user_boosts (user_id, ends_at);
@@ -0,0 +1,11 @@
+-- a match is stored once per pair, the lower user ID first
+CREATE TABLE matches (
+  id SERIAL PRIMARY KEY,
+  first_user_id INTEGER NOT NULL REFERENCES users (id),
+  second_user_id INTEGER NOT NULL REFERENCES users (id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (first_user_id < second_user_id),
+  UNIQUE (first_user_id, second_user_id)
+);
+
+CREATE INDEX match_second_user_id ON matches (second_user_id);
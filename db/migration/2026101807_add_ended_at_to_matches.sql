@@ -0,0 +1,5 @@
+-- a match ended by either side is kept for history but no longer listed
+ALTER TABLE matches ADD COLUMN ended_at TIMESTAMPTZ;
+
+CREATE INDEX match_first_user_id_created_at ON matches (first_user_id, created_at DESC) WHERE ended_at IS NULL;
+CREATE INDEX match_second_user_id_created_at ON matches (second_user_id, created_at DESC) WHERE ended_at IS NULL;
@@ -0,0 +1,3 @@
+-- who ended a match and why, kept for trust and safety analytics
+ALTER TABLE matches ADD COLUMN ended_by INTEGER REFERENCES users (id);
+ALTER TABLE matches ADD COLUMN end_reason VARCHAR(32);
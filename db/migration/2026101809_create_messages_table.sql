@@ -0,0 +1,13 @@
+-- messages are kept per match, the client message ID makes a retried send idempotent
+CREATE TABLE messages (
+  id SERIAL PRIMARY KEY,
+  match_id INTEGER NOT NULL REFERENCES matches (id),
+  sender_id INTEGER NOT NULL REFERENCES users (id),
+  receiver_id INTEGER NOT NULL REFERENCES users (id),
+  client_message_id VARCHAR(64) NOT NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (sender_id, client_message_id)
+);
+
+CREATE INDEX message_match_id_created_at ON messages (match_id, created_at DESC, id DESC);
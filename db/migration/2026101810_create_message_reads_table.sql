@@ -0,0 +1,10 @@
+-- the read cursor of each participant of a match, only the last message read is kept
+CREATE TABLE message_reads (
+  match_id INTEGER NOT NULL REFERENCES matches (id),
+  user_id INTEGER NOT NULL REFERENCES users (id),
+  last_read_message_id INTEGER NOT NULL REFERENCES messages (id),
+  read_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (match_id, user_id)
+);
+
+CREATE INDEX message_receiver_id_match_id ON messages (receiver_id, match_id, id);
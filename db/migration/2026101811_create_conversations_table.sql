@@ -0,0 +1,37 @@
+-- the inbox of each participant of a match, kept up to date by every message and read cursor so listing it never scans messages
+CREATE TABLE conversations (
+  match_id INTEGER NOT NULL REFERENCES matches (id),
+  user_id INTEGER NOT NULL REFERENCES users (id),
+  other_user_id INTEGER NOT NULL REFERENCES users (id),
+  last_message_id INTEGER NOT NULL REFERENCES messages (id),
+  last_message_sender_id INTEGER NOT NULL REFERENCES users (id),
+  last_message_snippet TEXT NOT NULL,
+  last_message_at TIMESTAMPTZ NOT NULL,
+  unread INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (match_id, user_id)
+);
+
+CREATE INDEX conversation_user_id_last_message_at ON conversations (user_id, last_message_at DESC, match_id DESC);
+
+-- backfill from the messages sent before this table existed
+INSERT INTO conversations (
+  match_id, user_id, other_user_id, last_message_id, last_message_sender_id, last_message_snippet, last_message_at, unread
+)
+SELECT
+  latest.match_id,
+  participant.user_id,
+  participant.other_user_id,
+  latest.id,
+  latest.sender_id,
+  LEFT(latest.body, 100),
+  latest.created_at,
+  (
+    SELECT COUNT(*) FROM messages msg
+    LEFT JOIN message_reads r ON r.match_id = msg.match_id AND r.user_id = msg.receiver_id
+    WHERE msg.match_id = latest.match_id AND msg.receiver_id = participant.user_id
+      AND msg.id > COALESCE(r.last_read_message_id, 0)
+  )
+FROM
+  (SELECT DISTINCT ON (match_id) * FROM messages ORDER BY match_id, id DESC) latest
+CROSS JOIN LATERAL
+  (VALUES (latest.sender_id, latest.receiver_id), (latest.receiver_id, latest.sender_id)) AS participant(user_id, other_user_id);
@@ -0,0 +1,15 @@
+-- messages caught by the content filters are held here instead of being delivered, until trust and safety reviews them
+CREATE TABLE flagged_messages (
+  id SERIAL PRIMARY KEY,
+  match_id INTEGER NOT NULL REFERENCES matches (id),
+  sender_id INTEGER NOT NULL REFERENCES users (id),
+  receiver_id INTEGER NOT NULL REFERENCES users (id),
+  client_message_id VARCHAR(64) NOT NULL,
+  body TEXT NOT NULL,
+  reason VARCHAR(255) NOT NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (sender_id, client_message_id)
+);
+
+CREATE INDEX flagged_message_status_created_at ON flagged_messages (status, created_at);
@@ -0,0 +1,11 @@
+-- a block hides each user of the pair from the other, it is looked up from both sides
+CREATE TABLE user_blocks (
+  user_id INTEGER NOT NULL REFERENCES users (id),
+  blocked_id INTEGER NOT NULL REFERENCES users (id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (user_id <> blocked_id),
+  PRIMARY KEY (user_id, blocked_id)
+);
+
+CREATE INDEX user_block_user_id_created_at ON user_blocks (user_id, created_at DESC);
+CREATE INDEX user_block_blocked_id ON user_blocks (blocked_id);
@@ -0,0 +1,21 @@
+-- reports of users wait here for trust and safety, a reported user is hidden once enough distinct users report them
+CREATE TABLE reports (
+  id SERIAL PRIMARY KEY,
+  reporter_id INTEGER NOT NULL REFERENCES users (id),
+  reported_id INTEGER NOT NULL REFERENCES users (id),
+  reason VARCHAR(32) NOT NULL,
+  details TEXT NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+  claimed_by INTEGER REFERENCES users (id),
+  claimed_at TIMESTAMPTZ,
+  resolved_by INTEGER REFERENCES users (id),
+  resolved_at TIMESTAMPTZ,
+  note TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (reporter_id <> reported_id)
+);
+
+-- a user has at most one open report of another user, reporting again once it is closed is allowed
+CREATE UNIQUE INDEX report_open_reporter_id_reported_id ON reports (reporter_id, reported_id) WHERE status IN ('pending', 'claimed');
+CREATE INDEX report_status_created_at ON reports (status, created_at DESC);
+CREATE INDEX report_reported_id ON reports (reported_id);
@@ -0,0 +1,4 @@
+-- hidden_at is set once enough distinct users reported the user, moderator marks who reviews the moderation queues
+ALTER TABLE users
+  ADD COLUMN hidden_at TIMESTAMPTZ,
+  ADD COLUMN moderator BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+-- reviewed_by and reviewed_at record the moderator who approved or rejected a flagged message, and when
+ALTER TABLE flagged_messages
+  ADD COLUMN reviewed_by INTEGER REFERENCES users (id),
+  ADD COLUMN reviewed_at TIMESTAMPTZ;
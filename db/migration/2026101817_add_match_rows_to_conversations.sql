@@ -0,0 +1,22 @@
+-- every match gets its conversation rows when it is made, so the inbox is paged off the conversations alone. Until the
+-- first message last_message_id is null and last_message_at holds when the match was made. The preview of the last
+-- message is cut from it when listing
+ALTER TABLE conversations
+  ALTER COLUMN last_message_id DROP NOT NULL,
+  ALTER COLUMN last_message_sender_id DROP NOT NULL,
+  DROP COLUMN last_message_snippet;
+
+-- backfill the matches without a message yet
+INSERT INTO conversations (
+  match_id, user_id, other_user_id, last_message_at
+)
+SELECT
+  m.id,
+  participant.user_id,
+  participant.other_user_id,
+  m.created_at
+FROM
+  matches m
+CROSS JOIN LATERAL
+  (VALUES (m.first_user_id, m.second_user_id), (m.second_user_id, m.first_user_id)) AS participant(user_id, other_user_id)
+ON CONFLICT(match_id, user_id) DO NOTHING;
@@ -0,0 +1,4 @@
+-- the reaction history pages by (updated_at, target_id) within a user, this index replaces the one on (user_id, updated_at)
+CREATE INDEX user_reaction_user_id_updated_at_target_id ON user_reactions (user_id, updated_at DESC, target_id DESC);
+
+DROP INDEX user_reaction_user_id_updated_at;
@@ -0,0 +1,5 @@
+-- the score recompute reads the reactions in (updated_at, user_id, target_id) order. This index used to be created with
+-- the user_scores table as user_reaction_updated_at, the one left there by that migration is dropped
+CREATE INDEX user_reaction_updated_at_user_id_target_id ON user_reactions (updated_at, user_id, target_id);
+
+DROP INDEX IF EXISTS user_reaction_updated_at;
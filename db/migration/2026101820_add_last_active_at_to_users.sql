@@ -0,0 +1,14 @@
+-- last_active_at is when the user last reacted to someone, kept on the user so discovery and matches do not look it up
+-- in user_reactions for every row. The backfill leaves updated_at as it is
+ALTER TABLE users ADD COLUMN last_active_at TIMESTAMPTZ;
+
+ALTER TABLE users DISABLE TRIGGER set_timestamp;
+
+UPDATE users u
+SET last_active_at = COALESCE((SELECT MAX(r.updated_at) FROM user_reactions r WHERE r.user_id = u.id), u.updated_at);
+
+ALTER TABLE users ENABLE TRIGGER set_timestamp;
+
+ALTER TABLE users
+  ALTER COLUMN last_active_at SET DEFAULT NOW(),
+  ALTER COLUMN last_active_at SET NOT NULL;
@@ -0,0 +1,10 @@
+-- user_score_checkpoint holds the last reaction applied to user_scores, so a recompute only replays the reactions
+-- made after it. The scores are cleared once so the first run rebuilds them from every reaction
+CREATE TABLE user_score_checkpoint (
+  id BOOLEAN NOT NULL PRIMARY KEY DEFAULT TRUE CHECK (id),
+  updated_at TIMESTAMPTZ NOT NULL,
+  user_id INTEGER NOT NULL,
+  target_id INTEGER NOT NULL
+);
+
+DELETE FROM user_scores;
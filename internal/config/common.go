@@ -1,9 +1,12 @@
 package config
 
 import (
+	"os"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v6"
+	"github.com/pkg/errors"
 
 	_ "github.com/joho/godotenv/autoload"
 	"go.uber.org/zap"
@@ -13,6 +16,9 @@ import (
 	postgres "timble/internal/connection/postgres"
 	redis "timble/internal/connection/redis"
 	"timble/internal/utils"
+	gatewayEntity "timble/module/gateway/entity"
+	messagesEntity "timble/module/messages/entity"
+	usersEntity "timble/module/users/entity"
 )
 
 type ServiceConnections struct {
@@ -21,7 +27,10 @@ type ServiceConnections struct {
 	RedisClient    *redis.RedisClient
 	PostgresClient *postgres.PostgresClient
 
-	Auth *utils.AuthConfig
+	Auth             *utils.AuthConfig
+	UsersSettings    usersEntity.Settings
+	MessagesSettings messagesEntity.Settings
+	GatewaySettings  gatewayEntity.Settings
 }
 
 type authConfig struct {
@@ -63,6 +72,55 @@ type databaseConfig struct {
 	MaxOpenConns int    `env:"DB_MAX_OPEN_CONNS" envDefault:"100"`
 }
 
+type rankingConfig struct {
+	EloWeight          float64 `env:"RANKING_ELO_WEIGHT" envDefault:"0.4"`
+	RecencyWeight      float64 `env:"RANKING_RECENCY_WEIGHT" envDefault:"0.3"`
+	CompletenessWeight float64 `env:"RANKING_COMPLETENESS_WEIGHT" envDefault:"0.1"`
+	DistanceWeight     float64 `env:"RANKING_DISTANCE_WEIGHT" envDefault:"0.2"`
+	RecencyHalfLife    string  `env:"RANKING_RECENCY_HALF_LIFE" envDefault:"72h"`
+	DistanceScaleKm    float64 `env:"RANKING_DISTANCE_SCALE_KM" envDefault:"25"`
+	BoostWeight        float64 `env:"RANKING_BOOST_WEIGHT" envDefault:"0.5"`
+}
+
+type rewindConfig struct {
+	Window string `env:"REWIND_WINDOW" envDefault:"5m"`
+}
+
+type boostConfig struct {
+	Duration     string `env:"BOOST_DURATION" envDefault:"30m"`
+	PremiumGrant int    `env:"BOOST_PREMIUM_GRANT" envDefault:"1"`
+}
+
+type reportConfig struct {
+	HideThreshold int    `env:"REPORT_HIDE_THRESHOLD" envDefault:"3"`
+	ClaimTTL      string `env:"REPORT_CLAIM_TTL" envDefault:"30m"`
+}
+
+type messagingConfig struct {
+	MaxLength       int      `env:"MESSAGE_MAX_LENGTH" envDefault:"1000"`
+	TypingTTL       string   `env:"MESSAGE_TYPING_TTL" envDefault:"5s"`
+	Keywords        []string `env:"MESSAGE_FILTER_KEYWORDS" envSeparator:","`
+	KeywordAction   string   `env:"MESSAGE_FILTER_KEYWORD_ACTION" envDefault:"block"`
+	LinkAction      string   `env:"MESSAGE_FILTER_LINK_ACTION" envDefault:"flag"`
+	PhoneAction     string   `env:"MESSAGE_FILTER_PHONE_ACTION" envDefault:"flag"`
+	DuplicateLimit  int      `env:"MESSAGE_DUPLICATE_LIMIT" envDefault:"3"`
+	DuplicateWindow string   `env:"MESSAGE_DUPLICATE_WINDOW" envDefault:"10m"`
+}
+
+type websocketConfig struct {
+	PingInterval   string   `env:"WS_PING_INTERVAL" envDefault:"30s"`
+	PongWait       string   `env:"WS_PONG_WAIT" envDefault:"60s"`
+	WriteWait      string   `env:"WS_WRITE_WAIT" envDefault:"10s"`
+	SendBuffer     int      `env:"WS_SEND_BUFFER" envDefault:"64"`
+	TicketTTL      string   `env:"WS_TICKET_TTL" envDefault:"30s"`
+	AllowedOrigins []string `env:"WS_ALLOWED_ORIGINS" envSeparator:","`
+}
+
+type policyConfig struct {
+	File            string `env:"POLICY_FILE"`
+	PremiumCacheTTL string `env:"PREMIUM_CACHE_TTL" envDefault:"24h"`
+}
+
 func LoadAuthConfig() authConfig {
 	authConfig := authConfig{}
 	env.Parse(&authConfig)
@@ -99,7 +157,207 @@ func LoadDatabaseConfig() databaseConfig {
 	return dbConfig
 }
 
-func NewServiceConnections() *ServiceConnections {
+func LoadRankingConfig() rankingConfig {
+	rankingCfg := rankingConfig{}
+	env.Parse(&rankingCfg)
+	return rankingCfg
+}
+
+func LoadRewindConfig() rewindConfig {
+	rewindCfg := rewindConfig{}
+	env.Parse(&rewindCfg)
+	return rewindCfg
+}
+
+func LoadBoostConfig() boostConfig {
+	boostCfg := boostConfig{}
+	env.Parse(&boostCfg)
+	return boostCfg
+}
+
+func LoadReportConfig() reportConfig {
+	reportCfg := reportConfig{}
+	env.Parse(&reportCfg)
+	return reportCfg
+}
+
+func LoadMessagingConfig() messagingConfig {
+	messagingCfg := messagingConfig{}
+	env.Parse(&messagingCfg)
+	return messagingCfg
+}
+
+func LoadWebsocketConfig() websocketConfig {
+	websocketCfg := websocketConfig{}
+	env.Parse(&websocketCfg)
+	return websocketCfg
+}
+
+func LoadPolicyConfig() policyConfig {
+	policyCfg := policyConfig{}
+	env.Parse(&policyCfg)
+	return policyCfg
+}
+
+// LoadPolicy reads the tier policy from the file, or uses the default one when there is no file
+func LoadPolicy(path string) (usersEntity.Policy, error) {
+	if path == "" {
+		return usersEntity.DefaultPolicy(), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return usersEntity.Policy{}, err
+	}
+	defer file.Close()
+
+	return usersEntity.NewPolicy(file)
+}
+
+// LoadUsersSettings fails when the policy file can not be used, running with limits nobody meant to set is worse than not starting
+func LoadUsersSettings() (usersEntity.Settings, error) {
+	rankingConfig := LoadRankingConfig()
+	rewindConfig := LoadRewindConfig()
+	policyConfig := LoadPolicyConfig()
+	boostConfig := LoadBoostConfig()
+	reportConfig := LoadReportConfig()
+
+	recencyHalfLife := 72 * time.Hour
+	if t, err := time.ParseDuration(rankingConfig.RecencyHalfLife); err == nil {
+		recencyHalfLife = t
+	}
+
+	rewindWindow := 5 * time.Minute
+	if t, err := time.ParseDuration(rewindConfig.Window); err == nil {
+		rewindWindow = t
+	}
+
+	policy, err := LoadPolicy(policyConfig.File)
+	if err != nil {
+		return usersEntity.Settings{}, errors.Wrap(err, "failed to load policy")
+	}
+
+	premiumCacheTTL := 24 * time.Hour
+	if t, err := time.ParseDuration(policyConfig.PremiumCacheTTL); err == nil {
+		premiumCacheTTL = t
+	}
+
+	boostDuration := 30 * time.Minute
+	if t, err := time.ParseDuration(boostConfig.Duration); err == nil {
+		boostDuration = t
+	}
+
+	claimTTL := 30 * time.Minute
+	if t, err := time.ParseDuration(reportConfig.ClaimTTL); err == nil {
+		claimTTL = t
+	}
+
+	return usersEntity.Settings{
+		Ranking: usersEntity.RankingWeights{
+			Elo:             rankingConfig.EloWeight,
+			Recency:         rankingConfig.RecencyWeight,
+			Completeness:    rankingConfig.CompletenessWeight,
+			Distance:        rankingConfig.DistanceWeight,
+			RecencyHalfLife: recencyHalfLife,
+			DistanceScaleKm: rankingConfig.DistanceScaleKm,
+			Boost:           rankingConfig.BoostWeight,
+		},
+		Rewind: usersEntity.RewindSettings{
+			Window: rewindWindow,
+		},
+		Policy:          policy,
+		PremiumCacheTTL: premiumCacheTTL,
+		Boost: usersEntity.BoostSettings{
+			Duration:     boostDuration,
+			PremiumGrant: boostConfig.PremiumGrant,
+		},
+		Reports: usersEntity.ReportSettings{
+			HideThreshold: reportConfig.HideThreshold,
+			ClaimTTL:      claimTTL,
+		},
+	}, nil
+}
+
+func LoadMessagesSettings() messagesEntity.Settings {
+	messagingConfig := LoadMessagingConfig()
+
+	typingTTL := 5 * time.Second
+	if t, err := time.ParseDuration(messagingConfig.TypingTTL); err == nil {
+		typingTTL = t
+	}
+
+	duplicateWindow := 10 * time.Minute
+	if t, err := time.ParseDuration(messagingConfig.DuplicateWindow); err == nil {
+		duplicateWindow = t
+	}
+
+	return messagesEntity.Settings{
+		MaxLength: messagingConfig.MaxLength,
+		TypingTTL: typingTTL,
+		Filter: messagesEntity.FilterSettings{
+			Keywords:        messagingConfig.Keywords,
+			KeywordAction:   loadFilterAction(messagingConfig.KeywordAction, messagesEntity.FILTER_ACTION_BLOCK),
+			LinkAction:      loadFilterAction(messagingConfig.LinkAction, messagesEntity.FILTER_ACTION_FLAG),
+			PhoneAction:     loadFilterAction(messagingConfig.PhoneAction, messagesEntity.FILTER_ACTION_FLAG),
+			DuplicateLimit:  messagingConfig.DuplicateLimit,
+			DuplicateWindow: duplicateWindow,
+		},
+	}
+}
+
+func loadFilterAction(value string, fallback messagesEntity.FilterAction) messagesEntity.FilterAction {
+	if action, ok := messagesEntity.NewFilterAction(value); ok {
+		return action
+	}
+	return fallback
+}
+
+func LoadGatewaySettings() gatewayEntity.Settings {
+	websocketConfig := LoadWebsocketConfig()
+
+	pingInterval := 30 * time.Second
+	if t, err := time.ParseDuration(websocketConfig.PingInterval); err == nil {
+		pingInterval = t
+	}
+
+	pongWait := 60 * time.Second
+	if t, err := time.ParseDuration(websocketConfig.PongWait); err == nil {
+		pongWait = t
+	}
+
+	// a pong wait shorter than the ping interval would drop every healthy connection
+	if pongWait <= pingInterval {
+		pongWait = 2 * pingInterval
+	}
+
+	writeWait := 10 * time.Second
+	if t, err := time.ParseDuration(websocketConfig.WriteWait); err == nil {
+		writeWait = t
+	}
+
+	ticketTTL := 30 * time.Second
+	if t, err := time.ParseDuration(websocketConfig.TicketTTL); err == nil {
+		ticketTTL = t
+	}
+
+	allowedOrigins := []string{}
+	for _, origin := range websocketConfig.AllowedOrigins {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins = append(allowedOrigins, origin)
+		}
+	}
+
+	return gatewayEntity.Settings{
+		PingInterval:   pingInterval,
+		PongWait:       pongWait,
+		WriteWait:      writeWait,
+		SendBuffer:     websocketConfig.SendBuffer,
+		TicketTTL:      ticketTTL,
+		AllowedOrigins: allowedOrigins,
+	}
+}
+
+func NewServiceConnections() (*ServiceConnections, error) {
 	redisConfig := LoadRedisConfig()
 	cacheConfig := LoadCacheConfig()
 	databaseConfig := LoadDatabaseConfig()
@@ -136,6 +394,11 @@ func NewServiceConnections() *ServiceConnections {
 		GormGetDBFunc:    postgres.GetSQLDB,
 	}
 
+	usersSettings, err := LoadUsersSettings()
+	if err != nil {
+		return nil, err
+	}
+
 	wrappedPostgresClient, _ := postgres.NewClient(
 		postgresClient,
 		databaseConfig.Host,
@@ -148,10 +411,13 @@ func NewServiceConnections() *ServiceConnections {
 	)
 
 	return &ServiceConnections{
-		LoggerClient:   logger,
-		CacheClient:    cacheClient,
-		RedisClient:    redisClient,
-		PostgresClient: wrappedPostgresClient,
-		Auth:           auth,
-	}
+		LoggerClient:     logger,
+		CacheClient:      cacheClient,
+		RedisClient:      redisClient,
+		PostgresClient:   wrappedPostgresClient,
+		Auth:             auth,
+		UsersSettings:    usersSettings,
+		MessagesSettings: LoadMessagesSettings(),
+		GatewaySettings:  LoadGatewaySettings(),
+	}, nil
 }
@@ -0,0 +1,208 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/internal/config"
+	gatewayEntity "timble/module/gateway/entity"
+	messagesEntity "timble/module/messages/entity"
+	usersEntity "timble/module/users/entity"
+)
+
+func Test_LoadPolicy(t *testing.T) {
+	invalidFile := filepath.Join(t.TempDir(), "policy.json")
+	os.WriteFile(invalidFile, []byte(`{"tiers": {"free": {}}}`), 0o600)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedResult usersEntity.Policy
+		expectedErr    bool
+	}{
+		{
+			name:           "no file uses the default policy",
+			expectedResult: usersEntity.DefaultPolicy(),
+		},
+		{
+			name:           "sample file matches the default policy",
+			path:           "../../config/policy.json",
+			expectedResult: usersEntity.DefaultPolicy(),
+		},
+		{
+			name:        "missing file",
+			path:        "not_found.json",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid file",
+			path:        invalidFile,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := config.LoadPolicy(tc.path)
+			if tc.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, policy)
+		})
+	}
+}
+
+func Test_LoadUsersSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		policyFile  string
+		expectedErr string
+	}{
+		{
+			name: "no policy file uses the default policy",
+		},
+		{
+			name:        "missing policy file fails instead of panicking",
+			policyFile:  "not_found.json",
+			expectedErr: "failed to load policy: open not_found.json: no such file or directory",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("POLICY_FILE", tc.policyFile)
+
+			settings, err := config.LoadUsersSettings()
+			if tc.expectedErr != "" {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr, err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, usersEntity.DefaultPolicy(), settings.Policy)
+		})
+	}
+}
+
+func Test_LoadGatewaySettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            map[string]string
+		expectedResult gatewayEntity.Settings
+	}{
+		{
+			name: "defaults",
+			expectedResult: gatewayEntity.Settings{
+				PingInterval:   30 * time.Second,
+				PongWait:       60 * time.Second,
+				WriteWait:      10 * time.Second,
+				SendBuffer:     64,
+				TicketTTL:      30 * time.Second,
+				AllowedOrigins: []string{},
+			},
+		},
+		{
+			name: "pong wait not longer than the ping interval",
+			env: map[string]string{
+				"WS_PING_INTERVAL": "45s",
+				"WS_PONG_WAIT":     "30s",
+				"WS_SEND_BUFFER":   "8",
+			},
+			expectedResult: gatewayEntity.Settings{
+				PingInterval:   45 * time.Second,
+				PongWait:       90 * time.Second,
+				WriteWait:      10 * time.Second,
+				SendBuffer:     8,
+				TicketTTL:      30 * time.Second,
+				AllowedOrigins: []string{},
+			},
+		},
+		{
+			name: "ticket TTL and allowed origins",
+			env: map[string]string{
+				"WS_TICKET_TTL":      "1m",
+				"WS_ALLOWED_ORIGINS": "https://timble.app, https://www.timble.app,",
+			},
+			expectedResult: gatewayEntity.Settings{
+				PingInterval:   30 * time.Second,
+				PongWait:       60 * time.Second,
+				WriteWait:      10 * time.Second,
+				SendBuffer:     64,
+				TicketTTL:      time.Minute,
+				AllowedOrigins: []string{"https://timble.app", "https://www.timble.app"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			assert.Equal(t, tc.expectedResult, config.LoadGatewaySettings())
+		})
+	}
+}
+
+func Test_LoadMessagesSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            map[string]string
+		expectedResult messagesEntity.Settings
+	}{
+		{
+			name: "defaults",
+			expectedResult: messagesEntity.Settings{
+				MaxLength: 1000,
+				TypingTTL: 5 * time.Second,
+				Filter: messagesEntity.FilterSettings{
+					KeywordAction:   messagesEntity.FILTER_ACTION_BLOCK,
+					LinkAction:      messagesEntity.FILTER_ACTION_FLAG,
+					PhoneAction:     messagesEntity.FILTER_ACTION_FLAG,
+					DuplicateLimit:  3,
+					DuplicateWindow: 10 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "configured filters",
+			env: map[string]string{
+				"MESSAGE_FILTER_KEYWORDS":       "scam,send money",
+				"MESSAGE_FILTER_KEYWORD_ACTION": "flag",
+				"MESSAGE_FILTER_LINK_ACTION":    "block",
+				"MESSAGE_FILTER_PHONE_ACTION":   "drop",
+				"MESSAGE_DUPLICATE_LIMIT":       "0",
+				"MESSAGE_DUPLICATE_WINDOW":      "1h",
+			},
+			expectedResult: messagesEntity.Settings{
+				MaxLength: 1000,
+				TypingTTL: 5 * time.Second,
+				Filter: messagesEntity.FilterSettings{
+					Keywords:        []string{"scam", "send money"},
+					KeywordAction:   messagesEntity.FILTER_ACTION_FLAG,
+					LinkAction:      messagesEntity.FILTER_ACTION_BLOCK,
+					PhoneAction:     messagesEntity.FILTER_ACTION_FLAG,
+					DuplicateLimit:  0,
+					DuplicateWindow: time.Hour,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			assert.Equal(t, tc.expectedResult, config.LoadMessagesSettings())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/caarlos0/env/v6"
+	"go.uber.org/zap"
+
+	usersConfig "timble/module/users/config"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Cronjob struct {
+	Jobs   []Job
+	Logger *zap.Logger
+}
+
+type cronjobConfig struct {
+	ScoreRecomputeInterval string `env:"SCORE_RECOMPUTE_INTERVAL"`
+}
+
+func LoadCronjobConfig() cronjobConfig {
+	cronjobCfg := cronjobConfig{}
+	env.Parse(&cronjobCfg)
+	return cronjobCfg
+}
+
+func NewCronjob() (*Cronjob, error) {
+	cronjobConfig := LoadCronjobConfig()
+	conns, err := NewServiceConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	scoreRecomputeInterval := 15 * time.Minute // recompute scores every 15 minutes by default
+	if t, err := time.ParseDuration(cronjobConfig.ScoreRecomputeInterval); err == nil {
+		scoreRecomputeInterval = t
+	}
+
+	usersJob := usersConfig.NewUsersJob(conns.LoggerClient, conns.RedisClient, conns.PostgresClient, conns.UsersSettings)
+
+	cronjob := &Cronjob{
+		Jobs: []Job{
+			{
+				Name:     "recompute_user_scores",
+				Interval: scoreRecomputeInterval,
+				Run:      usersJob.RecomputeScores,
+			},
+		},
+		Logger: conns.LoggerClient,
+	}
+	return cronjob, nil
+}
+
+// Run executes every job on its own interval until the context is cancelled
+func (c *Cronjob) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range c.Jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+
+			for {
+				c.runJob(ctx, job)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(job)
+	}
+	wg.Wait()
+}
+
+func (c *Cronjob) runJob(ctx context.Context, job Job) {
+	startTime := time.Now()
+	err := job.Run(ctx)
+	if err != nil {
+		c.Logger.Error(err.Error(), zap.String("job", job.Name))
+		return
+	}
+	c.Logger.Info("job finished", zap.String("job", job.Name), zap.Duration("duration", time.Since(startTime)))
+}
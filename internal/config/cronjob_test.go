@@ -0,0 +1,118 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"timble/internal/config"
+)
+
+func Test_NewCronjob(t *testing.T) {
+	rds := miniredis.RunT(t)
+	tests := []struct {
+		name             string
+		mockFn           func()
+		expectedInterval time.Duration
+	}{
+		{
+			name: "normal case",
+			mockFn: func() {
+				os.Setenv("SCORE_RECOMPUTE_INTERVAL", "1m")
+				os.Setenv("REDIS_HOST", rds.Host())
+				os.Setenv("REDIS_PORT", rds.Port())
+				os.Setenv("CACHE_HOST", rds.Host())
+				os.Setenv("CACHE_PORT", rds.Port())
+			},
+			expectedInterval: time.Minute,
+		},
+		{
+			name: "invalid interval falls back to the default",
+			mockFn: func() {
+				os.Setenv("SCORE_RECOMPUTE_INTERVAL", "abc")
+				os.Setenv("REDIS_HOST", rds.Host())
+				os.Setenv("REDIS_PORT", rds.Port())
+				os.Setenv("CACHE_HOST", rds.Host())
+				os.Setenv("CACHE_PORT", rds.Port())
+			},
+			expectedInterval: 15 * time.Minute,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFn()
+
+			cronjob, err := config.NewCronjob()
+
+			assert.Nil(t, err)
+			assert.Len(t, cronjob.Jobs, 1)
+			assert.Equal(t, "recompute_user_scores", cronjob.Jobs[0].Name)
+			assert.Equal(t, tc.expectedInterval, cronjob.Jobs[0].Interval)
+		})
+	}
+}
+
+func Test_NewCronjob_InvalidPolicy(t *testing.T) {
+	rds := miniredis.RunT(t)
+	t.Setenv("REDIS_HOST", rds.Host())
+	t.Setenv("REDIS_PORT", rds.Port())
+	t.Setenv("CACHE_HOST", rds.Host())
+	t.Setenv("CACHE_PORT", rds.Port())
+	t.Setenv("POLICY_FILE", "not_found.json")
+
+	cronjob, err := config.NewCronjob()
+
+	assert.Nil(t, cronjob)
+	assert.NotNil(t, err)
+	assert.Equal(t, "failed to load policy: open not_found.json: no such file or directory", err.Error())
+}
+
+func TestCronjob_Run(t *testing.T) {
+	tests := []struct {
+		name   string
+		jobErr error
+	}{
+		{
+			name: "job succeeded",
+		},
+		{
+			name:   "job failed",
+			jobErr: errors.New("job failed"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var runs int32
+			ctx, cancel := context.WithCancel(context.Background())
+
+			cronjob := &config.Cronjob{
+				Jobs: []config.Job{
+					{
+						Name:     "test_job",
+						Interval: time.Millisecond,
+						Run: func(ctx context.Context) error {
+							if atomic.AddInt32(&runs, 1) == 3 {
+								cancel()
+							}
+							return tc.jobErr
+						},
+					},
+				},
+				Logger: zap.NewNop(),
+			}
+
+			cronjob.Run(ctx)
+
+			assert.GreaterOrEqual(t, atomic.LoadInt32(&runs), int32(3))
+		})
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"moul.io/chizap"
 
 	"timble/internal/utils"
+	gatewayConfig "timble/module/gateway/config"
+	messagesConfig "timble/module/messages/config"
 	usersConfig "timble/module/users/config"
 )
 
@@ -26,7 +28,10 @@ func NewRestServer() (*RESTServer, error) {
 	prometheusConfig := LoadPrometheusConfig()
 	restServerConfig := LoadRestServerConfig()
 
-	restRouter := getRESTRoutes()
+	restRouter, err := getRESTRoutes()
+	if err != nil {
+		return nil, err
+	}
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", restServerConfig.ServerHost, restServerConfig.ServerPort),
@@ -51,8 +56,11 @@ func NewRestServer() (*RESTServer, error) {
 	return restServer, nil
 }
 
-func getRESTRoutes() *chi.Mux {
-	conns := NewServiceConnections()
+func getRESTRoutes() (*chi.Mux, error) {
+	conns, err := NewServiceConnections()
+	if err != nil {
+		return nil, err
+	}
 	logger := conns.LoggerClient
 	cache := conns.CacheClient
 	redis := conns.RedisClient
@@ -76,6 +84,21 @@ func getRESTRoutes() *chi.Mux {
 		cache,
 		redis,
 		postgres,
+		conns.UsersSettings,
+	)
+
+	messagesHandler := messagesConfig.NewMessagesHandler(
+		auth,
+		logger,
+		redis,
+		postgres,
+		conns.MessagesSettings,
+	)
+
+	gatewayHandler := gatewayConfig.NewGatewayHandler(
+		logger,
+		redis,
+		conns.GatewaySettings,
 	)
 
 	// Health check function
@@ -93,16 +116,73 @@ func getRESTRoutes() *chi.Mux {
 	router.Route("/api/protected/users", func(r chi.Router) {
 		r.Use(utils.Authentication(auth))
 		r.Get("/", usersHandler.Show)
+		r.Patch("/timezone", usersHandler.UpdateTimezone)
 		r.Patch("/react", usersHandler.React)
+		r.Post("/react/batch", usersHandler.ReactBatch)
+		r.Post("/react/undo", usersHandler.UndoReaction)
+		r.Get("/reactions", usersHandler.ReactionHistory)
+		r.Get("/likes/received", usersHandler.ReceivedLikes)
+		r.Get("/matches", usersHandler.Matches)
+		r.Delete("/matches/{id}", usersHandler.Unmatch)
+		r.Get("/blocks", usersHandler.Blocks)
+		r.Post("/blocks/{id}", usersHandler.Block)
+		r.Delete("/blocks/{id}", usersHandler.Unblock)
+		r.Get("/quota", usersHandler.Quota)
+		r.Get("/discover", usersHandler.Discover)
+		r.Get("/boost", usersHandler.BoostStatus)
+		r.Post("/boost", usersHandler.ActivateBoost)
 		r.Route("/premium", func(r chi.Router) {
 			r.Patch("/grant", usersHandler.GrantPremium)
 			r.Patch("/unsubscribe", usersHandler.UnsubscribePremium)
 		})
+		r.Get("/{id}", usersHandler.Profile)
+		r.Post("/{id}/report", usersHandler.Report)
+	})
+
+	router.Route("/api/protected/moderation", func(r chi.Router) {
+		r.Use(utils.Authentication(auth))
+		r.Get("/reports", usersHandler.ReportQueue)
+		r.Post("/reports/{id}/claim", usersHandler.ClaimReport)
+		r.Post("/reports/{id}/resolve", usersHandler.ResolveReport)
+		r.Post("/reports/{id}/dismiss", usersHandler.DismissReport)
+		r.Get("/messages", messagesHandler.FlaggedMessages)
+		r.Post("/messages/{id}/approve", messagesHandler.ApproveFlaggedMessage)
+		r.Post("/messages/{id}/reject", messagesHandler.RejectFlaggedMessage)
+	})
+
+	router.Route("/api/protected/messages", func(r chi.Router) {
+		r.Use(utils.Authentication(auth))
+		r.Get("/unread", messagesHandler.Unread)
+		r.Post("/{user_id}", messagesHandler.Send)
+		r.Get("/{user_id}", messagesHandler.List)
+		r.Post("/{user_id}/read", messagesHandler.MarkRead)
+		r.Post("/{user_id}/typing", messagesHandler.Typing)
+	})
+
+	router.Route("/api/protected/conversations", func(r chi.Router) {
+		r.Use(utils.Authentication(auth))
+		r.Get("/", messagesHandler.Conversations)
+	})
+
+	router.Route("/api/protected/gateway", func(r chi.Router) {
+		r.Use(utils.Authentication(auth))
+		r.Post("/ticket", gatewayHandler.Ticket)
+	})
+
+	router.Route("/api/protected/ws", func(r chi.Router) {
+		r.Use(utils.WebSocketAuthentication(auth, gatewayHandler.GatewayUsecase.RedeemTicket))
+		r.Get("/", gatewayHandler.Connect)
+	})
+
+	router.Route("/api/protected/events", func(r chi.Router) {
+		// EventSource cannot set headers either
+		r.Use(utils.WebSocketAuthentication(auth, gatewayHandler.GatewayUsecase.RedeemTicket))
+		r.Get("/", gatewayHandler.Events)
 	})
 
 	router.Route("/api/public/auth", func(r chi.Router) {
 		r.Post("/login", usersHandler.Login)
 	})
 
-	return router
+	return router, nil
 }
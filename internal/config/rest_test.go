@@ -3,6 +3,7 @@ package config_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -53,6 +54,15 @@ func Test_NewRestServer(t *testing.T) {
 				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "ok"),
 			},
 		},
+		{
+			name: "policy file is missing",
+			mockFn: func() {
+				os.Setenv("POLICY_FILE", "not_found.json")
+			},
+			expected: expected{
+				expectedErr: errors.New("failed to load policy: open not_found.json: no such file or directory"),
+			},
+		},
 		{
 			name: "redis is down",
 			mockFn: func() {
@@ -88,6 +98,7 @@ func Test_NewRestServer(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			os.Unsetenv("POLICY_FILE")
 			tc.mockFn()
 			if tc.expected.expectedPanic {
 				assert.Panics(t, func() {
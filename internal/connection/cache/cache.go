@@ -16,6 +16,7 @@ import (
 type CacheInterface interface {
 	Set(ctx context.Context, key string, value interface{}, expire time.Duration) error
 	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
 }
 
 var (
@@ -88,6 +89,15 @@ func (c *CacheClient) Get(ctx context.Context, key string) (res []byte, err erro
 	return res, err
 }
 
+// Delete remove the given key from redis and the local cache, a missing key is not an error
+func (c *CacheClient) Delete(ctx context.Context, key string) error {
+	metricInfo := utils.NewClientMetric("cache", "delete")
+	err := c.Client.Delete(ctx, key)
+	err = c.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return err
+}
+
 func (c *CacheClient) wrapError(err error) error {
 	if err != nil && !ignoredErrors[err.Error()] {
 		return err
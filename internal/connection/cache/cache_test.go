@@ -171,3 +171,47 @@ func TestRedisClient_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheClient_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		mockErr       string
+		expectedError error
+	}{
+		{
+			name: "normal case with existing key",
+			key:  testKey1,
+		},
+		{
+			name: "normal case with non existing key",
+			key:  testKey2,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+
+			client, _ := cache.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.Set(testKey1, testMember1)
+			s.SetError(tc.mockErr)
+
+			err := client.Delete(context.Background(), tc.key)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.False(t, s.Exists(tc.key))
+			}
+
+			defer s.Close()
+		})
+	}
+}
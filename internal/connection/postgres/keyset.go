@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"fmt"
+
+	"timble/internal/utils"
+)
+
+// Keyset builds pagination clauses over a (created_at, id) column pair, newest rows first
+type Keyset struct {
+	CreatedAtColumn string
+	IDColumn        string
+}
+
+// Condition returns the filter selecting the rows after the cursor
+func (k Keyset) Condition(cursor *utils.Cursor) (string, []interface{}) {
+	if cursor == nil {
+		return "TRUE", []interface{}{}
+	}
+
+	return fmt.Sprintf("(%s, %s) < (?, ?)", k.CreatedAtColumn, k.IDColumn), []interface{}{cursor.CreatedAt, cursor.ID}
+}
+
+func (k Keyset) OrderBy() string {
+	return fmt.Sprintf("%s DESC, %s DESC", k.CreatedAtColumn, k.IDColumn)
+}
+
+// Paginate appends the keyset condition, the ordering and the limit to a query ending in a WHERE clause.
+// One extra row is requested so the caller can tell whether another page exists, see utils.NewPage.
+func (k Keyset) Paginate(query string, args []interface{}, params utils.PageParams) (string, []interface{}) {
+	condition, conditionArgs := k.Condition(params.Cursor)
+
+	paginated := fmt.Sprintf("%s AND %s ORDER BY %s LIMIT ?", query, condition, k.OrderBy())
+	paginatedArgs := append(append(append([]interface{}{}, args...), conditionArgs...), params.Limit+1)
+
+	return paginated, paginatedArgs
+}
@@ -0,0 +1,47 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	client "timble/internal/connection/postgres"
+	"timble/internal/utils"
+)
+
+func TestKeyset_Paginate(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	keyset := client.Keyset{CreatedAtColumn: "r.created_at", IDColumn: "r.target_id"}
+
+	tests := []struct {
+		name          string
+		params        utils.PageParams
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "first page",
+			params:        utils.PageParams{Limit: 10},
+			expectedQuery: "SELECT * FROM user_reactions r WHERE r.user_id = ? AND TRUE ORDER BY r.created_at DESC, r.target_id DESC LIMIT ?",
+			expectedArgs:  []interface{}{uint(1), 11},
+		},
+		{
+			name: "page after a cursor",
+			params: utils.PageParams{
+				Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 5},
+				Limit:  10,
+			},
+			expectedQuery: "SELECT * FROM user_reactions r WHERE r.user_id = ? AND (r.created_at, r.target_id) < (?, ?) ORDER BY r.created_at DESC, r.target_id DESC LIMIT ?",
+			expectedArgs:  []interface{}{uint(1), timestamp, uint(5), 11},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args := keyset.Paginate("SELECT * FROM user_reactions r WHERE r.user_id = ?", []interface{}{uint(1)}, tc.params)
+
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
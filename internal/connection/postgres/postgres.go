@@ -13,7 +13,9 @@ import (
 
 type PostgresInterface interface {
 	GetFirst(record interface{}, condition string, args ...interface{}) error
+	Select(records interface{}, query string, args ...interface{}) error
 	Exec(query string, args ...interface{}) error
+	Transaction(fn func(tx PostgresInterface) error) error
 }
 
 var (
@@ -77,6 +79,14 @@ func (c *PostgresClient) GetFirst(record interface{}, condition string, args ...
 	return err
 }
 
+func (c *PostgresClient) Select(records interface{}, query string, args ...interface{}) error {
+	metricInfo := utils.NewClientMetric(c.Name, "select")
+	result := c.Client.Raw(query, args...).Scan(records)
+	err := c.wrapError(result.Error)
+	metricInfo.TrackClientWithError(err)
+	return err
+}
+
 func (c *PostgresClient) Exec(query string, args ...interface{}) error {
 	metricInfo := utils.NewClientMetric(c.Name, "exec")
 	result := c.Client.Exec(query, args...)
@@ -85,6 +95,17 @@ func (c *PostgresClient) Exec(query string, args ...interface{}) error {
 	return err
 }
 
+// Transaction runs fn on a client bound to a single transaction, committed when fn returns no error
+func (c *PostgresClient) Transaction(fn func(tx PostgresInterface) error) error {
+	metricInfo := utils.NewClientMetric(c.Name, "transaction")
+	err := c.Client.Transaction(func(tx *gorm.DB) error {
+		return fn(&PostgresClient{Name: c.Name, Client: tx})
+	})
+	err = c.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return err
+}
+
 func (c *PostgresClient) wrapError(err error) error {
 	if err != nil && !ignoredErrors[err.Error()] {
 		return err
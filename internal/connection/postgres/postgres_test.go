@@ -182,6 +182,64 @@ func TestPostgres_GetFirst(t *testing.T) {
 	}
 }
 
+func TestPostgres_Select(t *testing.T) {
+	query := `SELECT name FROM test_structs WHERE name <> $1`
+	name := "testname"
+
+	tests := []struct {
+		name           string
+		rows           *sqlmock.Rows
+		expectedResult []testStruct
+		expectedError  error
+	}{
+		{
+			name:           "successfully select rows",
+			rows:           sqlmock.NewRows([]string{"name"}).AddRow("first").AddRow("second"),
+			expectedResult: []testStruct{{Name: "first"}, {Name: "second"}},
+		},
+		{
+			name:           "successfully ran the query, but no rows are found",
+			rows:           sqlmock.NewRows([]string{"name"}),
+			expectedResult: []testStruct{},
+		},
+		{
+			name:          "unexpected error from db",
+			expectedError: errors.New("timeout"),
+		},
+	}
+
+	db, mock, gormDb, _ := openMockDB(t)
+	defer db.Close()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedError != nil {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(name).
+					WillReturnError(tc.expectedError)
+			} else {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(name).
+					WillReturnRows(tc.rows)
+			}
+
+			client := client.PostgresClient{
+				Client: gormDb,
+			}
+
+			records := []testStruct{}
+			err := client.Select(&records, "SELECT name FROM test_structs WHERE name <> ?", name)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, records)
+			}
+		})
+	}
+}
+
 func TestPostgres_Exec(t *testing.T) {
 	query := `UPDATE "test_structs" SET name = $1 WHERE name = $2`
 	name := "testname"
@@ -268,3 +326,58 @@ func openMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *gorm.DB, time.Time) {
 
 	return db, mock, gormDB, now
 }
+
+func TestPostgres_Transaction(t *testing.T) {
+	query := `UPDATE "test_structs" SET name = $1 WHERE name = $2`
+	name := "testname"
+
+	tests := []struct {
+		name          string
+		execError     error
+		expectedError error
+	}{
+		{
+			name: "successfully commit transaction",
+		},
+		{
+			name:          "rollback when a query fails",
+			execError:     errors.New("timeout"),
+			expectedError: errors.New("timeout"),
+		},
+	}
+
+	db, mock, gormDb, _ := openMockDB(t)
+	defer db.Close()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tc.execError != nil {
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(name, name).
+					WillReturnError(tc.execError)
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(name, name).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			postgresClient := client.PostgresClient{
+				Client: gormDb,
+			}
+
+			err := postgresClient.Transaction(func(tx client.PostgresInterface) error {
+				return tx.Exec("UPDATE \"test_structs\" SET name = ? WHERE name = ?", name, name)
+			})
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package redis
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -15,15 +18,83 @@ import (
 type RedisInterface interface {
 	Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error)
 	Get(ctx context.Context, key string) (string, error)
+	GetDel(ctx context.Context, key string) (string, error)
 	Expire(ctx context.Context, key string, tm time.Duration) (bool, error)
 	Incr(ctx context.Context, key string) (int64, error)
 	Del(ctx context.Context, key string) (int64, error)
+	SAdd(ctx context.Context, key string, members ...interface{}) (int64, error)
+	Publish(ctx context.Context, channel string, message interface{}) (int64, error)
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	PublishEvent(ctx context.Context, stream, channel, payload string, maxLen int64, ttl time.Duration) (string, error)
+	ReadEvents(ctx context.Context, stream, afterID string) ([]redis.XMessage, error)
+	ReserveQuota(ctx context.Context, key string, limit int, expire time.Duration) (bool, error)
+	RefundQuota(ctx context.Context, key string) (int64, error)
+	ReserveRollingQuota(ctx context.Context, key, member string, limit int, period time.Duration) (bool, error)
+	RefundRollingQuota(ctx context.Context, key, member string) (int64, error)
+	RefundRollingQuotaAt(ctx context.Context, key string, at time.Time) (int64, error)
+	RollingQuotaUsage(ctx context.Context, key string, period time.Duration) (int64, time.Time, error)
 }
 
 var (
+	ErrEventPayload = errors.New("event payload has to be a JSON object")
+
 	ignoredErrors = map[string]bool{
 		"redis: nil": true, // this error is expected for some users, so we can ignore it
 	}
+
+	// reserveQuotaScript increments the counter only while it stays within the limit,
+	// the expiry is set when the counter is created
+	reserveQuotaScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 and tonumber(ARGV[2]) > 0 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if count > tonumber(ARGV[1]) then
+  redis.call('DECR', KEYS[1])
+  return 0
+end
+return 1
+`)
+
+	// refundQuotaScript decrements the counter without going below zero
+	refundQuotaScript = redis.NewScript(`
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+if count > 0 then
+  return redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+	// reserveRollingQuotaScript keeps one sorted set entry per reservation scored by its time,
+	// entries older than the period are dropped before counting
+	reserveRollingQuotaScript = redis.NewScript(`
+local now = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - tonumber(ARGV[2]))
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[1]) then
+  return 0
+end
+redis.call('ZADD', KEYS[1], now, ARGV[4])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+	// refundRollingQuotaAtScript drops the latest entry taken at or before the given time
+	refundRollingQuotaAtScript = redis.NewScript(`
+local entries = redis.call('ZREVRANGEBYSCORE', KEYS[1], ARGV[1], '-inf', 'LIMIT', 0, 1)
+if #entries == 0 then
+  return 0
+end
+return redis.call('ZREM', KEYS[1], entries[1])
+`)
+
+	// publishEventScript appends the event to the stream, trimmed to about maxLen entries, then publishes it
+	// with the ID of its entry added. The rest of the published envelope after the ID is built by the caller
+	publishEventScript = redis.NewScript(`
+local id = redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[2], '*', 'payload', ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+redis.call('PUBLISH', ARGV[4], '{"id":"' .. id .. '"' .. ARGV[5])
+return id
+`)
 )
 
 // Redis represents redis connection object
@@ -98,6 +169,15 @@ func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return res, err
 }
 
+// GetDel retrieve value from redis and remove the key, so only one caller ever gets it
+func (r *RedisClient) GetDel(ctx context.Context, key string) (string, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "getdel")
+	res, err := r.Client.GetDel(ctx, key).Result()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res, err
+}
+
 // Del remove the given key from redis
 func (r *RedisClient) Del(ctx context.Context, key string) (int64, error) {
 	metricInfo := utils.NewClientMetric(r.Name, "del")
@@ -108,6 +188,138 @@ func (r *RedisClient) Del(ctx context.Context, key string) (int64, error) {
 	return result, err
 }
 
+// SAdd adds the members to the set at key
+func (r *RedisClient) SAdd(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "sadd")
+	// result is the number of members that were not in the set yet
+	result, err := r.Client.SAdd(ctx, key, members...).Result()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return result, err
+}
+
+// Publish send a message to the subscribers of the given channel
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "publish")
+	// result is the number of subscribers that received the message
+	result, err := r.Client.Publish(ctx, channel, message).Result()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return result, err
+}
+
+// Subscribe listens to the given channels, more channels can be added to the returned subscription later
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.Client.Subscribe(ctx, channels...)
+}
+
+// PublishEvent atomically logs the event in the stream and publishes it to the channel, it returns the ID of the entry
+func (r *RedisClient) PublishEvent(ctx context.Context, stream, channel, payload string, maxLen int64, ttl time.Duration) (string, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "publish_event")
+	rest, err := eventEnvelopeRest(payload)
+	if err != nil {
+		metricInfo.TrackClientWithError(err)
+		return "", err
+	}
+
+	res, err := publishEventScript.Run(ctx, r.Client, []string{stream}, payload, maxLen, ttl.Milliseconds(), channel, rest).Text()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res, err
+}
+
+// eventEnvelopeRest is what follows the ID in the published envelope, the fields of the payload object if it has any
+func eventEnvelopeRest(payload string) (string, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil || fields == nil {
+		return "", ErrEventPayload
+	}
+
+	compacted := bytes.Buffer{}
+	if err := json.Compact(&compacted, []byte(payload)); err != nil {
+		return "", ErrEventPayload
+	}
+
+	if len(fields) == 0 {
+		return "}", nil
+	}
+	return "," + compacted.String()[1:], nil
+}
+
+// ReadEvents returns the entries of the stream logged after the given ID, the oldest first
+func (r *RedisClient) ReadEvents(ctx context.Context, stream, afterID string) ([]redis.XMessage, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "read_events")
+	res, err := r.Client.XRange(ctx, stream, "("+afterID, "+").Result()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res, err
+}
+
+// ReserveQuota atomically takes one unit from the counter at key, it returns false when the limit is reached
+func (r *RedisClient) ReserveQuota(ctx context.Context, key string, limit int, expire time.Duration) (bool, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "reserve_quota")
+	res, err := reserveQuotaScript.Run(ctx, r.Client, []string{key}, limit, expire.Milliseconds()).Int64()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res == 1, err
+}
+
+// RefundQuota atomically gives back one unit to the counter at key
+func (r *RedisClient) RefundQuota(ctx context.Context, key string) (int64, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "refund_quota")
+	res, err := refundQuotaScript.Run(ctx, r.Client, []string{key}).Int64()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res, err
+}
+
+// ReserveRollingQuota atomically takes one unit from the quota at key when less than limit were taken within the last period,
+// the unit is kept as the given member so the reservation can be refunded on its own
+func (r *RedisClient) ReserveRollingQuota(ctx context.Context, key, member string, limit int, period time.Duration) (bool, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "reserve_rolling_quota")
+	now := time.Now()
+	res, err := reserveRollingQuotaScript.Run(ctx, r.Client, []string{key}, limit, period.Milliseconds(), now.UnixMilli(), member).Int64()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res == 1, err
+}
+
+// RefundRollingQuota gives back the unit the member took from the quota at key, it returns how many units were given back
+func (r *RedisClient) RefundRollingQuota(ctx context.Context, key, member string) (int64, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "refund_rolling_quota")
+	res, err := r.Client.ZRem(ctx, key, member).Result()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res, err
+}
+
+// RefundRollingQuotaAt gives back the latest unit taken from the quota at key by the given time, for a reservation
+// whose member is no longer known. It returns how many units were given back
+func (r *RedisClient) RefundRollingQuotaAt(ctx context.Context, key string, at time.Time) (int64, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "refund_rolling_quota_at")
+	res, err := refundRollingQuotaAtScript.Run(ctx, r.Client, []string{key}, at.UnixMilli()).Int64()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	return res, err
+}
+
+// RollingQuotaUsage returns how many units were taken from the quota at key within the last period, and when the oldest was taken
+func (r *RedisClient) RollingQuotaUsage(ctx context.Context, key string, period time.Duration) (int64, time.Time, error) {
+	metricInfo := utils.NewClientMetric(r.Name, "rolling_quota_usage")
+	since := time.Now().Add(-period).UnixMilli()
+	entries, err := r.Client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: fmt.Sprintf("(%d", since),
+		Max: "+inf",
+	}).Result()
+	err = r.wrapError(err)
+	metricInfo.TrackClientWithError(err)
+	if err != nil || len(entries) == 0 {
+		return 0, time.Time{}, err
+	}
+
+	return int64(len(entries)), time.UnixMilli(int64(entries[0].Score)), nil
+}
+
 func (r *RedisClient) wrapError(err error) error {
 	if err != nil && !ignoredErrors[err.Error()] {
 		return err
@@ -2,8 +2,11 @@ package redis_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -305,6 +308,57 @@ func TestRedisClient_Get(t *testing.T) {
 	}
 }
 
+func TestRedisClient_GetDel(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		mockErr        string
+		expectedResult string
+		expectedExists bool
+		expectedError  error
+	}{
+		{
+			name:           "normal case with non existing key",
+			key:            testKey2,
+			expectedResult: "",
+			expectedExists: true,
+		},
+		{
+			name:           "normal case with existing key",
+			key:            testKey1,
+			expectedResult: testMember1,
+		},
+		{
+			name:           "error case",
+			key:            testKey1,
+			expectedResult: "",
+			expectedError:  errors.New("timeout"),
+			mockErr:        "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.Set(testKey1, testMember1)
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.GetDel(context.Background(), tc.key)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedExists, s.Exists(testKey1))
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
 func TestRedisClient_Del(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -357,3 +411,684 @@ func TestRedisClient_Del(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisClient_SAdd(t *testing.T) {
+	tests := []struct {
+		name           string
+		member         string
+		mockErr        string
+		expectedResult int64
+		expectedErr    error
+	}{
+		{
+			name:           "normal case adding a new member",
+			member:         "new",
+			expectedResult: int64(1),
+		},
+		{
+			name:           "normal case adding an existing member",
+			member:         testMember1,
+			expectedResult: int64(0),
+		},
+		{
+			name:        "error case",
+			member:      "new",
+			expectedErr: errors.New("timeout"),
+			mockErr:     "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			// insert some redis test values
+			s.SAdd(testKey1, testMember1)
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.SAdd(context.Background(), testKey1, tc.member)
+			if tc.expectedErr != nil {
+				assert.NotEqual(t, err, nil)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Equal(t, nil, err)
+				assert.Equal(t, tc.expectedResult, result)
+				s.SetError("")
+				isMember, _ := s.SIsMember(testKey1, tc.member)
+				assert.True(t, isMember)
+			}
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_Publish(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockErr        string
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name:           "normal case",
+			expectedResult: 1,
+		},
+		{
+			name:          "error case",
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			subscriber := s.NewSubscriber()
+			subscriber.Subscribe(testKey1)
+			defer subscriber.Close()
+			// the subscriber channel is unbuffered, so it has to be drained while publishing
+			received := make(chan string, 1)
+			go func() {
+				for message := range subscriber.Messages() {
+					received <- message.Message
+				}
+			}()
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.Publish(context.Background(), testKey1, testMember1)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, testMember1, <-received)
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_Subscribe(t *testing.T) {
+	t.Run("normal case", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		defer s.Close()
+		client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+		ctx := context.Background()
+
+		subscription := client.Subscribe(ctx)
+		defer subscription.Close()
+		messages := subscription.Channel()
+
+		// channels added after the subscription is made receive messages too
+		err := subscription.Subscribe(ctx, testKey1)
+		assert.Nil(t, err)
+		assert.Eventually(t, func() bool {
+			return s.PubSubNumSub(testKey1)[testKey1] == 1
+		}, time.Second, 10*time.Millisecond)
+
+		s.Publish(testKey1, testMember1)
+
+		message := <-messages
+		assert.Equal(t, testKey1, message.Channel)
+		assert.Equal(t, testMember1, message.Payload)
+	})
+}
+
+func TestRedisClient_PublishEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockErr       string
+		expectedError error
+	}{
+		{
+			name: "normal case",
+		},
+		{
+			name:          "error case",
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			subscriber := s.NewSubscriber()
+			subscriber.Subscribe(testKey1)
+			defer subscriber.Close()
+			received := make(chan string, 3)
+			go func() {
+				for message := range subscriber.Messages() {
+					received <- message.Message
+				}
+			}()
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			ctx := context.Background()
+			s.SetError(tc.mockErr)
+
+			ids := []string{}
+			for i := 0; i < 3; i++ {
+				id, err := client.PublishEvent(ctx, testKey2, testKey1, fmt.Sprintf(`{"type":"test","data":%d}`, i), 2, time.Minute)
+				if tc.expectedError != nil {
+					assert.NotNil(t, err)
+					assert.Contains(t, err.Error(), tc.expectedError.Error())
+					return
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, fmt.Sprintf(`{"id":"%s","type":"test","data":%d}`, id, i), <-received)
+				ids = append(ids, id)
+			}
+
+			// the stream is trimmed to the latest entries
+			entries, err := client.ReadEvents(ctx, testKey2, "0")
+			assert.Nil(t, err)
+			assert.Len(t, entries, 2)
+			assert.Equal(t, ids[1], entries[0].ID)
+			assert.Equal(t, `{"type":"test","data":2}`, entries[1].Values["payload"])
+			assert.Equal(t, time.Minute, s.TTL(testKey2))
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_PublishEvent_Envelope(t *testing.T) {
+	tests := []struct {
+		name             string
+		payload          string
+		expectedEnvelope string
+		expectedError    error
+	}{
+		{
+			name:             "object payload",
+			payload:          ` { "type": "test", "data": {"a": [1, 2]} }`,
+			expectedEnvelope: `{"id":"%s","type":"test","data":{"a":[1,2]}}`,
+		},
+		{
+			name:             "empty object payload",
+			payload:          `{}`,
+			expectedEnvelope: `{"id":"%s"}`,
+		},
+		{
+			name:          "array payload",
+			payload:       `[1, 2]`,
+			expectedError: redis.ErrEventPayload,
+		},
+		{
+			name:          "null payload",
+			payload:       `null`,
+			expectedError: redis.ErrEventPayload,
+		},
+		{
+			name:          "invalid payload",
+			payload:       `{"type":`,
+			expectedError: redis.ErrEventPayload,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			subscriber := s.NewSubscriber()
+			subscriber.Subscribe(testKey1)
+			defer subscriber.Close()
+			received := make(chan string, 1)
+			go func() {
+				for message := range subscriber.Messages() {
+					received <- message.Message
+				}
+			}()
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			id, err := client.PublishEvent(context.Background(), testKey2, testKey1, tc.payload, 2, time.Minute)
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				// nothing is logged
+				assert.False(t, s.Exists(testKey2))
+				return
+			}
+			assert.Nil(t, err)
+			message := <-received
+			assert.Equal(t, fmt.Sprintf(tc.expectedEnvelope, id), message)
+			assert.True(t, json.Valid([]byte(message)))
+		})
+	}
+}
+
+func TestRedisClient_ReadEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		afterID        string
+		mockErr        string
+		expectedResult []string
+		expectedError  error
+	}{
+		{
+			name:           "normal case from the start",
+			afterID:        "0",
+			expectedResult: []string{"1-1", "2-1"},
+		},
+		{
+			name:           "normal case after an entry",
+			afterID:        "1-1",
+			expectedResult: []string{"2-1"},
+		},
+		{
+			name:           "normal case after the latest entry",
+			afterID:        "2-1",
+			expectedResult: []string{},
+		},
+		{
+			name:          "error case",
+			afterID:       "0",
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.XAdd(testKey1, "1-1", []string{"payload", testMember1})
+			s.XAdd(testKey1, "2-1", []string{"payload", testMember2})
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.ReadEvents(context.Background(), testKey1, tc.afterID)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				ids := []string{}
+				for _, entry := range result {
+					ids = append(ids, entry.ID)
+				}
+				assert.Equal(t, tc.expectedResult, ids)
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_ReserveQuota(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		limit          int
+		expire         time.Duration
+		mockErr        string
+		expectedValue  string
+		expectedTTL    time.Duration
+		expectedResult bool
+		expectedError  error
+	}{
+		{
+			name:           "normal case with new key",
+			key:            testKey2,
+			limit:          3,
+			expire:         time.Minute,
+			expectedValue:  "1",
+			expectedTTL:    time.Minute,
+			expectedResult: true,
+		},
+		{
+			name:           "normal case with existing key under the limit",
+			key:            testKey1,
+			limit:          3,
+			expire:         time.Minute,
+			expectedValue:  "3",
+			expectedResult: true,
+		},
+		{
+			name:           "normal case with existing key at the limit",
+			key:            testKey1,
+			limit:          2,
+			expire:         time.Minute,
+			expectedValue:  "2",
+			expectedResult: false,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			limit:         3,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.Set(testKey1, "2")
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.ReserveQuota(context.Background(), tc.key, tc.limit, tc.expire)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				val, _ := s.Get(tc.key)
+				assert.Equal(t, tc.expectedValue, val)
+				assert.Equal(t, tc.expectedTTL, s.TTL(tc.key))
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_ReserveQuota_Concurrent(t *testing.T) {
+	s := miniredis.RunT(t)
+	defer s.Close()
+	client, _ := redis.NewClient(s.Host(), s.Port(), "1s", "0")
+
+	limit := 10
+	var reserved atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < limit*5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := client.ReserveQuota(context.Background(), testKey1, limit, time.Minute)
+			assert.Nil(t, err)
+			if ok {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(limit), reserved.Load())
+	val, _ := s.Get(testKey1)
+	assert.Equal(t, fmt.Sprintf("%d", limit), val)
+}
+
+func TestRedisClient_RefundQuota(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		mockErr        string
+		expectedValue  string
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name:           "normal case with existing key",
+			key:            testKey1,
+			expectedValue:  "1",
+			expectedResult: 1,
+		},
+		{
+			name:           "normal case with non existing key is not decremented",
+			key:            testKey2,
+			expectedValue:  "",
+			expectedResult: 0,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.Set(testKey1, "2")
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.RefundQuota(context.Background(), tc.key)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				val, _ := s.Get(tc.key)
+				assert.Equal(t, tc.expectedValue, val)
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_ReserveRollingQuota(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		key            string
+		limit          int
+		mockErr        string
+		expectedCount  int
+		expectedResult bool
+		expectedError  error
+	}{
+		{
+			name:           "normal case with new key",
+			key:            testKey2,
+			limit:          1,
+			expectedCount:  1,
+			expectedResult: true,
+		},
+		{
+			name:           "normal case with entries outside the period dropped",
+			key:            testKey1,
+			limit:          2,
+			expectedCount:  2,
+			expectedResult: true,
+		},
+		{
+			name:           "normal case with the limit reached within the period",
+			key:            testKey1,
+			limit:          1,
+			expectedCount:  1,
+			expectedResult: false,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			limit:         3,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.ZAdd(testKey1, float64(now.Add(-2*time.Hour).UnixMilli()), "old")
+			s.ZAdd(testKey1, float64(now.Add(-time.Minute).UnixMilli()), "recent")
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.ReserveRollingQuota(context.Background(), tc.key, "new", tc.limit, time.Hour)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				members, _ := s.ZMembers(tc.key)
+				assert.Len(t, members, tc.expectedCount)
+				assert.NotContains(t, members, "old")
+				if tc.expectedResult {
+					assert.Contains(t, members, "new")
+				}
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_RefundRollingQuota(t *testing.T) {
+	tests := []struct {
+		name            string
+		key             string
+		mockErr         string
+		expectedMembers []string
+		expectedResult  int64
+		expectedError   error
+	}{
+		{
+			name:            "normal case drops the reservation of the member, not the latest one",
+			key:             testKey1,
+			expectedMembers: []string{"second"},
+			expectedResult:  1,
+		},
+		{
+			name:           "normal case with non existing key",
+			key:            testKey2,
+			expectedResult: 0,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.ZAdd(testKey1, 1, "first")
+			s.ZAdd(testKey1, 2, "second")
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.RefundRollingQuota(context.Background(), tc.key, "first")
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				if tc.expectedMembers != nil {
+					members, _ := s.ZMembers(tc.key)
+					assert.Equal(t, tc.expectedMembers, members)
+				}
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_RefundRollingQuotaAt(t *testing.T) {
+	at := time.UnixMilli(2)
+	tests := []struct {
+		name            string
+		key             string
+		mockErr         string
+		expectedMembers []string
+		expectedResult  int64
+		expectedError   error
+	}{
+		{
+			name:            "normal case drops the latest reservation taken by then, not a later one",
+			key:             testKey1,
+			expectedMembers: []string{"first", "third"},
+			expectedResult:  1,
+		},
+		{
+			name:           "normal case with non existing key",
+			key:            testKey2,
+			expectedResult: 0,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.ZAdd(testKey1, 1, "first")
+			s.ZAdd(testKey1, 2, "second")
+			s.ZAdd(testKey1, 3, "third")
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			result, err := client.RefundRollingQuotaAt(context.Background(), tc.key, at)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				if tc.expectedMembers != nil {
+					members, _ := s.ZMembers(tc.key)
+					assert.Equal(t, tc.expectedMembers, members)
+				}
+			}
+
+			defer s.Close()
+		})
+	}
+}
+
+func TestRedisClient_RollingQuotaUsage(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		key            string
+		mockErr        string
+		expectedCount  int64
+		expectedOldest time.Time
+		expectedError  error
+	}{
+		{
+			name:           "normal case counts the entries within the period",
+			key:            testKey1,
+			expectedCount:  2,
+			expectedOldest: time.UnixMilli(now.Add(-30 * time.Minute).UnixMilli()),
+		},
+		{
+			name: "normal case with non existing key",
+			key:  testKey2,
+		},
+		{
+			name:          "error case",
+			key:           testKey1,
+			expectedError: errors.New("timeout"),
+			mockErr:       "timeout",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.ZAdd(testKey1, float64(now.Add(-2*time.Hour).UnixMilli()), "old")
+			s.ZAdd(testKey1, float64(now.Add(-30*time.Minute).UnixMilli()), "first")
+			s.ZAdd(testKey1, float64(now.Add(-time.Minute).UnixMilli()), "second")
+
+			client, _ := redis.NewClient(s.Host(), s.Port(), testRedisTimeout, "0")
+			s.SetError(tc.mockErr)
+
+			count, oldest, err := client.RollingQuotaUsage(context.Background(), tc.key, time.Hour)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+				assert.True(t, tc.expectedOldest.Equal(oldest))
+			}
+
+			defer s.Close()
+		})
+	}
+}
@@ -31,6 +31,8 @@ var (
 		Code:       "Unauthorized",
 		HttpStatus: http.StatusUnauthorized,
 	}
+
+	ErrorInvalidCursor = BadRequestParamError("Invalid cursor", "cursor")
 )
 
 func NewStandardError(message, code, field string) *StandardError {
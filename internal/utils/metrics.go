@@ -20,6 +20,21 @@ var TimbleClientDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Help: "track request duration to client",
 }, []string{"client_name", "action", "status", "http_status"})
 
+var TimbleBoostEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "timble_boost_events_total",
+	Help: "count boost activations, and the impressions and likes of boosted users",
+}, []string{"event"})
+
+var TimbleWebsocketConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "timble_websocket_connections",
+	Help: "track the WebSocket and SSE connections open on this instance",
+})
+
+var TimbleWebsocketSlowClients = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "timble_websocket_slow_clients_total",
+	Help: "count WebSocket and SSE connections dropped for not keeping up with their events",
+})
+
 const (
 	RequestStatusOK   = "ok"
 	RequestStatusFail = "fail"
@@ -45,6 +60,9 @@ func RegisterCustomMetrics(reg *prometheus.Registry) {
 	reg.MustRegister(
 		TimbleRestServiceDuration,
 		TimbleClientDuration,
+		TimbleBoostEvents,
+		TimbleWebsocketConnections,
+		TimbleWebsocketSlowClients,
 	)
 }
 
@@ -73,6 +73,38 @@ func Authentication(auth *AuthConfig) func(next http.Handler) http.Handler {
 	}
 }
 
+// WebSocketAuthentication also takes a connect ticket from the ticket query parameter, since browsers can not set
+// headers on a WebSocket handshake or an EventSource. A ticket is redeemed once and short-lived, so the URL never
+// carries a token worth logging
+func WebSocketAuthentication(auth *AuthConfig, redeemTicket func(ctx context.Context, ticket string) (uint, error)) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authenticate := Authentication(auth)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ticket := r.URL.Query().Get("ticket")
+			if r.Header.Get("Authorization") != "" || ticket == "" {
+				authenticate.ServeHTTP(w, r)
+				return
+			}
+
+			userID, err := redeemTicket(r.Context(), ticket)
+			if err != nil {
+				body := NewErrorResponse(ErrorInternalServerResponse, http.StatusInternalServerError)
+				body.WriteAPIResponse(w, r, http.StatusInternalServerError)
+				return
+			}
+
+			if userID == 0 {
+				authFailed(w)
+				return
+			}
+
+			// the same type the token claims hold
+			ctx := context.WithValue(r.Context(), CtxUserIDKey, float64(userID))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func authFailed(w http.ResponseWriter) {
 	errByte, _ := json.Marshal(ErrorUnauthenticated)
 	w.Header().Set("Content-Type", "application/json")
@@ -1,6 +1,8 @@
 package utils_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -185,6 +187,95 @@ func TestMiddleware_Authentication(t *testing.T) {
 	defer ts.Close()
 }
 
+func TestMiddleware_WebSocketAuthentication(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write([]byte(fmt.Sprintf("user: %v", r.Context().Value(utils.CtxUserIDKey))))
+	}
+
+	cfg := &utils.AuthConfig{
+		SecretKey: []byte("secretz"),
+		TokenExp:  time.Hour,
+	}
+	validToken, _ := cfg.GenerateToken(uint(1))
+	redeemTicket := func(ctx context.Context, ticket string) (uint, error) {
+		switch ticket {
+		case "validticket":
+			return 2, nil
+		case "failingticket":
+			return 0, errors.New("timeout")
+		}
+		return 0, nil
+	}
+
+	router := chi.NewRouter()
+	router.Route("/test", func(r chi.Router) {
+		r.Use(utils.WebSocketAuthentication(cfg, redeemTicket))
+		r.Get("/", testHandler)
+	})
+	ts := httptest.NewServer(router)
+
+	cases := []struct {
+		name               string
+		header             string
+		query              string
+		expectedResult     string
+		expectedHTTPStatus int
+	}{
+		{
+			name:               "normal case - token on header",
+			header:             validToken,
+			expectedResult:     "user: 1",
+			expectedHTTPStatus: 200,
+		},
+		{
+			name:               "normal case - ticket on query",
+			query:              "ticket=validticket",
+			expectedResult:     "user: 2",
+			expectedHTTPStatus: 200,
+		},
+		{
+			name:               "missing token case",
+			expectedResult:     `{"message":"Invalid or missing required authentication","code":"Unauthorized"}`,
+			expectedHTTPStatus: 401,
+		},
+		{
+			name:               "token on query is not accepted",
+			query:              "access_token=" + validToken,
+			expectedResult:     `{"message":"Invalid or missing required authentication","code":"Unauthorized"}`,
+			expectedHTTPStatus: 401,
+		},
+		{
+			name:               "unknown or redeemed ticket",
+			query:              "ticket=thisisinvalidticket",
+			expectedResult:     `{"message":"Invalid or missing required authentication","code":"Unauthorized"}`,
+			expectedHTTPStatus: 401,
+		},
+		{
+			name:               "error when redeeming the ticket",
+			query:              "ticket=failingticket",
+			expectedResult:     `{"meta":{"http_status":500},"error_detail":{"message":"internal server error, please check the server logs","code":"INTERNAL SERVER ERROR"}}`,
+			expectedHTTPStatus: 500,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", fmt.Sprintf("%s/test?%s", ts.URL, tc.query), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.header != "" {
+				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tc.header))
+			}
+			res, body := testCallRequest(t, ts, req)
+			assert.Equal(t, tc.expectedResult, body)
+			assert.Equal(t, tc.expectedHTTPStatus, res.StatusCode)
+		})
+	}
+	defer ts.Close()
+}
+
 func testRequest(t *testing.T, ts *httptest.Server, method, path string, body io.Reader) (*http.Response, string) {
 	return testRequestWithReqID(t, ts, method, path, body, "test/0000001")
 }
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// EVENT_LOG_MAX_LENGTH is about how many of the latest events of a user are kept for clients to resume from
+	EVENT_LOG_MAX_LENGTH = 100
+	// EVENT_LOG_TTL drops the event log of a user who got no event for that long
+	EVENT_LOG_TTL = 24 * time.Hour
+)
+
+// BuildNotificationChannel is the pub/sub channel the events of a user are published to,
+// every instance holding a connection of the user listens to it
+func BuildNotificationChannel(userID uint) string {
+	return fmt.Sprintf("notifications:%d", userID)
+}
+
+// BuildEventStreamKey is the Redis stream keeping the latest events of a user, see EVENT_LOG_MAX_LENGTH
+func BuildEventStreamKey(userID uint) string {
+	return fmt.Sprintf("events:%d", userID)
+}
@@ -0,0 +1,17 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/internal/utils"
+)
+
+func TestNotification_BuildNotificationChannel(t *testing.T) {
+	assert.Equal(t, "notifications:7", utils.BuildNotificationChannel(7))
+}
+
+func TestNotification_BuildEventStreamKey(t *testing.T) {
+	assert.Equal(t, "events:7", utils.BuildEventStreamKey(7))
+}
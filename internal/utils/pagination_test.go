@@ -0,0 +1,221 @@
+package utils_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"timble/internal/utils"
+)
+
+func TestCursorSigner_EncodeDecode(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	signer := utils.NewCursorSigner([]byte("secretz"))
+	otherSigner := utils.NewCursorSigner([]byte("another secret"))
+	cursor := utils.Cursor{CreatedAt: timestamp, ID: 7}
+
+	cases := []struct {
+		name           string
+		value          string
+		expectedResult *utils.Cursor
+		expectedError  error
+	}{
+		{
+			name:           "successfully decode cursor",
+			value:          signer.Encode(cursor),
+			expectedResult: &cursor,
+		},
+		{
+			name:          "cursor signed with another key",
+			value:         otherSigner.Encode(cursor),
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid cursor; field: cursor"),
+		},
+		{
+			name:          "cursor signed with the secret itself instead of the derived key",
+			value:         signWithSecret(cursor, []byte("secretz")),
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid cursor; field: cursor"),
+		},
+		{
+			name:          "malformed cursor",
+			value:         "not-a-cursor",
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid cursor; field: cursor"),
+		},
+		{
+			name:          "invalid payload encoding",
+			value:         "!!!." + "abc",
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid cursor; field: cursor"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := signer.Decode(tc.value)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.True(t, tc.expectedResult.CreatedAt.Equal(result.CreatedAt))
+				assert.Equal(t, tc.expectedResult.ID, result.ID)
+			}
+		})
+	}
+}
+
+func signWithSecret(cursor utils.Cursor, secretKey []byte) string {
+	payload, _ := json.Marshal(cursor)
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestCursorSigner_ParsePageParams(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	signer := utils.NewCursorSigner([]byte("secretz"))
+	cursor := utils.Cursor{CreatedAt: timestamp, ID: 7}
+
+	cases := []struct {
+		name           string
+		query          string
+		expectedResult utils.PageParams
+		expectedError  error
+	}{
+		{
+			name:           "default limit without cursor",
+			query:          "",
+			expectedResult: utils.PageParams{Limit: utils.DefaultPageLimit},
+		},
+		{
+			name:           "custom limit with cursor",
+			query:          "?limit=5&cursor=" + signer.Encode(cursor),
+			expectedResult: utils.PageParams{Limit: 5, Cursor: &cursor},
+		},
+		{
+			name:          "limit above maximum",
+			query:         "?limit=101",
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Limit must be between 1 and 100; field: limit"),
+		},
+		{
+			name:          "non numeric limit",
+			query:         "?limit=abc",
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Limit must be between 1 and 100; field: limit"),
+		},
+		{
+			name:          "invalid cursor",
+			query:         "?cursor=abc",
+			expectedError: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid cursor; field: cursor"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/list"+tc.query, nil)
+
+			result, err := signer.ParsePageParams(req)
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult.Limit, result.Limit)
+				if tc.expectedResult.Cursor != nil {
+					assert.Equal(t, tc.expectedResult.Cursor.ID, result.Cursor.ID)
+				} else {
+					assert.Nil(t, result.Cursor)
+				}
+			}
+		})
+	}
+}
+
+func TestCursorSigner_PaginatedMeta(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	signer := utils.NewCursorSigner([]byte("secretz"))
+	cursor := utils.Cursor{CreatedAt: timestamp, ID: 7}
+	hasMore := true
+	noMore := false
+
+	cases := []struct {
+		name     string
+		page     utils.Page
+		expected utils.Meta
+	}{
+		{
+			name: "page with more results",
+			page: utils.Page{HasMore: true, NextCursor: &cursor},
+			expected: utils.Meta{
+				HTTPStatus: http.StatusOK,
+				NextCursor: signer.Encode(cursor),
+				HasMore:    &hasMore,
+			},
+		},
+		{
+			name: "last page",
+			page: utils.Page{},
+			expected: utils.Meta{
+				HTTPStatus: http.StatusOK,
+				HasMore:    &noMore,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, signer.PaginatedMeta(http.StatusOK, tc.page))
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	key := func(id uint) utils.Cursor {
+		return utils.Cursor{CreatedAt: timestamp, ID: id}
+	}
+
+	cases := []struct {
+		name          string
+		items         []uint
+		limit         int
+		expectedItems []uint
+		expectedPage  utils.Page
+	}{
+		{
+			name:          "extra row means there is another page",
+			items:         []uint{5, 4, 3},
+			limit:         2,
+			expectedItems: []uint{5, 4},
+			expectedPage:  utils.Page{HasMore: true, NextCursor: &utils.Cursor{CreatedAt: timestamp, ID: 4}},
+		},
+		{
+			name:          "last page",
+			items:         []uint{5, 4},
+			limit:         2,
+			expectedItems: []uint{5, 4},
+			expectedPage:  utils.Page{},
+		},
+		{
+			name:          "empty page",
+			items:         []uint{},
+			limit:         2,
+			expectedItems: []uint{},
+			expectedPage:  utils.Page{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, page := utils.NewPage(tc.items, tc.limit, key)
+
+			assert.Equal(t, tc.expectedItems, items)
+			assert.Equal(t, tc.expectedPage, page)
+		})
+	}
+}
@@ -14,7 +14,9 @@ type Response struct {
 }
 
 type Meta struct {
-	HTTPStatus int `json:"http_status"`
+	HTTPStatus int    `json:"http_status"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    *bool  `json:"has_more,omitempty"`
 }
 
 func NewDataResponse(data any, meta Meta) *Response {
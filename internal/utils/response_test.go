@@ -143,6 +143,7 @@ func TestResponse_WriteAPIResponse(t *testing.T) {
 }
 
 func TestBodyResponse_ToBytes(t *testing.T) {
+	hasMore := true
 	cases := []struct {
 		name     string
 		response utils.Response
@@ -172,6 +173,14 @@ func TestBodyResponse_ToBytes(t *testing.T) {
 			},
 			expected: []byte("{\"meta\":{\"http_status\":400},\"error\":\"Everything's NOT fine\"}"),
 		},
+		{
+			name: "normal case with pagination",
+			response: utils.Response{
+				Data: []string{"Everything's fine"},
+				Meta: utils.Meta{HTTPStatus: 200, NextCursor: "abc.def", HasMore: &hasMore},
+			},
+			expected: []byte("{\"meta\":{\"http_status\":200,\"next_cursor\":\"abc.def\",\"has_more\":true},\"data\":[\"Everything's fine\"]}"),
+		},
 	}
 
 	for _, tc := range cases {
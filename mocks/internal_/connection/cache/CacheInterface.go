@@ -14,6 +14,24 @@ type CacheInterface struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, key
+func (_m *CacheInterface) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, key
 func (_m *CacheInterface) Get(ctx context.Context, key string) ([]byte, error) {
 	ret := _m.Called(ctx, key)
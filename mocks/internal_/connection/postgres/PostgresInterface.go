@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	postgres "timble/internal/connection/postgres"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // PostgresInterface is an autogenerated mock type for the PostgresInterface type
 type PostgresInterface struct {
@@ -51,6 +55,45 @@ func (_m *PostgresInterface) GetFirst(record interface{}, condition string, args
 	return r0
 }
 
+// Select provides a mock function with given fields: records, query, args
+func (_m *PostgresInterface) Select(records interface{}, query string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, records, query)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Select")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}, string, ...interface{}) error); ok {
+		r0 = rf(records, query, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transaction provides a mock function with given fields: fn
+func (_m *PostgresInterface) Transaction(fn func(postgres.PostgresInterface) error) error {
+	ret := _m.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(postgres.PostgresInterface) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPostgresInterface creates a new instance of PostgresInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPostgresInterface(t interface {
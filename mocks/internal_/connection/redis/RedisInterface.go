@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
+
+	v9 "github.com/redis/go-redis/v9"
 )
 
 // RedisInterface is an autogenerated mock type for the RedisInterface type
@@ -99,6 +101,34 @@ func (_m *RedisInterface) Get(ctx context.Context, key string) (string, error) {
 	return r0, r1
 }
 
+// GetDel provides a mock function with given fields: ctx, key
+func (_m *RedisInterface) GetDel(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDel")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Incr provides a mock function with given fields: ctx, key
 func (_m *RedisInterface) Incr(ctx context.Context, key string) (int64, error) {
 	ret := _m.Called(ctx, key)
@@ -127,6 +157,298 @@ func (_m *RedisInterface) Incr(ctx context.Context, key string) (int64, error) {
 	return r0, r1
 }
 
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *RedisInterface) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	ret := _m.Called(ctx, channel, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (int64, error)); ok {
+		return rf(ctx, channel, message)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) int64); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, channel, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishEvent provides a mock function with given fields: ctx, stream, channel, payload, maxLen, ttl
+func (_m *RedisInterface) PublishEvent(ctx context.Context, stream string, channel string, payload string, maxLen int64, ttl time.Duration) (string, error) {
+	ret := _m.Called(ctx, stream, channel, payload, maxLen, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishEvent")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64, time.Duration) (string, error)); ok {
+		return rf(ctx, stream, channel, payload, maxLen, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int64, time.Duration) string); ok {
+		r0 = rf(ctx, stream, channel, payload, maxLen, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, stream, channel, payload, maxLen, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadEvents provides a mock function with given fields: ctx, stream, afterID
+func (_m *RedisInterface) ReadEvents(ctx context.Context, stream string, afterID string) ([]v9.XMessage, error) {
+	ret := _m.Called(ctx, stream, afterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadEvents")
+	}
+
+	var r0 []v9.XMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v9.XMessage, error)); ok {
+		return rf(ctx, stream, afterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v9.XMessage); ok {
+		r0 = rf(ctx, stream, afterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v9.XMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, stream, afterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundQuota provides a mock function with given fields: ctx, key
+func (_m *RedisInterface) RefundQuota(ctx context.Context, key string) (int64, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundQuota")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundRollingQuota provides a mock function with given fields: ctx, key, member
+func (_m *RedisInterface) RefundRollingQuota(ctx context.Context, key string, member string) (int64, error) {
+	ret := _m.Called(ctx, key, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundRollingQuota")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, key, member)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, key, member)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundRollingQuotaAt provides a mock function with given fields: ctx, key, at
+func (_m *RedisInterface) RefundRollingQuotaAt(ctx context.Context, key string, at time.Time) (int64, error) {
+	ret := _m.Called(ctx, key, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundRollingQuotaAt")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return rf(ctx, key, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, key, at)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, key, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveQuota provides a mock function with given fields: ctx, key, limit, expire
+func (_m *RedisInterface) ReserveQuota(ctx context.Context, key string, limit int, expire time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, limit, expire)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveQuota")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, limit, expire)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) bool); ok {
+		r0 = rf(ctx, key, limit, expire)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Duration) error); ok {
+		r1 = rf(ctx, key, limit, expire)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveRollingQuota provides a mock function with given fields: ctx, key, member, limit, period
+func (_m *RedisInterface) ReserveRollingQuota(ctx context.Context, key string, member string, limit int, period time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, member, limit, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveRollingQuota")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, member, limit, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, time.Duration) bool); ok {
+		r0 = rf(ctx, key, member, limit, period)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, time.Duration) error); ok {
+		r1 = rf(ctx, key, member, limit, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RollingQuotaUsage provides a mock function with given fields: ctx, key, period
+func (_m *RedisInterface) RollingQuotaUsage(ctx context.Context, key string, period time.Duration) (int64, time.Time, error) {
+	ret := _m.Called(ctx, key, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollingQuotaUsage")
+	}
+
+	var r0 int64
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, time.Time, error)); ok {
+		return rf(ctx, key, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, period)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) time.Time); ok {
+		r1 = rf(ctx, key, period)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, period)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SAdd provides a mock function with given fields: ctx, key, members
+func (_m *RedisInterface) SAdd(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, members...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SAdd")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(ctx, key, members...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(ctx, key, members...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Set provides a mock function with given fields: ctx, key, value, expire
 func (_m *RedisInterface) Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error) {
 	ret := _m.Called(ctx, key, value, expire)
@@ -155,6 +477,33 @@ func (_m *RedisInterface) Set(ctx context.Context, key string, value interface{}
 	return r0, r1
 }
 
+// Subscribe provides a mock function with given fields: ctx, channels
+func (_m *RedisInterface) Subscribe(ctx context.Context, channels ...string) *v9.PubSub {
+	_va := make([]interface{}, len(channels))
+	for _i := range channels {
+		_va[_i] = channels[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *v9.PubSub
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) *v9.PubSub); ok {
+		r0 = rf(ctx, channels...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v9.PubSub)
+		}
+	}
+
+	return r0
+}
+
 // NewRedisInterface creates a new instance of RedisInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRedisInterface(t interface {
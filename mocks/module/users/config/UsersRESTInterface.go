@@ -13,11 +13,46 @@ type UsersRESTInterface struct {
 	mock.Mock
 }
 
+// ActivateBoost provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ActivateBoost(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Block provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Block(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Blocks provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Blocks(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// BoostStatus provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) BoostStatus(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ClaimReport provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ClaimReport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // Create provides a mock function with given fields: w, r
 func (_m *UsersRESTInterface) Create(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// Discover provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Discover(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// DismissReport provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) DismissReport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // GrantPremium provides a mock function with given fields: w, r
 func (_m *UsersRESTInterface) GrantPremium(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -28,21 +63,86 @@ func (_m *UsersRESTInterface) Login(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// Matches provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Matches(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Profile provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Profile(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Quota provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Quota(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // React provides a mock function with given fields: w, r
 func (_m *UsersRESTInterface) React(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// ReactBatch provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ReactBatch(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ReactionHistory provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ReactionHistory(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ReceivedLikes provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ReceivedLikes(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Report provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Report(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ReportQueue provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ReportQueue(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ResolveReport provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // Show provides a mock function with given fields: w, r
 func (_m *UsersRESTInterface) Show(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// Unblock provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Unblock(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// UndoReaction provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) UndoReaction(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// Unmatch provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) Unmatch(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // UnsubscribePremium provides a mock function with given fields: w, r
 func (_m *UsersRESTInterface) UnsubscribePremium(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
 }
 
+// UpdateTimezone provides a mock function with given fields: w, r
+func (_m *UsersRESTInterface) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
 // NewUsersRESTInterface creates a new instance of UsersRESTInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUsersRESTInterface(t interface {
@@ -6,11 +6,577 @@ import (
 	entity "timble/module/users/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
-// PostgresRepository is an autogenerated mock type for the PostgresRepository type
-type PostgresRepository struct {
-	mock.Mock
+// PostgresRepository is an autogenerated mock type for the PostgresRepository type
+type PostgresRepository struct {
+	mock.Mock
+}
+
+// ActivateBoost provides a mock function with given fields: userID, duration
+func (_m *PostgresRepository) ActivateBoost(userID uint, duration time.Duration) (*entity.Boost, error) {
+	ret := _m.Called(userID, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateBoost")
+	}
+
+	var r0 *entity.Boost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Duration) (*entity.Boost, error)); ok {
+		return rf(userID, duration)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Duration) *entity.Boost); ok {
+		r0 = rf(userID, duration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Boost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Duration) error); ok {
+		r1 = rf(userID, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimReport provides a mock function with given fields: params, claimTTL
+func (_m *PostgresRepository) ClaimReport(params entity.ReportActionParams, claimTTL time.Duration) (*entity.Report, error) {
+	ret := _m.Called(params, claimTTL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimReport")
+	}
+
+	var r0 *entity.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReportActionParams, time.Duration) (*entity.Report, error)); ok {
+		return rf(params, claimTTL)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReportActionParams, time.Duration) *entity.Report); ok {
+		r0 = rf(params, claimTTL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.ReportActionParams, time.Duration) error); ok {
+		r1 = rf(params, claimTTL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseReport provides a mock function with given fields: params, status, threshold
+func (_m *PostgresRepository) CloseReport(params entity.ReportActionParams, status string, threshold int) (*entity.Report, error) {
+	ret := _m.Called(params, status, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseReport")
+	}
+
+	var r0 *entity.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReportActionParams, string, int) (*entity.Report, error)); ok {
+		return rf(params, status, threshold)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReportActionParams, string, int) *entity.Report); ok {
+		r0 = rf(params, status, threshold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.ReportActionParams, string, int) error); ok {
+		r1 = rf(params, status, threshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountReceivedLikes provides a mock function with given fields: userID
+func (_m *PostgresRepository) CountReceivedLikes(userID uint) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReceivedLikes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBlock provides a mock function with given fields: params
+func (_m *PostgresRepository) DeleteBlock(params entity.BlockParams) (bool, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.BlockParams) (bool, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.BlockParams) bool); ok {
+		r0 = rf(params)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.BlockParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndMatch provides a mock function with given fields: params
+func (_m *PostgresRepository) EndMatch(params entity.UnmatchParams) (*entity.Match, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndMatch")
+	}
+
+	var r0 *entity.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.UnmatchParams) (*entity.Match, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.UnmatchParams) *entity.Match); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.UnmatchParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveBoost provides a mock function with given fields: userID
+func (_m *PostgresRepository) GetActiveBoost(userID uint) (*entity.Boost, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveBoost")
+	}
+
+	var r0 *entity.Boost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*entity.Boost, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *entity.Boost); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Boost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockedUserIDs provides a mock function with given fields: userID
+func (_m *PostgresRepository) GetBlockedUserIDs(userID uint) ([]uint, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockedUserIDs")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]uint, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []uint); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlocks provides a mock function with given fields: params
+func (_m *PostgresRepository) GetBlocks(params entity.BlockListParams) ([]entity.BlockListItem, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlocks")
+	}
+
+	var r0 []entity.BlockListItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.BlockListParams) ([]entity.BlockListItem, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.BlockListParams) []entity.BlockListItem); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.BlockListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.BlockListParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCandidateByID provides a mock function with given fields: id
+func (_m *PostgresRepository) GetCandidateByID(id uint) (*entity.Candidate, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCandidateByID")
+	}
+
+	var r0 *entity.Candidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*entity.Candidate, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *entity.Candidate); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Candidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCandidates provides a mock function with given fields: userID, limit
+func (_m *PostgresRepository) GetCandidates(userID uint, limit int) ([]entity.Candidate, error) {
+	ret := _m.Called(userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCandidates")
+	}
+
+	var r0 []entity.Candidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, int) ([]entity.Candidate, error)); ok {
+		return rf(userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, int) []entity.Candidate); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Candidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastReaction provides a mock function with given fields: userID
+func (_m *PostgresRepository) GetLastReaction(userID uint) (*entity.UserReaction, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastReaction")
+	}
+
+	var r0 *entity.UserReaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*entity.UserReaction, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *entity.UserReaction); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserReaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMatches provides a mock function with given fields: params
+func (_m *PostgresRepository) GetMatches(params entity.MatchListParams) ([]entity.MatchListItem, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMatches")
+	}
+
+	var r0 []entity.MatchListItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.MatchListParams) ([]entity.MatchListItem, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.MatchListParams) []entity.MatchListItem); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.MatchListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.MatchListParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPassedCandidates provides a mock function with given fields: userID, cooldown, limit
+func (_m *PostgresRepository) GetPassedCandidates(userID uint, cooldown time.Duration, limit int) ([]entity.Candidate, error) {
+	ret := _m.Called(userID, cooldown, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPassedCandidates")
+	}
+
+	var r0 []entity.Candidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Duration, int) ([]entity.Candidate, error)); ok {
+		return rf(userID, cooldown, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Duration, int) []entity.Candidate); ok {
+		r0 = rf(userID, cooldown, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Candidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Duration, int) error); ok {
+		r1 = rf(userID, cooldown, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReactionHistory provides a mock function with given fields: params
+func (_m *PostgresRepository) GetReactionHistory(params entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReactionHistory")
+	}
+
+	var r0 []entity.ReactionHistoryItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReactionHistoryParams) []entity.ReactionHistoryItem); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReactionHistoryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.ReactionHistoryParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReactionsAfter provides a mock function with given fields: after, settle, limit
+func (_m *PostgresRepository) GetReactionsAfter(after entity.UserReaction, settle time.Duration, limit int) ([]entity.UserReaction, error) {
+	ret := _m.Called(after, settle, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReactionsAfter")
+	}
+
+	var r0 []entity.UserReaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.UserReaction, time.Duration, int) ([]entity.UserReaction, error)); ok {
+		return rf(after, settle, limit)
+	}
+	if rf, ok := ret.Get(0).(func(entity.UserReaction, time.Duration, int) []entity.UserReaction); ok {
+		r0 = rf(after, settle, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserReaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.UserReaction, time.Duration, int) error); ok {
+		r1 = rf(after, settle, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceivedLikes provides a mock function with given fields: params
+func (_m *PostgresRepository) GetReceivedLikes(params entity.ReceivedLikesParams) ([]entity.ReceivedLike, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceivedLikes")
+	}
+
+	var r0 []entity.ReceivedLike
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReceivedLikesParams) ([]entity.ReceivedLike, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReceivedLikesParams) []entity.ReceivedLike); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReceivedLike)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.ReceivedLikesParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReports provides a mock function with given fields: params
+func (_m *PostgresRepository) GetReports(params entity.ReportQueueParams) ([]entity.ReportQueueItem, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReports")
+	}
+
+	var r0 []entity.ReportQueueItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReportQueueParams) ([]entity.ReportQueueItem, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReportQueueParams) []entity.ReportQueueItem); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReportQueueItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.ReportQueueParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScoreCheckpoint provides a mock function with no fields
+func (_m *PostgresRepository) GetScoreCheckpoint() (entity.UserReaction, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScoreCheckpoint")
+	}
+
+	var r0 entity.UserReaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (entity.UserReaction, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() entity.UserReaction); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(entity.UserReaction)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetUserByID provides a mock function with given fields: id
@@ -73,6 +639,190 @@ func (_m *PostgresRepository) GetUserByUsername(username string) (*entity.User,
 	return r0, r1
 }
 
+// GetUserReaction provides a mock function with given fields: userID, targetID
+func (_m *PostgresRepository) GetUserReaction(userID uint, targetID uint) (*entity.UserReaction, error) {
+	ret := _m.Called(userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserReaction")
+	}
+
+	var r0 *entity.UserReaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*entity.UserReaction, error)); ok {
+		return rf(userID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *entity.UserReaction); ok {
+		r0 = rf(userID, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserReaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(userID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserScores provides a mock function with given fields: userIDs
+func (_m *PostgresRepository) GetUserScores(userIDs []uint) ([]entity.UserScore, error) {
+	ret := _m.Called(userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserScores")
+	}
+
+	var r0 []entity.UserScore
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]uint) ([]entity.UserScore, error)); ok {
+		return rf(userIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]uint) []entity.UserScore); ok {
+		r0 = rf(userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.UserScore)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]uint) error); ok {
+		r1 = rf(userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantPremium provides a mock function with given fields: userID, boosts
+func (_m *PostgresRepository) GrantPremium(userID uint, boosts int) error {
+	ret := _m.Called(userID, boosts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantPremium")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, int) error); ok {
+		r0 = rf(userID, boosts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementBoostImpressions provides a mock function with given fields: boostIDs
+func (_m *PostgresRepository) IncrementBoostImpressions(boostIDs []uint) error {
+	ret := _m.Called(boostIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementBoostImpressions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]uint) error); ok {
+		r0 = rf(boostIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementBoostLikes provides a mock function with given fields: userID
+func (_m *PostgresRepository) IncrementBoostLikes(userID uint) (bool, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementBoostLikes")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (bool, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertBlock provides a mock function with given fields: params
+func (_m *PostgresRepository) InsertBlock(params entity.BlockParams) (*entity.Match, error) {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertBlock")
+	}
+
+	var r0 *entity.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.BlockParams) (*entity.Match, error)); ok {
+		return rf(params)
+	}
+	if rf, ok := ret.Get(0).(func(entity.BlockParams) *entity.Match); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.BlockParams) error); ok {
+		r1 = rf(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertReport provides a mock function with given fields: params, threshold
+func (_m *PostgresRepository) InsertReport(params entity.ReportParams, threshold int) (*entity.Report, error) {
+	ret := _m.Called(params, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertReport")
+	}
+
+	var r0 *entity.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReportParams, int) (*entity.Report, error)); ok {
+		return rf(params, threshold)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReportParams, int) *entity.Report); ok {
+		r0 = rf(params, threshold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.ReportParams, int) error); ok {
+		r1 = rf(params, threshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InsertUser provides a mock function with given fields: user
 func (_m *PostgresRepository) InsertUser(user entity.User) error {
 	ret := _m.Called(user)
@@ -91,6 +841,64 @@ func (_m *PostgresRepository) InsertUser(user entity.User) error {
 	return r0
 }
 
+// RevertUserReaction provides a mock function with given fields: reaction
+func (_m *PostgresRepository) RevertUserReaction(reaction entity.UserReaction) (*entity.Match, error) {
+	ret := _m.Called(reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertUserReaction")
+	}
+
+	var r0 *entity.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.UserReaction) (*entity.Match, error)); ok {
+		return rf(reaction)
+	}
+	if rf, ok := ret.Get(0).(func(entity.UserReaction) *entity.Match); ok {
+		r0 = rf(reaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.UserReaction) error); ok {
+		r1 = rf(reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveUserScores provides a mock function with given fields: scores, after, last
+func (_m *PostgresRepository) SaveUserScores(scores []entity.UserScore, after entity.UserReaction, last entity.UserReaction) (bool, error) {
+	ret := _m.Called(scores, after, last)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUserScores")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]entity.UserScore, entity.UserReaction, entity.UserReaction) (bool, error)); ok {
+		return rf(scores, after, last)
+	}
+	if rf, ok := ret.Get(0).(func([]entity.UserScore, entity.UserReaction, entity.UserReaction) bool); ok {
+		r0 = rf(scores, after, last)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func([]entity.UserScore, entity.UserReaction, entity.UserReaction) error); ok {
+		r1 = rf(scores, after, last)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUserPremium provides a mock function with given fields: user, value
 func (_m *PostgresRepository) UpdateUserPremium(user entity.User, value interface{}) error {
 	ret := _m.Called(user, value)
@@ -109,22 +917,82 @@ func (_m *PostgresRepository) UpdateUserPremium(user entity.User, value interfac
 	return r0
 }
 
+// UpdateUserTimezone provides a mock function with given fields: params
+func (_m *PostgresRepository) UpdateUserTimezone(params entity.UserTimezoneParams) error {
+	ret := _m.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserTimezone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.UserTimezoneParams) error); ok {
+		r0 = rf(params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertUserReaction provides a mock function with given fields: reaction
-func (_m *PostgresRepository) UpsertUserReaction(reaction entity.ReactionParams) error {
+func (_m *PostgresRepository) UpsertUserReaction(reaction entity.ReactionParams) (*entity.Match, error) {
 	ret := _m.Called(reaction)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpsertUserReaction")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(entity.ReactionParams) error); ok {
+	var r0 *entity.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func(entity.ReactionParams) (*entity.Match, error)); ok {
+		return rf(reaction)
+	}
+	if rf, ok := ret.Get(0).(func(entity.ReactionParams) *entity.Match); ok {
 		r0 = rf(reaction)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Match)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(entity.ReactionParams) error); ok {
+		r1 = rf(reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertUserReactions provides a mock function with given fields: reactions
+func (_m *PostgresRepository) UpsertUserReactions(reactions []entity.ReactionParams) ([]*entity.Match, error) {
+	ret := _m.Called(reactions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertUserReactions")
+	}
+
+	var r0 []*entity.Match
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]entity.ReactionParams) ([]*entity.Match, error)); ok {
+		return rf(reactions)
+	}
+	if rf, ok := ret.Get(0).(func([]entity.ReactionParams) []*entity.Match); ok {
+		r0 = rf(reactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Match)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]entity.ReactionParams) error); ok {
+		r1 = rf(reactions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewPostgresRepository creates a new instance of PostgresRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
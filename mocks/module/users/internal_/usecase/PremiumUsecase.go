@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	entity "timble/module/users/entity"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,6 +14,62 @@ type PremiumUsecase struct {
 	mock.Mock
 }
 
+// ActivateBoost provides a mock function with given fields: ctx, userID
+func (_m *PremiumUsecase) ActivateBoost(ctx context.Context, userID uint) (entity.Boost, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateBoost")
+	}
+
+	var r0 entity.Boost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (entity.Boost, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.Boost); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.Boost)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BoostStatus provides a mock function with given fields: ctx, userID
+func (_m *PremiumUsecase) BoostStatus(ctx context.Context, userID uint) (entity.BoostStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BoostStatus")
+	}
+
+	var r0 entity.BoostStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (entity.BoostStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.BoostStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.BoostStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Grant provides a mock function with given fields: ctx, userID
 func (_m *PremiumUsecase) Grant(ctx context.Context, userID uint) error {
 	ret := _m.Called(ctx, userID)
@@ -14,6 +14,34 @@ type RedisRepository struct {
 	mock.Mock
 }
 
+// AddToSet provides a mock function with given fields: ctx, key, member, expire
+func (_m *RedisRepository) AddToSet(ctx context.Context, key string, member string, expire time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, member, expire)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddToSet")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, member, expire)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, member, expire)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, member, expire)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Del provides a mock function with given fields: ctx, key
 func (_m *RedisRepository) Del(ctx context.Context, key string) (int64, error) {
 	ret := _m.Called(ctx, key)
@@ -70,6 +98,34 @@ func (_m *RedisRepository) Get(ctx context.Context, key string) (string, error)
 	return r0, r1
 }
 
+// GetDel provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) GetDel(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDel")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Incr provides a mock function with given fields: ctx, key, expire
 func (_m *RedisRepository) Incr(ctx context.Context, key string, expire time.Duration) (int64, error) {
 	ret := _m.Called(ctx, key, expire)
@@ -98,6 +154,209 @@ func (_m *RedisRepository) Incr(ctx context.Context, key string, expire time.Dur
 	return r0, r1
 }
 
+// PublishEvent provides a mock function with given fields: ctx, userID, payload
+func (_m *RedisRepository) PublishEvent(ctx context.Context, userID uint, payload string) (string, error) {
+	ret := _m.Called(ctx, userID, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishEvent")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (string, error)); ok {
+		return rf(ctx, userID, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) string); ok {
+		r0 = rf(ctx, userID, payload)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, userID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundQuota provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) RefundQuota(ctx context.Context, key string) (int64, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundQuota")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundRollingQuota provides a mock function with given fields: ctx, key, member
+func (_m *RedisRepository) RefundRollingQuota(ctx context.Context, key string, member string) (int64, error) {
+	ret := _m.Called(ctx, key, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundRollingQuota")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, key, member)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, key, member)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundRollingQuotaAt provides a mock function with given fields: ctx, key, at
+func (_m *RedisRepository) RefundRollingQuotaAt(ctx context.Context, key string, at time.Time) (int64, error) {
+	ret := _m.Called(ctx, key, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundRollingQuotaAt")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return rf(ctx, key, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, key, at)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, key, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveQuota provides a mock function with given fields: ctx, key, limit, expire
+func (_m *RedisRepository) ReserveQuota(ctx context.Context, key string, limit int, expire time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, limit, expire)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveQuota")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, limit, expire)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) bool); ok {
+		r0 = rf(ctx, key, limit, expire)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Duration) error); ok {
+		r1 = rf(ctx, key, limit, expire)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveRollingQuota provides a mock function with given fields: ctx, key, member, limit, period
+func (_m *RedisRepository) ReserveRollingQuota(ctx context.Context, key string, member string, limit int, period time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, member, limit, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveRollingQuota")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, member, limit, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, time.Duration) bool); ok {
+		r0 = rf(ctx, key, member, limit, period)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, time.Duration) error); ok {
+		r1 = rf(ctx, key, member, limit, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RollingQuotaUsage provides a mock function with given fields: ctx, key, period
+func (_m *RedisRepository) RollingQuotaUsage(ctx context.Context, key string, period time.Duration) (int64, time.Time, error) {
+	ret := _m.Called(ctx, key, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollingQuotaUsage")
+	}
+
+	var r0 int64
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, time.Time, error)); ok {
+		return rf(ctx, key, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, period)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) time.Time); ok {
+		r1 = rf(ctx, key, period)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, period)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Set provides a mock function with given fields: ctx, key, value, expire
 func (_m *RedisRepository) Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error) {
 	ret := _m.Called(ctx, key, value, expire)
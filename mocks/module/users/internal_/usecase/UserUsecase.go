@@ -7,6 +7,8 @@ import (
 	entity "timble/module/users/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	utils "timble/internal/utils"
 )
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
@@ -14,6 +16,61 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// Block provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) Block(ctx context.Context, params entity.BlockParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BlockParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Blocks provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) Blocks(ctx context.Context, params entity.BlockListParams) ([]entity.BlockListItem, utils.Page, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Blocks")
+	}
+
+	var r0 []entity.BlockListItem
+	var r1 utils.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BlockListParams) ([]entity.BlockListItem, utils.Page, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BlockListParams) []entity.BlockListItem); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.BlockListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.BlockListParams) utils.Page); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(utils.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.BlockListParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, params
 func (_m *UserUsecase) Create(ctx context.Context, params entity.UserRegistrationParams) (entity.UserToken, error) {
 	ret := _m.Called(ctx, params)
@@ -42,22 +99,229 @@ func (_m *UserUsecase) Create(ctx context.Context, params entity.UserRegistratio
 	return r0, r1
 }
 
+// Matches provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) Matches(ctx context.Context, params entity.MatchListParams) ([]entity.MatchListItem, utils.Page, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Matches")
+	}
+
+	var r0 []entity.MatchListItem
+	var r1 utils.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.MatchListParams) ([]entity.MatchListItem, utils.Page, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.MatchListParams) []entity.MatchListItem); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.MatchListItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.MatchListParams) utils.Page); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(utils.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.MatchListParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Profile provides a mock function with given fields: ctx, userID, targetID
+func (_m *UserUsecase) Profile(ctx context.Context, userID uint, targetID uint) (*entity.UserProfile, error) {
+	ret := _m.Called(ctx, userID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Profile")
+	}
+
+	var r0 *entity.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.UserProfile, error)); ok {
+		return rf(ctx, userID, targetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.UserProfile); ok {
+		r0 = rf(ctx, userID, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Quota provides a mock function with given fields: ctx, userID
+func (_m *UserUsecase) Quota(ctx context.Context, userID uint) (entity.QuotaSummary, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quota")
+	}
+
+	var r0 entity.QuotaSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (entity.QuotaSummary, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) entity.QuotaSummary); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.QuotaSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // React provides a mock function with given fields: ctx, params
-func (_m *UserUsecase) React(ctx context.Context, params entity.ReactionParams) error {
+func (_m *UserUsecase) React(ctx context.Context, params entity.ReactionParams) (entity.ReactionResult, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for React")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.ReactionParams) error); ok {
+	var r0 entity.ReactionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReactionParams) (entity.ReactionResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReactionParams) entity.ReactionResult); ok {
 		r0 = rf(ctx, params)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entity.ReactionResult)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReactionParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReactBatch provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) ReactBatch(ctx context.Context, params entity.BatchReactionParams) ([]entity.BatchReactionResult, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactBatch")
+	}
+
+	var r0 []entity.BatchReactionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BatchReactionParams) ([]entity.BatchReactionResult, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BatchReactionParams) []entity.BatchReactionResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.BatchReactionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.BatchReactionParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReactionHistory provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) ReactionHistory(ctx context.Context, params entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, utils.Page, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactionHistory")
+	}
+
+	var r0 []entity.ReactionHistoryItem
+	var r1 utils.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, utils.Page, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReactionHistoryParams) []entity.ReactionHistoryItem); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ReactionHistoryItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReactionHistoryParams) utils.Page); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(utils.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.ReactionHistoryParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReceivedLikes provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) ReceivedLikes(ctx context.Context, params entity.ReceivedLikesParams) (entity.ReceivedLikes, utils.Page, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReceivedLikes")
+	}
+
+	var r0 entity.ReceivedLikes
+	var r1 utils.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceivedLikesParams) (entity.ReceivedLikes, utils.Page, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ReceivedLikesParams) entity.ReceivedLikes); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(entity.ReceivedLikes)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ReceivedLikesParams) utils.Page); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(utils.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.ReceivedLikesParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // Show provides a mock function with given fields: ctx, userID
@@ -90,6 +354,102 @@ func (_m *UserUsecase) Show(ctx context.Context, userID uint) (*entity.UserPubli
 	return r0, r1
 }
 
+// Unblock provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) Unblock(ctx context.Context, params entity.BlockParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unblock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BlockParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UndoReaction provides a mock function with given fields: ctx, userID
+func (_m *UserUsecase) UndoReaction(ctx context.Context, userID uint) (*entity.UserReaction, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoReaction")
+	}
+
+	var r0 *entity.UserReaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.UserReaction, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.UserReaction); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserReaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unmatch provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) Unmatch(ctx context.Context, params entity.UnmatchParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unmatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UnmatchParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTimezone provides a mock function with given fields: ctx, params
+func (_m *UserUsecase) UpdateTimezone(ctx context.Context, params entity.UserTimezoneParams) (*entity.UserPublic, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTimezone")
+	}
+
+	var r0 *entity.UserPublic
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserTimezoneParams) (*entity.UserPublic, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserTimezoneParams) *entity.UserPublic); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserPublic)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserTimezoneParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserUsecase creates a new instance of UserUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUsecase(t interface {
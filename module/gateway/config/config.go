@@ -0,0 +1,30 @@
+package config
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	redis "timble/internal/connection/redis"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/handler"
+	"timble/module/gateway/internal/repository"
+	"timble/module/gateway/internal/usecase"
+)
+
+// rest handler
+type GatewayRESTInterface interface {
+	Connect(w http.ResponseWriter, r *http.Request)
+	Events(w http.ResponseWriter, r *http.Request)
+	Ticket(w http.ResponseWriter, r *http.Request)
+}
+
+// NewGatewayHandler starts delivering the published events to the connections of this instance
+func NewGatewayHandler(logger *zap.Logger, redisClient redis.RedisInterface, settings entity.Settings) *handler.GatewayResource {
+	redisRepository := repository.NewRedisRepository(redisClient)
+
+	gatewayUsecase := usecase.NewGatewayUsecase(settings, redisRepository, logger)
+	go gatewayUsecase.Run()
+
+	return handler.NewGatewayResource(gatewayUsecase, settings, logger)
+}
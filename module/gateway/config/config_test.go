@@ -0,0 +1,27 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	redis "timble/internal/connection/redis"
+	"timble/module/gateway/config"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/handler"
+)
+
+func TestNewGatewayHandler(t *testing.T) {
+	s := miniredis.RunT(t)
+	defer s.Close()
+	t.Run("normal case", func(t *testing.T) {
+		redisClient, _ := redis.NewClient(s.Host(), s.Port(), "200ms", "0")
+
+		result := config.NewGatewayHandler(&zap.Logger{}, redisClient, entity.Settings{})
+
+		assert.NotNil(t, result)
+		assert.IsType(t, &handler.GatewayResource{}, result)
+	})
+}
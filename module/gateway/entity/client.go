@@ -0,0 +1,15 @@
+package entity
+
+// Client is a connection of a user, events wait on Events until the connection writes them.
+// Events is closed once the client is disconnected
+type Client struct {
+	UserID uint
+	Events chan []byte
+}
+
+func NewClient(userID uint, sendBuffer int) *Client {
+	return &Client{
+		UserID: userID,
+		Events: make(chan []byte, sendBuffer),
+	}
+}
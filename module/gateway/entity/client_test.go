@@ -0,0 +1,16 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/gateway/entity"
+)
+
+func TestClient_NewClient(t *testing.T) {
+	client := entity.NewClient(7, 2)
+
+	assert.Equal(t, uint(7), client.UserID)
+	assert.Equal(t, 2, cap(client.Events))
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"timble/internal/utils"
+)
+
+var (
+	eventIDPattern = regexp.MustCompile(`^\d+(-\d+)?$`)
+)
+
+// Event is an event of a user, ID is where it was logged in their event log
+type Event struct {
+	ID   string
+	Data []byte
+}
+
+// NewLiveEvent reads a published event, which carries the ID it was logged under
+func NewLiveEvent(payload []byte) Event {
+	logged := struct {
+		ID string `json:"id"`
+	}{}
+	json.Unmarshal(payload, &logged)
+
+	return Event{
+		ID:   logged.ID,
+		Data: payload,
+	}
+}
+
+// ParseEventID checks the ID a client resumes from, an empty ID starts from the live events
+func ParseEventID(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value != "" && !eventIDPattern.MatchString(value) {
+		return "", utils.BadRequestParamError("Invalid event ID", "Last-Event-ID")
+	}
+	return value, nil
+}
+
+// After tells whether the event was logged after the given ID. An event without ID is never skipped
+func (event Event) After(id string) bool {
+	if event.ID == "" || id == "" {
+		return true
+	}
+
+	eventMs, eventSeq := splitEventID(event.ID)
+	ms, seq := splitEventID(id)
+	if eventMs != ms {
+		return eventMs > ms
+	}
+	return eventSeq > seq
+}
+
+// splitEventID returns both parts of a Redis stream ID, the sequence defaults to zero
+func splitEventID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
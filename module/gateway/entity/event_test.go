@@ -0,0 +1,120 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/gateway/entity"
+)
+
+func TestEvent_NewLiveEvent(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedResult entity.Event
+	}{
+		{
+			name:           "logged event",
+			payload:        `{"id":"1700000000000-1","type":"like","data":null}`,
+			expectedResult: entity.Event{ID: "1700000000000-1", Data: []byte(`{"id":"1700000000000-1","type":"like","data":null}`)},
+		},
+		{
+			name:           "event without ID",
+			payload:        `{"type":"like","data":null}`,
+			expectedResult: entity.Event{Data: []byte(`{"type":"like","data":null}`)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, entity.NewLiveEvent([]byte(tc.payload)))
+		})
+	}
+}
+
+func TestEvent_ParseEventID(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		expectedResult string
+		expectedErr    error
+	}{
+		{
+			name:           "stream ID",
+			value:          " 1700000000000-1 ",
+			expectedResult: "1700000000000-1",
+		},
+		{
+			name:           "time only",
+			value:          "1700000000000",
+			expectedResult: "1700000000000",
+		},
+		{
+			name: "no ID",
+		},
+		{
+			name:        "invalid ID",
+			value:       "1700000000000-a",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid event ID; field: Last-Event-ID"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.ParseEventID(tc.value)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestEvent_After(t *testing.T) {
+	tests := []struct {
+		name           string
+		eventID        string
+		id             string
+		expectedResult bool
+	}{
+		{
+			name:           "later time",
+			eventID:        "1700000000001-0",
+			id:             "1700000000000-5",
+			expectedResult: true,
+		},
+		{
+			name:           "same time, later sequence",
+			eventID:        "1700000000000-10",
+			id:             "1700000000000-9",
+			expectedResult: true,
+		},
+		{
+			name:    "same event",
+			eventID: "1700000000000-1",
+			id:      "1700000000000-1",
+		},
+		{
+			name:    "earlier event",
+			eventID: "1699999999999-3",
+			id:      "1700000000000",
+		},
+		{
+			name:           "event without ID",
+			id:             "1700000000000-1",
+			expectedResult: true,
+		},
+		{
+			name:           "no ID to compare with",
+			eventID:        "1700000000000-1",
+			expectedResult: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, entity.Event{ID: tc.eventID}.After(tc.id))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Settings contains the tunable values of the gateway module, loaded from the environment on startup
+type Settings struct {
+	// PingInterval is how often the server pings a connection, it has to be shorter than PongWait
+	PingInterval time.Duration
+	// PongWait is how long a connection may stay silent before it is dropped
+	PongWait time.Duration
+	// WriteWait is how long a single write may take before the connection is dropped
+	WriteWait time.Duration
+	// SendBuffer is how many events may wait for a connection before it is dropped as too slow
+	SendBuffer int
+	// TicketTTL is how long a connect ticket can be redeemed for after it was issued
+	TicketTTL time.Duration
+	// AllowedOrigins are the browser origins allowed to open a WebSocket, none allows the same host only
+	AllowedOrigins []string
+}
@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+// Ticket lets a browser open a connection without putting its token in the URL, it is redeemed once before ExpiresAt
+type Ticket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
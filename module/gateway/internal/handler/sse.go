@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"timble/module/gateway/entity"
+)
+
+// Events streams the events of the user as Server-Sent Events for clients that cannot keep a WebSocket open.
+// A client reconnecting with Last-Event-ID first receives what it missed from the event log, then the live events
+func (resource *GatewayResource) Events(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+
+	lastEventID, err := entity.ParseEventID(r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		resource.returnErrorResponse(w, r, err)
+		return
+	}
+
+	// subscribe before replaying, so that nothing published in between is lost
+	client, err := resource.GatewayUsecase.Connect(r.Context(), userID)
+	if err != nil {
+		resource.returnErrorResponse(w, r, err)
+		return
+	}
+	defer resource.GatewayUsecase.Disconnect(r.Context(), client)
+
+	missed := []entity.Event{}
+	if lastEventID != "" {
+		missed, err = resource.GatewayUsecase.Replay(r.Context(), userID, lastEventID)
+		if err != nil {
+			resource.returnErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// keeps proxies such as nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	for _, event := range missed {
+		if err := resource.sendEvent(controller, w, event); err != nil {
+			return
+		}
+		lastEventID = event.ID
+	}
+	controller.Flush()
+
+	ticker := time.NewTicker(resource.settings.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case payload, ok := <-client.Events:
+			if !ok {
+				// the client was dropped for falling behind, it resumes from its last event when reconnecting
+				return
+			}
+
+			event := entity.NewLiveEvent(payload)
+			if !event.After(lastEventID) {
+				// already sent by the replay
+				continue
+			}
+			if err := resource.sendEvent(controller, w, event); err != nil {
+				return
+			}
+			lastEventID = event.ID
+		case <-ticker.C:
+			// a comment line keeps idle proxies from closing the stream
+			if err := resource.send(controller, w, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (resource *GatewayResource) sendEvent(controller *http.ResponseController, w http.ResponseWriter, event entity.Event) error {
+	message := fmt.Sprintf("data: %s\n\n", event.Data)
+	if event.ID != "" {
+		message = fmt.Sprintf("id: %s\n%s", event.ID, message)
+	}
+	return resource.send(controller, w, message)
+}
+
+func (resource *GatewayResource) send(controller *http.ResponseController, w http.ResponseWriter, message string) error {
+	// not every writer supports deadlines, the write itself still fails on a dead connection
+	controller.SetWriteDeadline(time.Now().Add(resource.settings.WriteWait))
+	if _, err := fmt.Fprint(w, message); err != nil {
+		return err
+	}
+	return controller.Flush()
+}
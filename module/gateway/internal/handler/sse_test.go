@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"timble/internal/utils"
+	mockshandler "timble/mocks/module/gateway/internal_/usecase"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/handler"
+	"timble/module/gateway/internal/usecase"
+)
+
+func TestGatewayResource_Events(t *testing.T) {
+	t.Run("normal case - live events are streamed with their IDs", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		resp, reader := stream(t, uc, "")
+
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+		client.Events <- []byte(`{"id":"5-0","type":"like","data":null}`)
+		assert.Equal(t, []string{"id: 5-0", `data: {"id":"5-0","type":"like","data":null}`}, readEvent(t, reader))
+
+		close(client.Events)
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("normal case - missed events are replayed before the live ones", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		uc.On("Replay", mock.Anything, uint(1), "1-0").Return([]entity.Event{
+			{ID: "2-0", Data: []byte(`{"type":"match","data":null}`)},
+			{ID: "3-0", Data: []byte(`{"type":"message","data":null}`)},
+		}, nil)
+		_, reader := stream(t, uc, "1-0")
+
+		assert.Equal(t, []string{"id: 2-0", `data: {"type":"match","data":null}`}, readEvent(t, reader))
+		assert.Equal(t, []string{"id: 3-0", `data: {"type":"message","data":null}`}, readEvent(t, reader))
+
+		// published while replaying, so already sent
+		client.Events <- []byte(`{"id":"3-0","type":"message","data":null}`)
+		client.Events <- []byte(`{"id":"4-0","type":"like","data":null}`)
+		assert.Equal(t, []string{"id: 4-0", `data: {"id":"4-0","type":"like","data":null}`}, readEvent(t, reader))
+
+		close(client.Events)
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("normal case - idle streams receive heartbeats", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		resp, reader := stream(t, uc, "")
+
+		heartbeat, err := reader.ReadString('\n')
+		assert.Nil(t, err)
+		assert.Equal(t, ": ping\n", heartbeat)
+
+		resp.Body.Close()
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("error case - invalid last event ID", func(t *testing.T) {
+		uc := mockshandler.NewGatewayUsecase(t)
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/protected/events", bytes.NewBuffer(nil))
+		req.Header.Set("Last-Event-ID", "latest")
+		req = req.WithContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+
+		st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+		st.Events(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+
+	t.Run("error case - usecase returned unexpected error on connect", func(t *testing.T) {
+		uc := mockshandler.NewGatewayUsecase(t)
+		uc.On("Connect", mock.Anything, uint(1)).Return(nil, errors.New("unexpected"))
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/protected/events", bytes.NewBuffer(nil))
+		req = req.WithContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+
+		st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+		st.Events(recorder, req)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+		assert.JSONEq(t, fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"), recorder.Body.String())
+	})
+
+	t.Run("error case - usecase returned unexpected error on replay", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		uc.On("Replay", mock.Anything, uint(1), "1-0").Return(nil, errors.New("unexpected"))
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/protected/events", bytes.NewBuffer(nil))
+		req.Header.Set("Last-Event-ID", "1-0")
+		req = req.WithContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+
+		st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+		st.Events(recorder, req)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+		waitForDisconnect(t, disconnected)
+	})
+}
+
+func stream(t *testing.T, uc usecase.GatewayUsecase, lastEventID string) (*http.Response, *bufio.Reader) {
+	st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st.Events(w, r.WithContext(context.WithValue(r.Context(), utils.CtxUserIDKey, float64(1))))
+	}))
+	t.Cleanup(server.Close)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp, bufio.NewReader(resp.Body)
+}
+
+// readEvent returns the lines of the next event, skipping the heartbeats
+func readEvent(t *testing.T, reader *bufio.Reader) []string {
+	lines := []string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if !assert.Nil(t, err) {
+			return lines
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == ": ping":
+		case line != "":
+			lines = append(lines, line)
+		case len(lines) > 0:
+			return lines
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"timble/internal/utils"
+)
+
+// Ticket issues a connect ticket to the authenticated user. Browsers can not set headers on a WebSocket handshake
+// or an EventSource, so they pass the ticket in the URL instead of their token
+func (resource *GatewayResource) Ticket(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	ticket, err := resource.GatewayUsecase.IssueTicket(r.Context(), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusCreated
+	meta := utils.Meta{
+		HTTPStatus: http.StatusCreated,
+	}
+	body := utils.NewDataResponse(ticket, meta)
+	body.WriteAPIResponse(w, r, http.StatusCreated)
+}
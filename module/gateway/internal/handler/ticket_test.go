@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"timble/internal/utils"
+	mockshandler "timble/mocks/module/gateway/internal_/usecase"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/handler"
+)
+
+func TestGatewayResource_Ticket(t *testing.T) {
+	ticketResponseString := `{
+	   "meta":{
+	      "http_status":201
+	   },
+	   "data":{
+	      "ticket":"abc",
+	      "expires_at":"2026-10-18T09:00:30Z"
+	   }
+	}`
+
+	cases := []struct {
+		name               string
+		result             entity.Ticket
+		err                error
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:               "normal case - successfully issue a ticket",
+			result:             entity.Ticket{Ticket: "abc", ExpiresAt: time.Date(2026, 10, 18, 9, 0, 30, 0, time.UTC)},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   ticketResponseString,
+		},
+		{
+			name:               "error case - usecase returned unexpected error",
+			err:                errors.New("unexpected"),
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewGatewayUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			uc.On("IssueTicket", mock.Anything, uint(1)).Return(tc.result, tc.err)
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/protected/gateway/ticket", bytes.NewBuffer(nil))
+			req = req.WithContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+
+			st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+			st.Ticket(recorder, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expectedResponse, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/usecase"
+)
+
+const (
+	// clients only send control frames, anything bigger is not a pong
+	MAX_CLIENT_MESSAGE_SIZE = 512
+)
+
+type GatewayResource struct {
+	GatewayUsecase usecase.GatewayUsecase
+	settings       entity.Settings
+	upgrader       websocket.Upgrader
+	logger         *log.Logger
+}
+
+func NewGatewayResource(gatewayUsecase usecase.GatewayUsecase, settings entity.Settings, logger *log.Logger) *GatewayResource {
+	resource := &GatewayResource{
+		GatewayUsecase: gatewayUsecase,
+		settings:       settings,
+		logger:         logger,
+	}
+	resource.upgrader = websocket.Upgrader{
+		CheckOrigin: resource.checkOrigin,
+	}
+	return resource
+}
+
+// Connect upgrades the request to a WebSocket and pushes the events of the user until either side closes it.
+// The server pings every PingInterval and drops a connection that stays silent for PongWait
+func (resource *GatewayResource) Connect(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+
+	client, err := resource.GatewayUsecase.Connect(r.Context(), userID)
+	if err != nil {
+		resource.returnErrorResponse(w, r, err)
+		return
+	}
+
+	conn, err := resource.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already answered the request
+		resource.GatewayUsecase.Disconnect(r.Context(), client)
+		return
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	go resource.read(conn, closed)
+	resource.write(conn, client, closed)
+	resource.GatewayUsecase.Disconnect(r.Context(), client)
+}
+
+// read moves the read deadline on every pong until the connection fails or the client closes it
+func (resource *GatewayResource) read(conn *websocket.Conn, closed chan<- struct{}) {
+	defer close(closed)
+
+	conn.SetReadLimit(MAX_CLIENT_MESSAGE_SIZE)
+	conn.SetReadDeadline(time.Now().Add(resource.settings.PongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(resource.settings.PongWait))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// write is the only writer of the connection, it sends the events and the pings
+func (resource *GatewayResource) write(conn *websocket.Conn, client *entity.Client, closed <-chan struct{}) {
+	ticker := time.NewTicker(resource.settings.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-client.Events:
+			if !ok {
+				// the client was dropped for falling behind on its events
+				message := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "too slow to receive events")
+				conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(resource.settings.WriteWait))
+				return
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(resource.settings.WriteWait))
+			if err := conn.WriteMessage(websocket.TextMessage, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(resource.settings.WriteWait)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// checkOrigin lets a browser open a connection only from an allowed origin, or from the same host when none is
+// configured. Clients other than browsers send no Origin
+func (resource *GatewayResource) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if len(resource.settings.AllowedOrigins) == 0 {
+		parsed, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(parsed.Host, r.Host)
+	}
+
+	for _, allowed := range resource.settings.AllowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (resource *GatewayResource) getUserIDFromContext(r *http.Request) uint {
+	return uint(r.Context().Value(utils.CtxUserIDKey).(float64))
+}
+
+func (resource *GatewayResource) returnErrorResponse(w http.ResponseWriter, r *http.Request, err error) int {
+	errOrig, ok := err.(*utils.StandardError)
+	if !ok {
+		httpStatus := http.StatusInternalServerError
+		resource.logger.Error(errors.WithStack(err).Error(), utils.BuildRequestLogFields(r, httpStatus)...)
+		writeErrorResponse(w, r, utils.ErrorInternalServerResponse, httpStatus)
+		return httpStatus
+	}
+
+	httpStatus := http.StatusBadRequest
+	if errOrig.HttpStatus != 0 {
+		httpStatus = errOrig.HttpStatus
+	}
+	writeErrorResponse(w, r, errOrig, httpStatus)
+	resource.logger.Error(err.Error(), utils.BuildRequestLogFields(r, httpStatus)...)
+	return httpStatus
+}
+
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, err *utils.StandardError, statusCode int) {
+	body := utils.NewErrorResponse(err, statusCode)
+	body.WriteAPIResponse(w, r, statusCode)
+}
@@ -0,0 +1,244 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	mockshandler "timble/mocks/module/gateway/internal_/usecase"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/handler"
+	"timble/module/gateway/internal/usecase"
+)
+
+var (
+	stdErrorResponseWithoutField = `{
+   	"meta":{
+      	"http_status":%d
+   	},
+   	"error_detail": {
+   		"message": "%s",
+   		"code": "%s"
+   	}
+	}`
+
+	defaultSettings = entity.Settings{
+		PingInterval: 20 * time.Millisecond,
+		PongWait:     100 * time.Millisecond,
+		WriteWait:    100 * time.Millisecond,
+		SendBuffer:   2,
+	}
+)
+
+func Test_NewGatewayResource(t *testing.T) {
+	t.Run("new gateway resource", func(t *testing.T) {
+		guc := &usecase.GatewayUc{}
+
+		res := handler.NewGatewayResource(guc, entity.Settings{}, &log.Logger{})
+
+		assert.IsType(t, &handler.GatewayResource{}, res)
+	})
+}
+
+func TestGatewayResource_Connect(t *testing.T) {
+	t.Run("normal case - events are pushed until the client is dropped", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		conn := dial(t, uc)
+		defer conn.Close()
+
+		client.Events <- []byte(`{"type":"like","data":null}`)
+		_, event, err := conn.ReadMessage()
+		assert.Nil(t, err)
+		assert.Equal(t, `{"type":"like","data":null}`, string(event))
+
+		close(client.Events)
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("normal case - the client closing the connection disconnects it", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		conn := dial(t, uc)
+
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.Close()
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("normal case - a client answering the pings stays connected", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		conn := dial(t, uc)
+		defer conn.Close()
+
+		// reading answers the pings, well past the pong wait
+		go func() {
+			time.Sleep(3 * defaultSettings.PongWait)
+			client.Events <- []byte("still here")
+		}()
+		_, event, err := conn.ReadMessage()
+		assert.Nil(t, err)
+		assert.Equal(t, "still here", string(event))
+
+		conn.Close()
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("normal case - a silent client is dropped after the pong wait", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+		conn := dial(t, uc)
+		defer conn.Close()
+
+		// without reading, the client never answers the pings
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("error case - request without upgrade", func(t *testing.T) {
+		client := entity.NewClient(1, defaultSettings.SendBuffer)
+		uc, disconnected := mockConnectedUsecase(t, client)
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/protected/ws", bytes.NewBuffer(nil))
+		req = req.WithContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+
+		st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+		st.Connect(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		waitForDisconnect(t, disconnected)
+	})
+
+	t.Run("error case - usecase returned unexpected error", func(t *testing.T) {
+		uc := mockshandler.NewGatewayUsecase(t)
+		uc.On("Connect", mock.Anything, uint(1)).Return(nil, errors.New("unexpected"))
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/protected/ws", bytes.NewBuffer(nil))
+		req = req.WithContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+
+		st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+		st.Connect(recorder, req)
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+		assert.JSONEq(t, fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"), recorder.Body.String())
+	})
+}
+
+func TestGatewayResource_Connect_Origin(t *testing.T) {
+	cases := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expectedAllow  bool
+	}{
+		{
+			name:          "no origin, not a browser",
+			expectedAllow: true,
+		},
+		{
+			name:          "same host without allowed origins",
+			origin:        "http://%s",
+			expectedAllow: true,
+		},
+		{
+			name:   "other host without allowed origins",
+			origin: "https://evil.example",
+		},
+		{
+			name:           "allowed origin",
+			allowedOrigins: []string{"https://timble.app"},
+			origin:         "https://Timble.app",
+			expectedAllow:  true,
+		},
+		{
+			name:           "origin not allowed",
+			allowedOrigins: []string{"https://timble.app"},
+			origin:         "https://evil.example",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := defaultSettings
+			settings.AllowedOrigins = tc.allowedOrigins
+			client := entity.NewClient(1, settings.SendBuffer)
+			uc, disconnected := mockConnectedUsecase(t, client)
+
+			st := handler.NewGatewayResource(uc, settings, initLogger(t))
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				st.Connect(w, r.WithContext(context.WithValue(r.Context(), utils.CtxUserIDKey, float64(1))))
+			}))
+			defer server.Close()
+
+			header := http.Header{}
+			if tc.origin != "" {
+				header.Set("Origin", strings.ReplaceAll(tc.origin, "%s", strings.TrimPrefix(server.URL, "http://")))
+			}
+			conn, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+			if tc.expectedAllow {
+				assert.Nil(t, err)
+				conn.Close()
+			} else {
+				assert.Equal(t, websocket.ErrBadHandshake, err)
+				assert.Equal(t, http.StatusForbidden, res.StatusCode)
+			}
+			waitForDisconnect(t, disconnected)
+		})
+	}
+}
+
+func mockConnectedUsecase(t *testing.T, client *entity.Client) (*mockshandler.GatewayUsecase, chan struct{}) {
+	disconnected := make(chan struct{})
+	uc := mockshandler.NewGatewayUsecase(t)
+	uc.On("Connect", mock.Anything, uint(1)).Return(client, nil)
+	uc.On("Disconnect", mock.Anything, client).Run(func(mock.Arguments) {
+		close(disconnected)
+	}).Return().Once()
+	return uc, disconnected
+}
+
+func dial(t *testing.T, uc usecase.GatewayUsecase) *websocket.Conn {
+	st := handler.NewGatewayResource(uc, defaultSettings, initLogger(t))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st.Connect(w, r.WithContext(context.WithValue(r.Context(), utils.CtxUserIDKey, float64(1))))
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.Nil(t, err)
+	return conn
+}
+
+func waitForDisconnect(t *testing.T, disconnected chan struct{}) {
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Error("the connection was not disconnected")
+	}
+}
+
+func initLogger(t *testing.T) *log.Logger {
+	config := log.NewProductionConfig()
+	config.Level = log.NewAtomicLevelAt(log.FatalLevel)
+
+	logger, err := config.Build()
+	assert.Nil(t, err)
+	return logger
+}
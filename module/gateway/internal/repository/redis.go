@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	goredis "github.com/redis/go-redis/v9"
+
+	"timble/internal/connection/redis"
+)
+
+type RedisRepository struct {
+	redisClient redis.RedisInterface
+}
+
+func NewRedisRepository(redisClient redis.RedisInterface) *RedisRepository {
+	return &RedisRepository{redisClient}
+}
+
+// Subscribe starts a subscription to the given channels, channels are added and removed on the returned subscription
+func (repo *RedisRepository) Subscribe(ctx context.Context, channels ...string) *goredis.PubSub {
+	return repo.redisClient.Subscribe(ctx, channels...)
+}
+
+// ReadEvents returns the events logged in the stream after the given ID, the oldest first
+func (repo *RedisRepository) ReadEvents(ctx context.Context, stream, afterID string) ([]goredis.XMessage, error) {
+	res, err := repo.redisClient.ReadEvents(ctx, stream, afterID)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when read events")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error) {
+	res, err := repo.redisClient.Set(ctx, key, value, expire)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when set")
+	}
+
+	return res, nil
+}
+
+// GetDel returns the value of the key and removes it, a missing key reads as empty
+func (repo *RedisRepository) GetDel(ctx context.Context, key string) (string, error) {
+	res, err := repo.redisClient.GetDel(ctx, key)
+	if err != nil {
+		return "", errors.Wrap(err, "redis client error when getdel")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,166 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	redis "timble/internal/connection/redis"
+	mocksredis "timble/mocks/internal_/connection/redis"
+	"timble/module/gateway/internal/repository"
+)
+
+func TestNewRedisRepository(t *testing.T) {
+	t.Run("new redis repository", func(t *testing.T) {
+		repo := repository.NewRedisRepository(&redis.RedisClient{})
+
+		assert.IsType(t, &repository.RedisRepository{}, repo)
+	})
+}
+
+func TestRedisRepository_Subscribe(t *testing.T) {
+	ctx := context.Background()
+	t.Run("normal case - successfully subscribe", func(t *testing.T) {
+		subscription := &goredis.PubSub{}
+		redisClient := mocksredis.NewRedisInterface(t)
+		redisClient.On("Subscribe", ctx, "notifications:1").Return(subscription)
+
+		repo := repository.NewRedisRepository(redisClient)
+
+		assert.Equal(t, subscription, repo.Subscribe(ctx, "notifications:1"))
+	})
+}
+
+func TestRedisRepository_ReadEvents(t *testing.T) {
+	ctx := context.Background()
+	events := []goredis.XMessage{{ID: "2-1", Values: map[string]interface{}{"payload": "{}"}}}
+	tests := []struct {
+		name           string
+		expectedResult []goredis.XMessage
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully read events",
+			expectedResult: events,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("ReadEvents", ctx, "events:1", "1-1").Return(events, nil)
+			},
+		},
+		{
+			name: "error case - error when reading events",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("ReadEvents", ctx, "events:1", "1-1").Return(nil, errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when read events: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.ReadEvents(ctx, "events:1", "1-1")
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_Set(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult string
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully set key",
+			expectedResult: "OK",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("Set", ctx, "gateway_ticket:abc", uint(1), time.Minute).Return("OK", nil)
+			},
+		},
+		{
+			name: "error case - error when setting key",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("Set", ctx, "gateway_ticket:abc", uint(1), time.Minute).Return("", errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when set: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.Set(ctx, "gateway_ticket:abc", uint(1), time.Minute)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_GetDel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult string
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully get and delete key",
+			expectedResult: "1",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("GetDel", ctx, "gateway_ticket:abc").Return("1", nil)
+			},
+		},
+		{
+			name: "error case - error when getting key",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("GetDel", ctx, "gateway_ticket:abc").Return("", errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when getdel: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.GetDel(ctx, "gateway_ticket:abc")
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
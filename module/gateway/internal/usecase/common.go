@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type RedisRepository interface {
+	Subscribe(ctx context.Context, channels ...string) *goredis.PubSub
+	ReadEvents(ctx context.Context, stream, afterID string) ([]goredis.XMessage, error)
+	Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error)
+	GetDel(ctx context.Context, key string) (string, error)
+}
+
+// BuildTicketRedisKey holds the user a connect ticket was issued to until it is redeemed or expires
+func BuildTicketRedisKey(ticket string) string {
+	return fmt.Sprintf("gateway_ticket:%s", ticket)
+}
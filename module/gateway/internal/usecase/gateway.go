@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	goredis "github.com/redis/go-redis/v9"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/gateway/entity"
+)
+
+type GatewayUsecase interface {
+	Connect(ctx context.Context, userID uint) (*entity.Client, error)
+	Disconnect(ctx context.Context, client *entity.Client)
+	Replay(ctx context.Context, userID uint, lastEventID string) ([]entity.Event, error)
+	IssueTicket(ctx context.Context, userID uint) (entity.Ticket, error)
+	RedeemTicket(ctx context.Context, ticket string) (uint, error)
+}
+
+// GatewayUc fans the events published on Redis out to the connections of this instance. It holds a single
+// subscription, a user channel is subscribed while the user has at least one connection here
+type GatewayUc struct {
+	settings     entity.Settings
+	redis        RedisRepository
+	subscription *goredis.PubSub
+	logger       *log.Logger
+
+	mu      sync.Mutex
+	clients map[string]map[*entity.Client]struct{}
+}
+
+func NewGatewayUsecase(settings entity.Settings, redis RedisRepository, logger *log.Logger) *GatewayUc {
+	return &GatewayUc{
+		settings:     settings,
+		redis:        redis,
+		subscription: redis.Subscribe(context.Background()),
+		logger:       logger,
+		clients:      map[string]map[*entity.Client]struct{}{},
+	}
+}
+
+// Run delivers the published events until the usecase is closed
+func (usecase *GatewayUc) Run() {
+	for message := range usecase.subscription.Channel() {
+		usecase.dispatch(message.Channel, message.Payload)
+	}
+}
+
+// Close ends the subscription, which stops Run
+func (usecase *GatewayUc) Close() error {
+	return usecase.subscription.Close()
+}
+
+func (usecase *GatewayUc) Connect(ctx context.Context, userID uint) (*entity.Client, error) {
+	client := entity.NewClient(userID, usecase.settings.SendBuffer)
+	channel := utils.BuildNotificationChannel(userID)
+
+	usecase.mu.Lock()
+	defer usecase.mu.Unlock()
+
+	if len(usecase.clients[channel]) == 0 {
+		err := usecase.subscription.Subscribe(ctx, channel)
+		if err != nil {
+			return nil, errors.Wrap(err, "redis client error when subscribe")
+		}
+		usecase.clients[channel] = map[*entity.Client]struct{}{}
+	}
+
+	usecase.clients[channel][client] = struct{}{}
+	utils.TimbleWebsocketConnections.Inc()
+	return client, nil
+}
+
+func (usecase *GatewayUc) Disconnect(ctx context.Context, client *entity.Client) {
+	usecase.mu.Lock()
+	defer usecase.mu.Unlock()
+
+	usecase.remove(ctx, client)
+}
+
+// Replay returns the events of the user logged after lastEventID that are still in their event log, the oldest first
+func (usecase *GatewayUc) Replay(ctx context.Context, userID uint, lastEventID string) ([]entity.Event, error) {
+	messages, err := usecase.redis.ReadEvents(ctx, utils.BuildEventStreamKey(userID), lastEventID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	events := []entity.Event{}
+	for _, message := range messages {
+		payload, _ := message.Values["payload"].(string)
+		events = append(events, entity.Event{
+			ID:   message.ID,
+			Data: []byte(payload),
+		})
+	}
+	return events, nil
+}
+
+// IssueTicket returns a random ticket the user redeems once to open a connection, within the ticket TTL
+func (usecase *GatewayUc) IssueTicket(ctx context.Context, userID uint) (entity.Ticket, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return entity.Ticket{}, errors.WithStack(err)
+	}
+
+	ticket := entity.Ticket{
+		Ticket:    hex.EncodeToString(random),
+		ExpiresAt: time.Now().Add(usecase.settings.TicketTTL),
+	}
+	_, err := usecase.redis.Set(ctx, BuildTicketRedisKey(ticket.Ticket), userID, usecase.settings.TicketTTL)
+	if err != nil {
+		return entity.Ticket{}, errors.WithStack(err)
+	}
+
+	return ticket, nil
+}
+
+// RedeemTicket returns the user the ticket was issued to and removes it, zero when it is unknown, expired or
+// already redeemed
+func (usecase *GatewayUc) RedeemTicket(ctx context.Context, ticket string) (uint, error) {
+	res, err := usecase.redis.GetDel(ctx, BuildTicketRedisKey(ticket))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	userID, _ := strconv.ParseUint(res, 10, 64)
+	return uint(userID), nil
+}
+
+// dispatch queues the event for every connection of the channel. A client whose queue is full is dropped
+// rather than holding back the others
+func (usecase *GatewayUc) dispatch(channel, payload string) {
+	usecase.mu.Lock()
+	defer usecase.mu.Unlock()
+
+	for client := range usecase.clients[channel] {
+		select {
+		case client.Events <- []byte(payload):
+		default:
+			usecase.remove(context.Background(), client)
+			utils.TimbleWebsocketSlowClients.Inc()
+		}
+	}
+}
+
+// remove closes the events of the client and leaves the channel once nobody listens to it,
+// removing a client twice does nothing. The caller holds the lock
+func (usecase *GatewayUc) remove(ctx context.Context, client *entity.Client) {
+	channel := utils.BuildNotificationChannel(client.UserID)
+	if _, ok := usecase.clients[channel][client]; !ok {
+		return
+	}
+
+	delete(usecase.clients[channel], client)
+	close(client.Events)
+	utils.TimbleWebsocketConnections.Dec()
+
+	if len(usecase.clients[channel]) > 0 {
+		return
+	}
+
+	delete(usecase.clients, channel)
+	err := usecase.subscription.Unsubscribe(ctx, channel)
+	if err != nil {
+		// a channel left subscribed only costs the events nobody here reads
+		usecase.logger.Warn(errors.Wrap(err, "redis client error when unsubscribe").Error())
+	}
+}
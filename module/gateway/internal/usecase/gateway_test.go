@@ -0,0 +1,218 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	log "go.uber.org/zap"
+
+	redisconn "timble/internal/connection/redis"
+	"timble/module/gateway/entity"
+	"timble/module/gateway/internal/repository"
+	uc "timble/module/gateway/internal/usecase"
+)
+
+var (
+	defaultSettings = entity.Settings{
+		PingInterval: time.Second,
+		PongWait:     2 * time.Second,
+		WriteWait:    time.Second,
+		SendBuffer:   2,
+		TicketTTL:    30 * time.Second,
+	}
+)
+
+func TestNewGatewayUsecase(t *testing.T) {
+	t.Run("new gateway usecase", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+
+		usecase := uc.NewGatewayUsecase(entity.Settings{}, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+
+		assert.IsType(t, &uc.GatewayUc{}, usecase)
+	})
+}
+
+func TestGatewayUc_Connect(t *testing.T) {
+	ctx := context.Background()
+	channel := "notifications:1"
+
+	t.Run("normal case - every connection of the user receives the events", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+		usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+		go usecase.Run()
+
+		phone, err := usecase.Connect(ctx, 1)
+		assert.Nil(t, err)
+		web, err := usecase.Connect(ctx, 1)
+		assert.Nil(t, err)
+		waitForSubscribers(t, s, channel, 1)
+
+		s.Publish(channel, `{"type":"like","data":null}`)
+
+		assert.Equal(t, `{"type":"like","data":null}`, string(<-phone.Events))
+		assert.Equal(t, `{"type":"like","data":null}`, string(<-web.Events))
+
+		// the channel stays subscribed until the last connection of the user leaves
+		usecase.Disconnect(ctx, phone)
+		_, open := <-phone.Events
+		assert.False(t, open)
+		waitForSubscribers(t, s, channel, 1)
+
+		usecase.Disconnect(ctx, web)
+		usecase.Disconnect(ctx, web)
+		waitForSubscribers(t, s, channel, 0)
+	})
+
+	t.Run("normal case - a client too slow to keep up is dropped", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+		usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+		go usecase.Run()
+
+		client, err := usecase.Connect(ctx, 1)
+		assert.Nil(t, err)
+		waitForSubscribers(t, s, channel, 1)
+
+		for _, event := range []string{"first", "second", "third"} {
+			s.Publish(channel, event)
+		}
+
+		// the queued events are still delivered before the events are closed
+		waitForSubscribers(t, s, channel, 0)
+		assert.Equal(t, "first", string(<-client.Events))
+		assert.Equal(t, "second", string(<-client.Events))
+		_, open := <-client.Events
+		assert.False(t, open)
+	})
+
+	t.Run("error case - error when subscribing", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+		usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+		s.Close()
+
+		client, err := usecase.Connect(ctx, 1)
+		assert.NotNil(t, err)
+		assert.Nil(t, client)
+	})
+}
+
+func TestGatewayUc_Replay(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		lastEventID    string
+		mockErr        string
+		expectedResult []entity.Event
+		expectedErr    bool
+	}{
+		{
+			name:        "normal case - events logged after the last one received",
+			lastEventID: "1-1",
+			expectedResult: []entity.Event{
+				{ID: "2-1", Data: []byte(`{"type":"match","data":null}`)},
+				{ID: "3-1", Data: []byte(`{"type":"message","data":null}`)},
+			},
+		},
+		{
+			name:           "normal case - nothing missed",
+			lastEventID:    "3-1",
+			expectedResult: []entity.Event{},
+		},
+		{
+			name:        "error case - error from redis",
+			lastEventID: "1-1",
+			mockErr:     "timeout",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := miniredis.RunT(t)
+			s.XAdd("events:1", "1-1", []string{"payload", `{"type":"like","data":null}`})
+			s.XAdd("events:1", "2-1", []string{"payload", `{"type":"match","data":null}`})
+			s.XAdd("events:1", "3-1", []string{"payload", `{"type":"message","data":null}`})
+			redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+			usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+			defer usecase.Close()
+			s.SetError(tc.mockErr)
+
+			result, err := usecase.Replay(ctx, 1, tc.lastEventID)
+			if tc.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestGatewayUc_Tickets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normal case - a ticket is redeemed once", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+		usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+
+		ticket, err := usecase.IssueTicket(ctx, 7)
+		assert.Nil(t, err)
+		assert.Len(t, ticket.Ticket, 64)
+		assert.WithinDuration(t, time.Now().Add(30*time.Second), ticket.ExpiresAt, time.Second)
+		assert.Equal(t, 30*time.Second, s.TTL(uc.BuildTicketRedisKey(ticket.Ticket)))
+
+		userID, err := usecase.RedeemTicket(ctx, ticket.Ticket)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(7), userID)
+
+		userID, err = usecase.RedeemTicket(ctx, ticket.Ticket)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(0), userID)
+	})
+
+	t.Run("normal case - an expired ticket is not redeemed", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+		usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+
+		ticket, err := usecase.IssueTicket(ctx, 7)
+		assert.Nil(t, err)
+		s.FastForward(31 * time.Second)
+
+		userID, err := usecase.RedeemTicket(ctx, ticket.Ticket)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(0), userID)
+	})
+
+	t.Run("error case - error from redis", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "200ms", "0")
+		usecase := uc.NewGatewayUsecase(defaultSettings, repository.NewRedisRepository(redisClient), &log.Logger{})
+		defer usecase.Close()
+		s.SetError("timeout")
+
+		_, err := usecase.IssueTicket(ctx, 7)
+		assert.NotNil(t, err)
+
+		_, err = usecase.RedeemTicket(ctx, "abc")
+		assert.NotNil(t, err)
+	})
+}
+
+func waitForSubscribers(t *testing.T, s *miniredis.Miniredis, channel string, expected int) {
+	assert.Eventually(t, func() bool {
+		return s.PubSubNumSub(channel)[channel] == expected
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	postgres "timble/internal/connection/postgres"
+	redis "timble/internal/connection/redis"
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/handler"
+	"timble/module/messages/internal/repository"
+	"timble/module/messages/internal/usecase"
+)
+
+// rest handler
+type MessagesRESTInterface interface {
+	Send(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	MarkRead(w http.ResponseWriter, r *http.Request)
+	Unread(w http.ResponseWriter, r *http.Request)
+	Typing(w http.ResponseWriter, r *http.Request)
+	Conversations(w http.ResponseWriter, r *http.Request)
+	FlaggedMessages(w http.ResponseWriter, r *http.Request)
+	ApproveFlaggedMessage(w http.ResponseWriter, r *http.Request)
+	RejectFlaggedMessage(w http.ResponseWriter, r *http.Request)
+}
+
+func NewMessagesHandler(auth *utils.AuthConfig, logger *zap.Logger, redisClient redis.RedisInterface, postgresClient postgres.PostgresInterface, settings entity.Settings) *handler.MessagesResource {
+	redisRepository := repository.NewRedisRepository(redisClient)
+	postgresRepository := repository.NewPostgresRepository(postgresClient)
+
+	messageUsecase := usecase.NewMessageUsecase(usecase.NewDefaultFilters(settings.Filter, redisRepository), settings, redisRepository, postgresRepository, logger)
+	moderationUsecase := usecase.NewModerationUsecase(redisRepository, postgresRepository, logger)
+
+	cursorSigner := utils.NewCursorSigner(auth.SecretKey)
+
+	return handler.NewMessagesResource(messageUsecase, moderationUsecase, cursorSigner, logger)
+}
@@ -0,0 +1,27 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"timble/internal/utils"
+	mockspostgre "timble/mocks/internal_/connection/postgres"
+	mocksredis "timble/mocks/internal_/connection/redis"
+	"timble/module/messages/config"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/handler"
+)
+
+func TestNewMessagesHandler(t *testing.T) {
+	t.Run("normal case", func(t *testing.T) {
+		redisClient := mocksredis.NewRedisInterface(t)
+		postgresClient := mockspostgre.NewPostgresInterface(t)
+
+		result := config.NewMessagesHandler(&utils.AuthConfig{}, &zap.Logger{}, redisClient, postgresClient, entity.Settings{})
+
+		assert.NotNil(t, result)
+		assert.IsType(t, &handler.MessagesResource{}, result)
+	})
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"timble/internal/utils"
+)
+
+const (
+	// MESSAGE_SNIPPET_LENGTH is how much of the last message body a conversation previews, in characters
+	MESSAGE_SNIPPET_LENGTH = 100
+)
+
+// Conversation is an active match of the user as listed in their inbox, UserID being the other side. The last message
+// is null until one is sent, the conversation is then as recent as the match
+type Conversation struct {
+	MatchID             uint       `json:"match_id"`
+	UserID              uint       `json:"user_id"`
+	LastMessageID       *uint      `json:"last_message_id"`
+	LastMessageSenderID *uint      `json:"last_message_sender_id"`
+	LastMessageSnippet  *string    `json:"last_message_snippet"`
+	LastMessageAt       *time.Time `json:"last_message_at"`
+	LastActivityAt      time.Time  `json:"last_activity_at"`
+	Unread              int64      `json:"unread"`
+}
+
+// ConversationListParams lists the conversations of the user, the most recently active first
+type ConversationListParams struct {
+	UserID uint
+	Page   utils.PageParams
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"timble/internal/utils"
+)
+
+const (
+	FILTER_ACTION_ALLOW FilterAction = "allow"
+	FILTER_ACTION_FLAG  FilterAction = "flag"
+	FILTER_ACTION_BLOCK FilterAction = "block"
+
+	FILTER_REASON_KEYWORD   = "keyword"
+	FILTER_REASON_LINK      = "link"
+	FILTER_REASON_PHONE     = "phone number"
+	FILTER_REASON_DUPLICATE = "too many identical messages"
+
+	FLAGGED_STATUS_PENDING  = "pending"
+	FLAGGED_STATUS_APPROVED = "approved"
+	FLAGGED_STATUS_REJECTED = "rejected"
+)
+
+var FlaggedStatuses = map[string]bool{
+	FLAGGED_STATUS_PENDING:  true,
+	FLAGGED_STATUS_APPROVED: true,
+	FLAGGED_STATUS_REJECTED: true,
+}
+
+// FilterAction is what happens to a message caught by a filter. A flagged message is held in the
+// moderation queue instead of being delivered, a blocked one is refused
+type FilterAction string
+
+// NewFilterAction parses a configured action, ok is false for an unknown one
+func NewFilterAction(value string) (FilterAction, bool) {
+	action := FilterAction(strings.ToLower(strings.TrimSpace(value)))
+	switch action {
+	case FILTER_ACTION_ALLOW, FILTER_ACTION_FLAG, FILTER_ACTION_BLOCK:
+		return action, true
+	}
+	return "", false
+}
+
+func (action FilterAction) severity() int {
+	switch action {
+	case FILTER_ACTION_FLAG:
+		return 1
+	case FILTER_ACTION_BLOCK:
+		return 2
+	}
+	return 0
+}
+
+// FilterVerdict is the decision of the filters on a message, Reasons tells moderators what caught it
+type FilterVerdict struct {
+	Action  FilterAction
+	Reasons []string
+}
+
+func AllowVerdict() FilterVerdict {
+	return FilterVerdict{Action: FILTER_ACTION_ALLOW}
+}
+
+func NewFilterVerdict(action FilterAction, reason string) FilterVerdict {
+	if action.severity() == 0 {
+		return AllowVerdict()
+	}
+	return FilterVerdict{Action: action, Reasons: []string{reason}}
+}
+
+// Merge keeps the stricter action of both verdicts and the reasons of every one that caught the message
+func (verdict FilterVerdict) Merge(other FilterVerdict) FilterVerdict {
+	merged := FilterVerdict{
+		Action:  verdict.Action,
+		Reasons: append(append([]string{}, verdict.Reasons...), other.Reasons...),
+	}
+	if other.Action.severity() > verdict.Action.severity() {
+		merged.Action = other.Action
+	}
+	if len(merged.Reasons) == 0 {
+		merged.Reasons = nil
+	}
+	return merged
+}
+
+func (verdict FilterVerdict) Reason() string {
+	return strings.Join(verdict.Reasons, ", ")
+}
+
+// FilterSettings configures the content filters run on every message before it is sent
+type FilterSettings struct {
+	Keywords      []string
+	KeywordAction FilterAction
+	LinkAction    FilterAction
+	PhoneAction   FilterAction
+	// DuplicateLimit is how many identical messages a sender can deliver to the same receiver within DuplicateWindow,
+	// zero disables the check
+	DuplicateLimit  int
+	DuplicateWindow time.Duration
+}
+
+// FlaggedMessage is a message held in the moderation queue, it is pending until a moderator approves it,
+// which delivers it, or rejects it
+type FlaggedMessage struct {
+	ID              uint       `json:"id"`
+	MatchID         uint       `json:"match_id"`
+	SenderID        uint       `json:"sender_id"`
+	ReceiverID      uint       `json:"receiver_id"`
+	ClientMessageID string     `json:"client_message_id"`
+	Body            string     `json:"body"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status"`
+	ReviewedBy      *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type FlaggedQueueParams struct {
+	ModeratorID uint
+	Status      string
+	Page        utils.PageParams
+}
+
+type FlaggedReviewParams struct {
+	FlaggedMessageID uint
+	ModeratorID      uint
+}
+
+// NewFlaggedQueueParams lists the pending flagged messages unless another status is asked for
+func NewFlaggedQueueParams(status string, page utils.PageParams, moderatorID uint) (FlaggedQueueParams, error) {
+	params := FlaggedQueueParams{
+		ModeratorID: moderatorID,
+		Status:      status,
+		Page:        page,
+	}
+	if params.Status == "" {
+		params.Status = FLAGGED_STATUS_PENDING
+	}
+
+	if !FlaggedStatuses[params.Status] {
+		return params, utils.BadRequestParamError("Invalid flagged message status", "status")
+	}
+
+	return params, nil
+}
+
+func NewFlaggedReviewParams(flaggedMessageID string, moderatorID uint) (FlaggedReviewParams, error) {
+	params := FlaggedReviewParams{
+		ModeratorID: moderatorID,
+	}
+
+	id, err := strconv.ParseUint(flaggedMessageID, 10, 0)
+	if err != nil || id == 0 {
+		return params, utils.BadRequestParamError("Invalid flagged message", "id")
+	}
+	params.FlaggedMessageID = uint(id)
+
+	return params, nil
+}
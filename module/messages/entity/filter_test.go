@@ -0,0 +1,159 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+)
+
+func TestFilter_NewFilterAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		expectedResult entity.FilterAction
+		expectedOk     bool
+	}{
+		{
+			name:           "known action",
+			value:          " Flag ",
+			expectedResult: entity.FILTER_ACTION_FLAG,
+			expectedOk:     true,
+		},
+		{
+			name:  "unknown action",
+			value: "drop",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := entity.NewFilterAction(tc.value)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestFilter_FilterVerdict_Merge(t *testing.T) {
+	link := entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK)
+	tests := []struct {
+		name           string
+		verdict        entity.FilterVerdict
+		other          entity.FilterVerdict
+		expectedResult entity.FilterVerdict
+		expectedReason string
+	}{
+		{
+			name:           "both allowed",
+			verdict:        entity.AllowVerdict(),
+			other:          entity.NewFilterVerdict(entity.FILTER_ACTION_ALLOW, entity.FILTER_REASON_PHONE),
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:           "flag over allow",
+			verdict:        entity.AllowVerdict(),
+			other:          link,
+			expectedResult: link,
+			expectedReason: "link",
+		},
+		{
+			name:    "block over flag",
+			verdict: link,
+			other:   entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_KEYWORD),
+			expectedResult: entity.FilterVerdict{
+				Action:  entity.FILTER_ACTION_BLOCK,
+				Reasons: []string{entity.FILTER_REASON_LINK, entity.FILTER_REASON_KEYWORD},
+			},
+			expectedReason: "link, keyword",
+		},
+		{
+			name:           "flag does not lower a block",
+			verdict:        entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_DUPLICATE),
+			other:          entity.AllowVerdict(),
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_DUPLICATE),
+			expectedReason: "too many identical messages",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.verdict.Merge(tc.other)
+			assert.Equal(t, tc.expectedResult, actual)
+			assert.Equal(t, tc.expectedReason, actual.Reason())
+		})
+	}
+}
+
+func TestFilter_NewFlaggedQueueParams(t *testing.T) {
+	page := utils.PageParams{Limit: 20}
+	tests := []struct {
+		name           string
+		status         string
+		expectedResult entity.FlaggedQueueParams
+		expectedErr    error
+	}{
+		{
+			name:           "pending by default",
+			expectedResult: entity.FlaggedQueueParams{ModeratorID: 9, Status: "pending", Page: page},
+		},
+		{
+			name:           "normal case with status",
+			status:         "rejected",
+			expectedResult: entity.FlaggedQueueParams{ModeratorID: 9, Status: "rejected", Page: page},
+		},
+		{
+			name:        "error case with unknown status",
+			status:      "claimed",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid flagged message status; field: status"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewFlaggedQueueParams(tc.status, page, 9)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestFilter_NewFlaggedReviewParams(t *testing.T) {
+	tests := []struct {
+		name             string
+		flaggedMessageID string
+		expectedResult   entity.FlaggedReviewParams
+		expectedErr      error
+	}{
+		{
+			name:             "normal case",
+			flaggedMessageID: "5",
+			expectedResult:   entity.FlaggedReviewParams{FlaggedMessageID: 5, ModeratorID: 9},
+		},
+		{
+			name:             "error case with invalid flagged message ID",
+			flaggedMessageID: "abc",
+			expectedErr:      errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid flagged message; field: id"),
+		},
+		{
+			name:             "error case with zero flagged message ID",
+			flaggedMessageID: "0",
+			expectedErr:      errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid flagged message; field: id"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewFlaggedReviewParams(tc.flaggedMessageID, 9)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
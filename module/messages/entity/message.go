@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"timble/internal/utils"
+)
+
+const (
+	MAX_CLIENT_MESSAGE_ID_LENGTH = 64
+)
+
+type Message struct {
+	ID              uint      `json:"id"`
+	MatchID         uint      `json:"match_id"`
+	SenderID        uint      `json:"sender_id"`
+	ReceiverID      uint      `json:"receiver_id"`
+	ClientMessageID string    `json:"client_message_id"`
+	Body            string    `json:"body"`
+	CreatedAt       time.Time `json:"created_at"`
+	// Created tells apart a new message from a retry of one already sent
+	Created bool `json:"-"`
+	// Held tells a message caught by the content filters, it waits in the moderation queue and is not delivered
+	Held bool `json:"-"`
+}
+
+// SendMessageParams is a message to a match, the client picks the ID so a retried send is saved once
+type SendMessageParams struct {
+	SenderID        uint   `json:"-"`
+	ReceiverID      uint   `json:"-"`
+	ClientMessageID string `json:"client_message_id"`
+	Body            string `json:"body"`
+}
+
+// MessageListParams lists the conversation of the user with the other side of their match
+type MessageListParams struct {
+	UserID      uint
+	OtherUserID uint
+	Page        utils.PageParams
+}
+
+func NewSendMessagePayload(body io.Reader, senderID uint, receiverID string) (SendMessageParams, error) {
+	params := SendMessageParams{
+		SenderID: senderID,
+	}
+	id, err := parseOtherUserID(receiverID, senderID)
+	if err != nil {
+		return params, err
+	}
+	params.ReceiverID = id
+
+	err = json.NewDecoder(body).Decode(&params)
+	if err != nil {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	if len(params.ClientMessageID) == 0 {
+		return params, utils.BadRequestParamError("Client message ID can not be blank", "client_message_id")
+	}
+
+	if len(params.ClientMessageID) > MAX_CLIENT_MESSAGE_ID_LENGTH {
+		return params, utils.BadRequestParamError(fmt.Sprintf("Client message ID must be at most %d characters", MAX_CLIENT_MESSAGE_ID_LENGTH), "client_message_id")
+	}
+
+	if len(strings.TrimSpace(params.Body)) == 0 {
+		return params, utils.BadRequestParamError("Message can not be blank", "body")
+	}
+
+	return params, nil
+}
+
+func NewMessageListParams(otherUserID string, userID uint, page utils.PageParams) (MessageListParams, error) {
+	params := MessageListParams{
+		UserID: userID,
+		Page:   page,
+	}
+	id, err := parseOtherUserID(otherUserID, userID)
+	if err != nil {
+		return params, err
+	}
+	params.OtherUserID = id
+
+	return params, nil
+}
+
+func parseOtherUserID(value string, userID uint) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || id == 0 || uint(id) == userID {
+		return 0, utils.BadRequestParamError("Invalid user", "user_id")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,120 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+)
+
+func TestMessage_NewSendMessagePayload(t *testing.T) {
+	tests := []struct {
+		name           string
+		receiverID     string
+		body           string
+		expectedResult entity.SendMessageParams
+		expectedErr    error
+	}{
+		{
+			name:       "normal case",
+			receiverID: "2",
+			body: `{
+		      "client_message_id": "client-1",
+		      "body": "hello"
+		    }`,
+			expectedResult: entity.SendMessageParams{
+				SenderID:        1,
+				ReceiverID:      2,
+				ClientMessageID: "client-1",
+				Body:            "hello",
+			},
+		},
+		{
+			name:        "error case with invalid receiver ID",
+			receiverID:  "abc",
+			body:        `{"client_message_id": "client-1", "body": "hello"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid user; field: user_id"),
+		},
+		{
+			name:        "error case with same receiver ID as sender ID",
+			receiverID:  "1",
+			body:        `{"client_message_id": "client-1", "body": "hello"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid user; field: user_id"),
+		},
+		{
+			name:        "error case with invalid body",
+			receiverID:  "2",
+			body:        `{"body": "hello"`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: unexpected EOF; field: payload"),
+		},
+		{
+			name:        "error case with blank client message ID",
+			receiverID:  "2",
+			body:        `{"body": "hello"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Client message ID can not be blank; field: client_message_id"),
+		},
+		{
+			name:        "error case with too long client message ID",
+			receiverID:  "2",
+			body:        `{"client_message_id": "` + strings.Repeat("a", 65) + `", "body": "hello"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Client message ID must be at most 64 characters; field: client_message_id"),
+		},
+		{
+			name:        "error case with blank message",
+			receiverID:  "2",
+			body:        `{"client_message_id": "client-1", "body": "  "}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Message can not be blank; field: body"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewSendMessagePayload(strings.NewReader(tc.body), 1, tc.receiverID)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestMessage_NewMessageListParams(t *testing.T) {
+	page := utils.PageParams{Limit: 10}
+	tests := []struct {
+		name           string
+		otherUserID    string
+		expectedResult entity.MessageListParams
+		expectedErr    error
+	}{
+		{
+			name:        "normal case",
+			otherUserID: "2",
+			expectedResult: entity.MessageListParams{
+				UserID:      1,
+				OtherUserID: 2,
+				Page:        page,
+			},
+		},
+		{
+			name:        "error case with zero user ID",
+			otherUserID: "0",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid user; field: user_id"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewMessageListParams(tc.otherUserID, 1, page)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package entity
+
+const (
+	NOTIFICATION_TYPE_MESSAGE = "message"
+	NOTIFICATION_TYPE_READ    = "read"
+	NOTIFICATION_TYPE_TYPING  = "typing"
+)
+
+// Notification is an event pushed to a single user
+type Notification struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"timble/internal/utils"
+)
+
+// ReadParams moves the read cursor of the user in the conversation,
+// up to the latest received message when MessageID is not given
+type ReadParams struct {
+	UserID      uint `json:"-"`
+	OtherUserID uint `json:"-"`
+	MessageID   uint `json:"message_id"`
+}
+
+// ReadReceipt is the read cursor of a participant of a match
+type ReadReceipt struct {
+	MatchID           uint      `json:"match_id"`
+	UserID            uint      `json:"user_id"`
+	LastReadMessageID uint      `json:"last_read_message_id"`
+	ReadAt            time.Time `json:"read_at"`
+}
+
+// ConversationUnread is the number of received messages past the read cursor of the user in a conversation
+type ConversationUnread struct {
+	MatchID uint  `json:"match_id"`
+	UserID  uint  `json:"user_id"`
+	Unread  int64 `json:"unread"`
+}
+
+type UnreadCounts struct {
+	Total         int64                `json:"total"`
+	Conversations []ConversationUnread `json:"conversations"`
+}
+
+func NewUnreadCounts(conversations []ConversationUnread) UnreadCounts {
+	counts := UnreadCounts{
+		Conversations: conversations,
+	}
+	for _, conversation := range conversations {
+		counts.Total += conversation.Unread
+	}
+	return counts
+}
+
+// NewReadPayload parses the optional body, an empty body reads up to the latest message
+func NewReadPayload(body io.Reader, userID uint, otherUserID string) (ReadParams, error) {
+	params := ReadParams{
+		UserID: userID,
+	}
+	id, err := parseOtherUserID(otherUserID, userID)
+	if err != nil {
+		return params, err
+	}
+	params.OtherUserID = id
+
+	err = json.NewDecoder(body).Decode(&params)
+	if err != nil && err != io.EOF {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	return params, nil
+}
@@ -0,0 +1,95 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/messages/entity"
+)
+
+func TestRead_NewReadPayload(t *testing.T) {
+	tests := []struct {
+		name           string
+		otherUserID    string
+		body           string
+		expectedResult entity.ReadParams
+		expectedErr    error
+	}{
+		{
+			name:        "normal case - up to a message",
+			otherUserID: "2",
+			body:        `{"message_id": 12}`,
+			expectedResult: entity.ReadParams{
+				UserID:      1,
+				OtherUserID: 2,
+				MessageID:   12,
+			},
+		},
+		{
+			name:        "normal case - without body reads up to the latest message",
+			otherUserID: "2",
+			expectedResult: entity.ReadParams{
+				UserID:      1,
+				OtherUserID: 2,
+			},
+		},
+		{
+			name:        "error case with invalid user ID",
+			otherUserID: "abc",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid user; field: user_id"),
+		},
+		{
+			name:        "error case with invalid body",
+			otherUserID: "2",
+			body:        `{"message_id": "12"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: json: cannot unmarshal string into Go struct field ReadParams.message_id of type uint; field: payload"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewReadPayload(strings.NewReader(tc.body), 1, tc.otherUserID)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestRead_NewUnreadCounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		conversations  []entity.ConversationUnread
+		expectedResult entity.UnreadCounts
+	}{
+		{
+			name:           "no unread conversation",
+			conversations:  []entity.ConversationUnread{},
+			expectedResult: entity.UnreadCounts{Conversations: []entity.ConversationUnread{}},
+		},
+		{
+			name: "total of every conversation",
+			conversations: []entity.ConversationUnread{
+				{MatchID: 7, UserID: 2, Unread: 3},
+				{MatchID: 8, UserID: 3, Unread: 2},
+			},
+			expectedResult: entity.UnreadCounts{
+				Total: 5,
+				Conversations: []entity.ConversationUnread{
+					{MatchID: 7, UserID: 2, Unread: 3},
+					{MatchID: 8, UserID: 3, Unread: 2},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, entity.NewUnreadCounts(tc.conversations))
+		})
+	}
+}
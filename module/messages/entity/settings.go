@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Settings contains the tunable values of the messages module, loaded from the environment on startup
+type Settings struct {
+	MaxLength int
+	// TypingTTL is how long a typing signal lasts without another one
+	TypingTTL time.Duration
+	Filter    FilterSettings
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// TypingParams tells the other side of the match that the user is typing
+type TypingParams struct {
+	UserID      uint
+	OtherUserID uint
+}
+
+// Typing is shown to the other side of the match until ExpiresAt, unless the user keeps typing
+type Typing struct {
+	MatchID   uint      `json:"match_id"`
+	UserID    uint      `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewTypingParams(otherUserID string, userID uint) (TypingParams, error) {
+	params := TypingParams{
+		UserID: userID,
+	}
+	id, err := parseOtherUserID(otherUserID, userID)
+	if err != nil {
+		return params, err
+	}
+	params.OtherUserID = id
+
+	return params, nil
+}
@@ -0,0 +1,41 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/messages/entity"
+)
+
+func TestTyping_NewTypingParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		otherUserID    string
+		expectedResult entity.TypingParams
+		expectedErr    error
+	}{
+		{
+			name:           "normal case",
+			otherUserID:    "2",
+			expectedResult: entity.TypingParams{UserID: 1, OtherUserID: 2},
+		},
+		{
+			name:        "error case with same user ID",
+			otherUserID: "1",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid user; field: user_id"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewTypingParams(tc.otherUserID, 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
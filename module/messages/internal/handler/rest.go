@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/usecase"
+)
+
+type MessagesResource struct {
+	MessageUsecase    usecase.MessageUsecase
+	ModerationUsecase usecase.ModerationUsecase
+	cursorSigner      *utils.CursorSigner
+	logger            *log.Logger
+}
+
+func NewMessagesResource(messageUsecase usecase.MessageUsecase, moderationUsecase usecase.ModerationUsecase, cursorSigner *utils.CursorSigner, logger *log.Logger) *MessagesResource {
+	return &MessagesResource{
+		MessageUsecase:    messageUsecase,
+		ModerationUsecase: moderationUsecase,
+		cursorSigner:      cursorSigner,
+		logger:            logger,
+	}
+}
+
+// Send answers with 201 for a new message, 200 for a retry of one already sent and 202 for a message held for moderation
+func (resource *MessagesResource) Send(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewSendMessagePayload(r.Body, userID, chi.URLParam(r, "user_id"))
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	message, err := resource.MessageUsecase.Send(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	if message.Held {
+		m.HTTPStatus = http.StatusAccepted
+		meta := utils.Meta{
+			HTTPStatus: http.StatusAccepted,
+		}
+		body := utils.NewMessageResponse("Message held for review", meta)
+		body.WriteAPIResponse(w, r, http.StatusAccepted)
+		return
+	}
+
+	httpStatus := http.StatusOK
+	if message.Created {
+		httpStatus = http.StatusCreated
+	}
+	m.HTTPStatus = httpStatus
+	meta := utils.Meta{
+		HTTPStatus: httpStatus,
+	}
+	body := utils.NewDataResponse(message, meta)
+	body.WriteAPIResponse(w, r, httpStatus)
+}
+
+func (resource *MessagesResource) List(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params, err := entity.NewMessageListParams(chi.URLParam(r, "user_id"), userID, page)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	messages, nextPage, err := resource.MessageUsecase.List(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(messages, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewReadPayload(r.Body, userID, chi.URLParam(r, "user_id"))
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	err = resource.MessageUsecase.MarkRead(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewMessageResponse("Conversation marked as read", meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) Unread(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	counts, err := resource.MessageUsecase.Unread(r.Context(), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(counts, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) Typing(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewTypingParams(chi.URLParam(r, "user_id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	typing, err := resource.MessageUsecase.Typing(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(typing, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) Conversations(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params := entity.ConversationListParams{
+		UserID: userID,
+		Page:   page,
+	}
+	conversations, nextPage, err := resource.MessageUsecase.Conversations(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(conversations, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) FlaggedMessages(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params, err := entity.NewFlaggedQueueParams(r.URL.Query().Get("status"), page, userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	flaggedMessages, nextPage, err := resource.ModerationUsecase.FlaggedQueue(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(flaggedMessages, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) ApproveFlaggedMessage(w http.ResponseWriter, r *http.Request) {
+	resource.reviewFlaggedMessage(w, r, resource.ModerationUsecase.Approve)
+}
+
+func (resource *MessagesResource) RejectFlaggedMessage(w http.ResponseWriter, r *http.Request) {
+	resource.reviewFlaggedMessage(w, r, resource.ModerationUsecase.Reject)
+}
+
+// reviewFlaggedMessage runs a moderator review on the flagged message of the path and answers with the reviewed message
+func (resource *MessagesResource) reviewFlaggedMessage(w http.ResponseWriter, r *http.Request, review func(ctx context.Context, params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error)) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewFlaggedReviewParams(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	flagged, err := review(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(flagged, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *MessagesResource) getUserIDFromContext(r *http.Request) uint {
+	return uint(r.Context().Value(utils.CtxUserIDKey).(float64))
+}
+
+func (resource *MessagesResource) returnErrorResponse(w http.ResponseWriter, r *http.Request, err error) int {
+	errOrig, ok := err.(*utils.StandardError)
+	if !ok {
+		httpStatus := http.StatusInternalServerError
+		resource.logger.Error(errors.WithStack(err).Error(), utils.BuildRequestLogFields(r, httpStatus)...)
+		writeErrorResponse(w, r, utils.ErrorInternalServerResponse, httpStatus)
+		return httpStatus
+	}
+
+	httpStatus := http.StatusBadRequest
+	if errOrig.HttpStatus != 0 {
+		httpStatus = errOrig.HttpStatus
+	}
+	writeErrorResponse(w, r, errOrig, httpStatus)
+	resource.logger.Error(err.Error(), utils.BuildRequestLogFields(r, httpStatus)...)
+	return httpStatus
+}
+
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, err *utils.StandardError, statusCode int) {
+	body := utils.NewErrorResponse(err, statusCode)
+	body.WriteAPIResponse(w, r, statusCode)
+}
@@ -0,0 +1,1182 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	mockshandler "timble/mocks/module/messages/internal_/usecase"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/handler"
+	"timble/module/messages/internal/repository"
+	"timble/module/messages/internal/usecase"
+)
+
+var (
+	stdErrorResponseBase = `{
+   	"meta":{
+      	"http_status":%d
+   	},
+   	"error_detail": {
+   		"message": "%s",
+   		"code": "%s",
+   		"field": "%s"
+   	}
+	}`
+
+	stdErrorResponseWithoutField = `{
+   	"meta":{
+      	"http_status":%d
+   	},
+   	"error_detail": {
+   		"message": "%s",
+   		"code": "%s"
+   	}
+	}`
+
+	messageResponseString = `{
+	   "meta":{
+	      "http_status":%d
+	   },
+	   "data":{
+	      "id":5,
+	      "match_id":7,
+	      "sender_id":1,
+	      "receiver_id":2,
+	      "client_message_id":"client-1",
+	      "body":"hello",
+	      "created_at":"2025-02-02T00:00:00Z"
+	   }
+	}`
+
+	messageResponseBase = `{
+   	"meta":{
+      	"http_status":%d
+   	},
+   	"message":"%s"
+	}`
+
+	testCursorSigner = utils.NewCursorSigner([]byte("secretz"))
+)
+
+type shouldMock struct {
+	handlerFunc bool
+}
+
+type expected struct {
+	expectedResponse   string
+	expectedHTTPStatus int
+}
+
+func Test_NewMessagesResource(t *testing.T) {
+	t.Run("new messages resource", func(t *testing.T) {
+		muc := usecase.NewMessageUsecase(usecase.FilterPipeline{}, entity.Settings{}, &repository.RedisRepository{}, &repository.PostgresRepository{}, &log.Logger{})
+
+		mduc := usecase.NewModerationUsecase(&repository.RedisRepository{}, &repository.PostgresRepository{}, &log.Logger{})
+
+		res := handler.NewMessagesResource(muc, mduc, testCursorSigner, &log.Logger{})
+
+		assert.IsType(t, &handler.MessagesResource{}, res)
+	})
+}
+
+func TestMessagesResource_Send(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	message := entity.Message{
+		ID:              5,
+		MatchID:         7,
+		SenderID:        1,
+		ReceiverID:      2,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+		CreatedAt:       timestamp,
+	}
+	createdMessage := message
+	createdMessage.Created = true
+	heldMessage := message
+	heldMessage.ID = 0
+	heldMessage.Held = true
+
+	normalRequestData := `{"client_message_id": "client-1", "body": "hello"}`
+	normalRequestDataParsed := entity.SendMessageParams{
+		SenderID:        1,
+		ReceiverID:      2,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+	}
+
+	type args struct {
+		args              uint
+		receiverID        string
+		requestData       string
+		requestDataParsed entity.SendMessageParams
+	}
+
+	type mocked struct {
+		handlerResult entity.Message
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - new message",
+			args: args{
+				args:              1,
+				receiverID:        "2",
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: createdMessage,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusCreated,
+				expectedResponse:   fmt.Sprintf(messageResponseString, http.StatusCreated),
+			},
+		},
+		{
+			name: "normal case - message held for moderation",
+			args: args{
+				args:              1,
+				receiverID:        "2",
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: heldMessage,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusAccepted,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusAccepted, "Message held for review"),
+			},
+		},
+		{
+			name: "normal case - retried message",
+			args: args{
+				args:              1,
+				receiverID:        "2",
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: message,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseString, http.StatusOK),
+			},
+		},
+		{
+			name: "error case - invalid receiver ID",
+			args: args{
+				args:        1,
+				receiverID:  "abc",
+				requestData: normalRequestData,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid user", "PARAMETER_PARSING_FAILS", "user_id"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args:              1,
+				receiverID:        "2",
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: usecase.NoActiveMatchError(),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "No active match with this user", "NO_ACTIVE_MATCH", "user_id"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args:              1,
+				receiverID:        "2",
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewMessageUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/messages/" + tc.args.receiverID
+
+			req := httptest.NewRequest(http.MethodPost, urlPath, bytes.NewBuffer([]byte(tc.args.requestData)))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			chi.RouteContext(ctx).URLParams.Add("user_id", tc.args.receiverID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Send", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(uc, mockshandler.NewModerationUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Send)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_List(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	messages := []entity.Message{
+		{
+			ID:              5,
+			MatchID:         7,
+			SenderID:        2,
+			ReceiverID:      1,
+			ClientMessageID: "client-1",
+			Body:            "hello",
+			CreatedAt:       timestamp,
+		},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp, ID: 5}
+
+	messagesResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "id":5,
+	         "match_id":7,
+	         "sender_id":2,
+	         "receiver_id":1,
+	         "client_message_id":"client-1",
+	         "body":"hello",
+	         "created_at":"2025-02-02T00:00:00Z"
+	      }
+	   ]
+	}`
+
+	type args struct {
+		args              uint
+		otherUserID       string
+		query             string
+		requestDataParsed entity.MessageListParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.Message
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully list messages",
+			args: args{
+				args:        1,
+				otherUserID: "2",
+				query:       "?limit=1",
+				requestDataParsed: entity.MessageListParams{
+					UserID:      1,
+					OtherUserID: 2,
+					Page:        utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: messages,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messagesResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid limit",
+			args: args{
+				args:        1,
+				otherUserID: "2",
+				query:       "?limit=0",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Limit must be between 1 and 100", "PARAMETER_PARSING_FAILS", "limit"),
+			},
+		},
+		{
+			name: "error case - invalid user ID",
+			args: args{
+				args:        1,
+				otherUserID: "1",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid user", "PARAMETER_PARSING_FAILS", "user_id"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args:        1,
+				otherUserID: "2",
+				requestDataParsed: entity.MessageListParams{
+					UserID:      1,
+					OtherUserID: 2,
+					Page:        utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewMessageUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/messages/" + tc.args.otherUserID + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			chi.RouteContext(ctx).URLParams.Add("user_id", tc.args.otherUserID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("List", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(uc, mockshandler.NewModerationUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.List)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_MarkRead(t *testing.T) {
+	type args struct {
+		args              uint
+		otherUserID       string
+		requestData       string
+		requestDataParsed entity.ReadParams
+	}
+
+	type mocked struct {
+		handlerError error
+	}
+
+	normalRequestDataParsed := entity.ReadParams{
+		UserID:      1,
+		OtherUserID: 2,
+		MessageID:   12,
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully mark as read",
+			args: args{
+				args:              1,
+				otherUserID:       "2",
+				requestData:       `{"message_id": 12}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Conversation marked as read"),
+			},
+		},
+		{
+			name: "error case - invalid user ID",
+			args: args{
+				args:        1,
+				otherUserID: "abc",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid user", "PARAMETER_PARSING_FAILS", "user_id"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args:              1,
+				otherUserID:       "2",
+				requestData:       `{"message_id": 12}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: usecase.NoActiveMatchError(),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "No active match with this user", "NO_ACTIVE_MATCH", "user_id"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args:              1,
+				otherUserID:       "2",
+				requestData:       `{"message_id": 12}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewMessageUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/messages/" + tc.args.otherUserID + "/read"
+
+			req := httptest.NewRequest(http.MethodPost, urlPath, bytes.NewBuffer([]byte(tc.args.requestData)))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			chi.RouteContext(ctx).URLParams.Add("user_id", tc.args.otherUserID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("MarkRead", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(uc, mockshandler.NewModerationUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.MarkRead)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_Unread(t *testing.T) {
+	unreadResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "total":3,
+	      "conversations":[
+	         {
+	            "match_id":7,
+	            "user_id":2,
+	            "unread":3
+	         }
+	      ]
+	   }
+	}`
+
+	type mocked struct {
+		handlerResult entity.UnreadCounts
+		handlerError  error
+	}
+
+	cases := []struct {
+		name     string
+		mocked   mocked
+		expected expected
+	}{
+		{
+			name: "normal case - successfully get unread counts",
+			mocked: mocked{
+				handlerResult: entity.UnreadCounts{
+					Total:         3,
+					Conversations: []entity.ConversationUnread{{MatchID: 7, UserID: 2, Unread: 3}},
+				},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   unreadResponseString,
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewMessageUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/protected/messages/unread", bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			uc.
+				On("Unread", ctx, uint(1)).
+				Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+
+			st := handler.NewMessagesResource(uc, mockshandler.NewModerationUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Unread)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_Typing(t *testing.T) {
+	expiresAt, _ := time.Parse("1/2/2006", "2/2/2025")
+	typingResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "match_id":7,
+	      "user_id":1,
+	      "expires_at":"2025-02-02T00:00:00Z"
+	   }
+	}`
+
+	type args struct {
+		args              uint
+		otherUserID       string
+		requestDataParsed entity.TypingParams
+	}
+
+	type mocked struct {
+		handlerResult entity.Typing
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully signal typing",
+			args: args{
+				args:              1,
+				otherUserID:       "2",
+				requestDataParsed: entity.TypingParams{UserID: 1, OtherUserID: 2},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: entity.Typing{MatchID: 7, UserID: 1, ExpiresAt: expiresAt},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   typingResponseString,
+			},
+		},
+		{
+			name: "error case - invalid user ID",
+			args: args{
+				args:        1,
+				otherUserID: "0",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid user", "PARAMETER_PARSING_FAILS", "user_id"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args:              1,
+				otherUserID:       "2",
+				requestDataParsed: entity.TypingParams{UserID: 1, OtherUserID: 2},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewMessageUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/messages/" + tc.args.otherUserID + "/typing"
+
+			req := httptest.NewRequest(http.MethodPost, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			chi.RouteContext(ctx).URLParams.Add("user_id", tc.args.otherUserID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Typing", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(uc, mockshandler.NewModerationUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Typing)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_Conversations(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	lastMessageID, lastMessageSenderID, lastMessageSnippet := uint(12), uint(2), "hello"
+	conversations := []entity.Conversation{
+		{
+			MatchID:             7,
+			UserID:              2,
+			LastMessageID:       &lastMessageID,
+			LastMessageSenderID: &lastMessageSenderID,
+			LastMessageSnippet:  &lastMessageSnippet,
+			LastMessageAt:       &timestamp,
+			LastActivityAt:      timestamp,
+			Unread:              3,
+		},
+		{
+			MatchID:        6,
+			UserID:         3,
+			LastActivityAt: timestamp.Add(-time.Hour),
+		},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp.Add(-time.Hour), ID: 6}
+
+	conversationsResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "match_id":7,
+	         "user_id":2,
+	         "last_message_id":12,
+	         "last_message_sender_id":2,
+	         "last_message_snippet":"hello",
+	         "last_message_at":"2025-02-02T00:00:00Z",
+	         "last_activity_at":"2025-02-02T00:00:00Z",
+	         "unread":3
+	      },
+	      {
+	         "match_id":6,
+	         "user_id":3,
+	         "last_message_id":null,
+	         "last_message_sender_id":null,
+	         "last_message_snippet":null,
+	         "last_message_at":null,
+	         "last_activity_at":"2025-02-01T23:00:00Z",
+	         "unread":0
+	      }
+	   ]
+	}`
+
+	type args struct {
+		query             string
+		requestDataParsed entity.ConversationListParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.Conversation
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully list conversations",
+			args: args{
+				query: "?limit=1",
+				requestDataParsed: entity.ConversationListParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: conversations,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(conversationsResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid limit",
+			args: args{
+				query: "?limit=0",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Limit must be between 1 and 100", "PARAMETER_PARSING_FAILS", "limit"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				requestDataParsed: entity.ConversationListParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewMessageUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/conversations" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Conversations", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(uc, mockshandler.NewModerationUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Conversations)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_FlaggedMessages(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	flaggedMessages := []entity.FlaggedMessage{
+		{ID: 3, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", Reason: "link", Status: "pending", CreatedAt: timestamp},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp, ID: 3}
+
+	flaggedMessagesResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "id":3,
+	         "match_id":7,
+	         "sender_id":1,
+	         "receiver_id":2,
+	         "client_message_id":"client-1",
+	         "body":"hello",
+	         "reason":"link",
+	         "status":"pending",
+	         "created_at":"2025-02-02T00:00:00Z"
+	      }
+	   ]
+	}`
+
+	type args struct {
+		query             string
+		requestDataParsed entity.FlaggedQueueParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.FlaggedMessage
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully list flagged messages",
+			args: args{
+				query: "?limit=1",
+				requestDataParsed: entity.FlaggedQueueParams{
+					ModeratorID: 1,
+					Status:      "pending",
+					Page:        utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: flaggedMessages,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(flaggedMessagesResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid status",
+			args: args{
+				query: "?status=claimed",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid flagged message status", "PARAMETER_PARSING_FAILS", "status"),
+			},
+		},
+		{
+			name: "error case - not a moderator",
+			args: args{
+				requestDataParsed: entity.FlaggedQueueParams{
+					ModeratorID: 1,
+					Status:      "pending",
+					Page:        utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: &utils.StandardError{Message: "Only moderators can review flagged messages", Code: "FORBIDDEN", HttpStatus: http.StatusForbidden},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusForbidden,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusForbidden, "Only moderators can review flagged messages", "FORBIDDEN"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewModerationUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/moderation/messages" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("FlaggedQueue", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(mockshandler.NewMessageUsecase(t), uc, testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.FlaggedMessages)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestMessagesResource_ReviewFlaggedMessage(t *testing.T) {
+	moderatorID := uint(1)
+	flaggedResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "id":3,
+	      "match_id":7,
+	      "sender_id":1,
+	      "receiver_id":2,
+	      "client_message_id":"client-1",
+	      "body":"hello",
+	      "reason":"link",
+	      "status":"%s",
+	      "reviewed_by":1,
+	      "created_at":"0001-01-01T00:00:00Z"
+	   }
+	}`
+
+	type args struct {
+		action            string
+		flaggedMessageID  string
+		requestDataParsed entity.FlaggedReviewParams
+	}
+
+	type mocked struct {
+		handlerResult *entity.FlaggedMessage
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully approve flagged message",
+			args: args{
+				action:            "Approve",
+				flaggedMessageID:  "3",
+				requestDataParsed: entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.FlaggedMessage{ID: 3, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", Reason: "link", Status: "approved", ReviewedBy: &moderatorID},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(flaggedResponseString, "approved"),
+			},
+		},
+		{
+			name: "normal case - successfully reject flagged message",
+			args: args{
+				action:            "Reject",
+				flaggedMessageID:  "3",
+				requestDataParsed: entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.FlaggedMessage{ID: 3, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", Reason: "link", Status: "rejected", ReviewedBy: &moderatorID},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(flaggedResponseString, "rejected"),
+			},
+		},
+		{
+			name: "error case - invalid flagged message ID",
+			args: args{
+				action:           "Approve",
+				flaggedMessageID: "abc",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid flagged message", "PARAMETER_PARSING_FAILS", "id"),
+			},
+		},
+		{
+			name: "error case - flagged message already reviewed",
+			args: args{
+				action:            "Reject",
+				flaggedMessageID:  "3",
+				requestDataParsed: entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.NewStandardError("Flagged message not found or already reviewed", "NOT FOUND", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "Flagged message not found or already reviewed", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				action:            "Approve",
+				flaggedMessageID:  "3",
+				requestDataParsed: entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewModerationUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/moderation/messages/" + tc.args.flaggedMessageID + "/" + strings.ToLower(tc.args.action)
+
+			req := httptest.NewRequest(http.MethodPost, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			chi.RouteContext(ctx).URLParams.Add("id", tc.args.flaggedMessageID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On(tc.args.action, ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewMessagesResource(mockshandler.NewMessageUsecase(t), uc, testCursorSigner, logger)
+
+			hndlr := map[string]http.HandlerFunc{
+				"Approve": st.ApproveFlaggedMessage,
+				"Reject":  st.RejectFlaggedMessage,
+			}[tc.args.action]
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func initRoutingContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+}
+
+func initLogger(t *testing.T) *log.Logger {
+	config := log.NewProductionConfig()
+	config.Level = log.NewAtomicLevelAt(log.FatalLevel)
+
+	logger, err := config.Build()
+	assert.Nil(t, err)
+	return logger
+}
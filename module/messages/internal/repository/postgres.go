@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"github.com/pkg/errors"
+
+	"timble/internal/connection/postgres"
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+)
+
+const (
+	// the pair of an active match can message each other as long as neither of them is hidden
+	ACTIVE_MATCH_CONDITION = `
+        m.ended_at IS NULL
+        AND NOT EXISTS (
+          SELECT 1 FROM users u WHERE u.id IN (m.first_user_id, m.second_user_id) AND u.hidden_at IS NOT NULL
+        )
+    `
+
+	// the message is only saved through an active match of the pair, a retried client message ID returns the saved one.
+	// xmax is only zero on a row this statement inserted. A new message also becomes the last one of the conversation
+	// of both sides, unread by the receiver; the older of two concurrent messages does not overwrite the newer one
+	INSERT_MESSAGE_QUERY = `
+      WITH inserted AS (
+        INSERT INTO messages (
+          match_id, sender_id, receiver_id, client_message_id, body
+        )
+        SELECT
+          m.id, ?, ?, ?, ?
+        FROM
+          matches m
+        WHERE
+          m.first_user_id = ? AND m.second_user_id = ? AND ` + ACTIVE_MATCH_CONDITION + `
+        ON CONFLICT(sender_id, client_message_id)
+        DO UPDATE SET
+          client_message_id = messages.client_message_id
+        RETURNING
+          id, match_id, sender_id, receiver_id, client_message_id, body, created_at, (xmax = 0) AS created
+      ), conversation AS (
+        INSERT INTO conversations (
+          match_id, user_id, other_user_id, last_message_id, last_message_sender_id, last_message_at, unread
+        )
+        SELECT
+          i.match_id, p.user_id, p.other_user_id, i.id, i.sender_id, i.created_at, p.unread
+        FROM
+          inserted i
+        CROSS JOIN LATERAL
+          (VALUES (i.sender_id, i.receiver_id, 0), (i.receiver_id, i.sender_id, 1)) AS p(user_id, other_user_id, unread)
+        WHERE
+          i.created
+        ON CONFLICT(match_id, user_id)
+        DO UPDATE SET
+          last_message_id = GREATEST(conversations.last_message_id, EXCLUDED.last_message_id),
+          last_message_sender_id = CASE WHEN COALESCE(conversations.last_message_id, 0) < EXCLUDED.last_message_id
+            THEN EXCLUDED.last_message_sender_id ELSE conversations.last_message_sender_id END,
+          last_message_at = CASE WHEN COALESCE(conversations.last_message_id, 0) < EXCLUDED.last_message_id
+            THEN EXCLUDED.last_message_at ELSE conversations.last_message_at END,
+          unread = conversations.unread + EXCLUDED.unread
+      )
+      SELECT
+        *
+      FROM
+        inserted
+    `
+
+	// a flagged message is only queued through an active match of the pair, a retried client message ID returns the queued one
+	INSERT_FLAGGED_MESSAGE_QUERY = `
+      INSERT INTO flagged_messages (
+        match_id, sender_id, receiver_id, client_message_id, body, reason
+      )
+      SELECT
+        m.id, ?, ?, ?, ?, ?
+      FROM
+        matches m
+      WHERE
+        m.first_user_id = ? AND m.second_user_id = ? AND ` + ACTIVE_MATCH_CONDITION + `
+      ON CONFLICT(sender_id, client_message_id)
+      DO UPDATE SET
+        client_message_id = flagged_messages.client_message_id
+      RETURNING
+        id, match_id, sender_id, receiver_id, client_message_id, body, reason, status, created_at
+    `
+
+	// the message the sender already sent with the client message ID, a held one has no ID until it is delivered
+	SELECT_SENT_MESSAGE_QUERY = `
+      SELECT
+        id, match_id, sender_id, receiver_id, client_message_id, body, created_at, FALSE AS held
+      FROM
+        messages
+      WHERE
+        sender_id = ? AND client_message_id = ?
+      UNION ALL
+      SELECT
+        0, match_id, sender_id, receiver_id, client_message_id, body, created_at, TRUE
+      FROM
+        flagged_messages
+      WHERE
+        sender_id = ? AND client_message_id = ?
+      ORDER BY
+        held
+      LIMIT 1
+    `
+
+	SELECT_ACTIVE_MATCH_ID_QUERY = `
+      SELECT
+        m.id
+      FROM
+        matches m
+      WHERE
+        m.first_user_id = ? AND m.second_user_id = ? AND ` + ACTIVE_MATCH_CONDITION
+
+	// the cursor only moves forward, to the latest received message up to the given one when there is one.
+	// No row is returned when the cursor did not move, otherwise the unread count of the conversation is
+	// recounted past the new cursor
+	UPSERT_READ_CURSOR_QUERY = `
+      WITH receipt AS (
+        INSERT INTO message_reads (
+          match_id, user_id, last_read_message_id
+        )
+        SELECT
+          match_id, receiver_id, MAX(id)
+        FROM
+          messages
+        WHERE
+          match_id = ? AND receiver_id = ? AND (? = 0 OR id <= ?)
+        GROUP BY
+          match_id, receiver_id
+        ON CONFLICT(match_id, user_id)
+        DO UPDATE SET
+          last_read_message_id = EXCLUDED.last_read_message_id,
+          read_at = NOW()
+        WHERE
+          message_reads.last_read_message_id < EXCLUDED.last_read_message_id
+        RETURNING
+          match_id, user_id, last_read_message_id, read_at
+      ), conversation AS (
+        UPDATE
+          conversations c
+        SET
+          unread = (
+            SELECT COUNT(*) FROM messages msg
+            WHERE msg.match_id = r.match_id AND msg.receiver_id = r.user_id AND msg.id > r.last_read_message_id
+          )
+        FROM
+          receipt r
+        WHERE
+          c.match_id = r.match_id AND c.user_id = r.user_id
+      )
+      SELECT
+        *
+      FROM
+        receipt
+    `
+
+	SELECT_UNREAD_COUNTS_QUERY = `
+      SELECT
+        c.match_id,
+        c.other_user_id AS user_id,
+        c.unread
+      FROM
+        conversations c
+      JOIN
+        matches m ON m.id = c.match_id
+      WHERE
+        c.user_id = ? AND c.unread > 0 AND ` + ACTIVE_MATCH_CONDITION + `
+      ORDER BY
+        c.match_id
+    `
+
+	// every active match of the user has a conversation from the time it was made, last_message_at holding the match
+	// time until a message is sent, so the page is read off the (user_id, last_message_at) index. The snippet is cut
+	// from the last message when listing, so only entity.MESSAGE_SNIPPET_LENGTH sets its length
+	SELECT_CONVERSATIONS_QUERY = `
+      SELECT
+        c.match_id,
+        c.other_user_id AS user_id,
+        c.last_message_id,
+        c.last_message_sender_id,
+        LEFT(msg.body, ?) AS last_message_snippet,
+        CASE WHEN c.last_message_id IS NULL THEN NULL ELSE c.last_message_at END AS last_message_at,
+        c.last_message_at AS last_activity_at,
+        c.unread
+      FROM
+        conversations c
+      JOIN
+        matches m ON m.id = c.match_id
+      LEFT JOIN
+        messages msg ON msg.id = c.last_message_id
+      WHERE
+        c.user_id = ? AND ` + ACTIVE_MATCH_CONDITION + `
+    `
+
+	FLAGGED_MESSAGE_COLUMNS = `
+        f.id, f.match_id, f.sender_id, f.receiver_id, f.client_message_id, f.body, f.reason, f.status,
+        f.reviewed_by, f.reviewed_at, f.created_at
+    `
+
+	SELECT_FLAGGED_MESSAGES_QUERY = `
+      SELECT
+    ` + FLAGGED_MESSAGE_COLUMNS + `
+      FROM
+        flagged_messages f
+      WHERE
+        f.status = ?
+    `
+
+	// only a pending message of an active match is approved, the match is locked until the message is delivered
+	// so it can not end in between
+	APPROVE_FLAGGED_MESSAGE_QUERY = `
+      UPDATE
+        flagged_messages f
+      SET
+        status = 'approved',
+        reviewed_by = ?,
+        reviewed_at = NOW()
+      WHERE
+        f.id = ?
+        AND f.status = 'pending'
+        AND EXISTS (
+          SELECT 1 FROM matches m WHERE m.id = f.match_id AND ` + ACTIVE_MATCH_CONDITION + ` FOR SHARE
+        )
+      RETURNING
+    ` + FLAGGED_MESSAGE_COLUMNS
+
+	REJECT_FLAGGED_MESSAGE_QUERY = `
+      UPDATE
+        flagged_messages f
+      SET
+        status = 'rejected',
+        reviewed_by = ?,
+        reviewed_at = NOW()
+      WHERE
+        f.id = ?
+        AND f.status = 'pending'
+      RETURNING
+    ` + FLAGGED_MESSAGE_COLUMNS
+
+	SELECT_MODERATOR_QUERY = `
+      SELECT
+        moderator
+      FROM
+        users
+      WHERE
+        id = ?
+    `
+
+	SELECT_MESSAGES_QUERY = `
+      SELECT
+        id, match_id, sender_id, receiver_id, client_message_id, body, created_at
+      FROM
+        messages
+      WHERE
+        match_id = ?
+    `
+)
+
+var (
+	messagesKeyset = postgres.Keyset{
+		CreatedAtColumn: "created_at",
+		IDColumn:        "id",
+	}
+
+	flaggedMessagesKeyset = postgres.Keyset{
+		CreatedAtColumn: "f.created_at",
+		IDColumn:        "f.id",
+	}
+
+	conversationsKeyset = postgres.Keyset{
+		CreatedAtColumn: "c.last_message_at",
+		IDColumn:        "c.match_id",
+	}
+)
+
+type PostgresRepository struct {
+	PostgresClient postgres.PostgresInterface
+}
+
+func NewPostgresRepository(postgresClient postgres.PostgresInterface) *PostgresRepository {
+	return &PostgresRepository{
+		PostgresClient: postgresClient,
+	}
+}
+
+// InsertMessage saves the message when sender and receiver share an active match, it returns nil when they do not
+func (repo *PostgresRepository) InsertMessage(params entity.SendMessageParams) (*entity.Message, error) {
+	result := []entity.Message{}
+	firstUserID, secondUserID := matchPair(params.SenderID, params.ReceiverID)
+	err := repo.PostgresClient.Select(&result, INSERT_MESSAGE_QUERY, params.SenderID, params.ReceiverID, params.ClientMessageID, params.Body, firstUserID, secondUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when insert to messages")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// InsertFlaggedMessage holds the message in the moderation queue, it returns nil when the pair has no active match
+func (repo *PostgresRepository) InsertFlaggedMessage(params entity.SendMessageParams, reason string) (*entity.FlaggedMessage, error) {
+	result := []entity.FlaggedMessage{}
+	firstUserID, secondUserID := matchPair(params.SenderID, params.ReceiverID)
+	err := repo.PostgresClient.Select(&result, INSERT_FLAGGED_MESSAGE_QUERY, params.SenderID, params.ReceiverID, params.ClientMessageID, params.Body, reason, firstUserID, secondUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when insert to flagged messages")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// GetSentMessage returns the message the sender already sent with the client message ID, delivered or held,
+// it returns nil when there is none
+func (repo *PostgresRepository) GetSentMessage(senderID uint, clientMessageID string) (*entity.Message, error) {
+	result := []entity.Message{}
+	err := repo.PostgresClient.Select(&result, SELECT_SENT_MESSAGE_QUERY, senderID, clientMessageID, senderID, clientMessageID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when get sent message")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// GetActiveMatchID returns the active match of the pair, zero when there is none
+func (repo *PostgresRepository) GetActiveMatchID(userID, otherUserID uint) (uint, error) {
+	result := []uint{}
+	firstUserID, secondUserID := matchPair(userID, otherUserID)
+	err := repo.PostgresClient.Select(&result, SELECT_ACTIVE_MATCH_ID_QUERY, firstUserID, secondUserID)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres client error when get active match")
+	}
+
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return result[0], nil
+}
+
+// GetMessages lists the messages of the match, the most recent first
+func (repo *PostgresRepository) GetMessages(matchID uint, page utils.PageParams) ([]entity.Message, error) {
+	result := []entity.Message{}
+	query, args := messagesKeyset.Paginate(SELECT_MESSAGES_QUERY, []interface{}{matchID}, page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get messages")
+	}
+
+	return result, nil
+}
+
+// UpsertReadCursor moves the read cursor of the user, it returns nil when the cursor did not move
+func (repo *PostgresRepository) UpsertReadCursor(matchID, userID, messageID uint) (*entity.ReadReceipt, error) {
+	result := []entity.ReadReceipt{}
+	err := repo.PostgresClient.Select(&result, UPSERT_READ_CURSOR_QUERY, matchID, userID, messageID, messageID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when upsert read cursor")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// GetUnreadCounts lists the active conversations of the user that have unread messages
+func (repo *PostgresRepository) GetUnreadCounts(userID uint) ([]entity.ConversationUnread, error) {
+	result := []entity.ConversationUnread{}
+	err := repo.PostgresClient.Select(&result, SELECT_UNREAD_COUNTS_QUERY, userID)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get unread counts")
+	}
+
+	return result, nil
+}
+
+// GetConversations lists the active matches of the user as conversations, the most recently active first
+func (repo *PostgresRepository) GetConversations(params entity.ConversationListParams) ([]entity.Conversation, error) {
+	result := []entity.Conversation{}
+	args := []interface{}{entity.MESSAGE_SNIPPET_LENGTH, params.UserID}
+	query, args := conversationsKeyset.Paginate(SELECT_CONVERSATIONS_QUERY, args, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get conversations")
+	}
+
+	return result, nil
+}
+
+// IsModerator tells whether the user reviews the moderation queues, it is false for an unknown user
+func (repo *PostgresRepository) IsModerator(userID uint) (bool, error) {
+	result := []bool{}
+	err := repo.PostgresClient.Select(&result, SELECT_MODERATOR_QUERY, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres client error when get moderator")
+	}
+
+	return len(result) > 0 && result[0], nil
+}
+
+// GetFlaggedMessages lists the flagged messages with the status, the latest first
+func (repo *PostgresRepository) GetFlaggedMessages(params entity.FlaggedQueueParams) ([]entity.FlaggedMessage, error) {
+	result := []entity.FlaggedMessage{}
+	query, args := flaggedMessagesKeyset.Paginate(SELECT_FLAGGED_MESSAGES_QUERY, []interface{}{params.Status}, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get flagged messages")
+	}
+
+	return result, nil
+}
+
+// ApproveFlaggedMessage delivers a pending flagged message in a transaction with its review, both are nil
+// when the message is not pending anymore or its match has ended
+func (repo *PostgresRepository) ApproveFlaggedMessage(params entity.FlaggedReviewParams) (*entity.FlaggedMessage, *entity.Message, error) {
+	var flagged *entity.FlaggedMessage
+	var message *entity.Message
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		approved := []entity.FlaggedMessage{}
+		err := tx.Select(&approved, APPROVE_FLAGGED_MESSAGE_QUERY, params.ModeratorID, params.FlaggedMessageID)
+		if err != nil {
+			return err
+		}
+
+		if len(approved) == 0 {
+			return nil
+		}
+
+		inserted := []entity.Message{}
+		review := approved[0]
+		firstUserID, secondUserID := matchPair(review.SenderID, review.ReceiverID)
+		err = tx.Select(&inserted, INSERT_MESSAGE_QUERY, review.SenderID, review.ReceiverID, review.ClientMessageID, review.Body, firstUserID, secondUserID)
+		if err != nil {
+			return err
+		}
+
+		// the match is locked by the approval, so this only happens when one of the pair was hidden in between
+		if len(inserted) == 0 {
+			return errors.New("approved message could not be delivered")
+		}
+
+		flagged, message = &review, &inserted[0]
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "postgres client error when approve flagged message")
+	}
+
+	return flagged, message, nil
+}
+
+// RejectFlaggedMessage drops a pending flagged message, it returns nil when the message is not pending anymore
+func (repo *PostgresRepository) RejectFlaggedMessage(params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error) {
+	result := []entity.FlaggedMessage{}
+	err := repo.PostgresClient.Select(&result, REJECT_FLAGGED_MESSAGE_QUERY, params.ModeratorID, params.FlaggedMessageID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when reject flagged message")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// matchPair orders two user IDs the way the matches table stores them
+func matchPair(userID, otherUserID uint) (uint, uint) {
+	if userID < otherUserID {
+		return userID, otherUserID
+	}
+	return otherUserID, userID
+}
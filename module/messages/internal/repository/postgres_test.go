@@ -0,0 +1,745 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"timble/internal/connection/postgres"
+	"timble/internal/utils"
+	mockspostgres "timble/mocks/internal_/connection/postgres"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/repository"
+)
+
+var (
+	testMessage = entity.Message{
+		ID:              1,
+		MatchID:         7,
+		SenderID:        2,
+		ReceiverID:      1,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+		Created:         true,
+	}
+)
+
+func TestNewPostgresRepository(t *testing.T) {
+	t.Run("new postgres repository", func(t *testing.T) {
+		repo := repository.NewPostgresRepository(&postgres.PostgresClient{})
+
+		assert.IsType(t, &repository.PostgresRepository{}, repo)
+	})
+}
+
+func TestPostgresRepository_InsertMessage(t *testing.T) {
+	params := entity.SendMessageParams{
+		SenderID:        2,
+		ReceiverID:      1,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.Message
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully insert message",
+			expectedResult: &testMessage,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Message{}, repository.INSERT_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", uint(1), uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Message)
+					*arg = append(*arg, testMessage)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no active match",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Message{}, repository.INSERT_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", uint(1), uint(2)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Message{}, repository.INSERT_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", uint(1), uint(2)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when insert to messages: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.InsertMessage(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_InsertFlaggedMessage(t *testing.T) {
+	params := entity.SendMessageParams{
+		SenderID:        2,
+		ReceiverID:      1,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+	}
+	flagged := entity.FlaggedMessage{
+		ID:              3,
+		MatchID:         7,
+		SenderID:        2,
+		ReceiverID:      1,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+		Reason:          "link",
+		Status:          "pending",
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.FlaggedMessage
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully hold message",
+			expectedResult: &flagged,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, repository.INSERT_FLAGGED_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", "link", uint(1), uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.FlaggedMessage)
+					*arg = append(*arg, flagged)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no active match",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, repository.INSERT_FLAGGED_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", "link", uint(1), uint(2)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, repository.INSERT_FLAGGED_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", "link", uint(1), uint(2)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when insert to flagged messages: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.InsertFlaggedMessage(params, "link")
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetSentMessage(t *testing.T) {
+	message := entity.Message{ID: 5, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello"}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.Message
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - message already sent",
+			expectedResult: &message,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Message{}, repository.SELECT_SENT_MESSAGE_QUERY, uint(1), "client-1", uint(1), "client-1").Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Message)
+					*arg = append(*arg, message)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - message not sent yet",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Message{}, repository.SELECT_SENT_MESSAGE_QUERY, uint(1), "client-1", uint(1), "client-1").Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Message{}, repository.SELECT_SENT_MESSAGE_QUERY, uint(1), "client-1", uint(1), "client-1").Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get sent message: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetSentMessage(1, "client-1")
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetActiveMatchID(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   uint
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - active match found",
+			expectedResult: 7,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.SELECT_ACTIVE_MATCH_ID_QUERY, uint(1), uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]uint)
+					*arg = append(*arg, 7)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no active match",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.SELECT_ACTIVE_MATCH_ID_QUERY, uint(1), uint(2)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.SELECT_ACTIVE_MATCH_ID_QUERY, uint(1), uint(2)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get active match: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetActiveMatchID(2, 1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetMessages(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	messages := []entity.Message{testMessage}
+	tests := []struct {
+		name             string
+		args             utils.PageParams
+		expectedError    error
+		expectedResult   []entity.Message
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - next page of messages",
+			args:           utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 8}},
+			expectedResult: messages,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_MESSAGES_QUERY + " AND (created_at, id) < (?, ?) ORDER BY created_at DESC, id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.Message{}, query, uint(7), timestamp, uint(8), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Message)
+					*arg = append(*arg, messages...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			args:           utils.PageParams{Limit: 10},
+			expectedResult: []entity.Message{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_MESSAGES_QUERY + " AND TRUE ORDER BY created_at DESC, id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.Message{}, query, uint(7), 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get messages: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetMessages(7, tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_UpsertReadCursor(t *testing.T) {
+	readAt, _ := time.Parse("1/2/2006", "2/2/2025")
+	receipt := entity.ReadReceipt{MatchID: 7, UserID: 1, LastReadMessageID: 12, ReadAt: readAt}
+	tests := []struct {
+		name             string
+		messageID        uint
+		expectedError    error
+		expectedResult   *entity.ReadReceipt
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - cursor moved to the latest message",
+			expectedResult: &receipt,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.ReadReceipt{}, repository.UPSERT_READ_CURSOR_QUERY, uint(7), uint(1), uint(0), uint(0)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.ReadReceipt)
+					*arg = append(*arg, receipt)
+				}).Return(nil)
+			},
+		},
+		{
+			name:      "normal case - cursor already past the given message",
+			messageID: 10,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.ReadReceipt{}, repository.UPSERT_READ_CURSOR_QUERY, uint(7), uint(1), uint(10), uint(10)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.ReadReceipt{}, repository.UPSERT_READ_CURSOR_QUERY, uint(7), uint(1), uint(0), uint(0)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when upsert read cursor: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.UpsertReadCursor(7, 1, tc.messageID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetUnreadCounts(t *testing.T) {
+	conversations := []entity.ConversationUnread{{MatchID: 7, UserID: 2, Unread: 3}}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   []entity.ConversationUnread
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get unread counts",
+			expectedResult: conversations,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.ConversationUnread{}, repository.SELECT_UNREAD_COUNTS_QUERY, uint(1)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.ConversationUnread)
+					*arg = append(*arg, conversations...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: []entity.ConversationUnread{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.ConversationUnread{}, repository.SELECT_UNREAD_COUNTS_QUERY, uint(1)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get unread counts: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetUnreadCounts(1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetConversations(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	lastMessageID, lastMessageSenderID, lastMessageSnippet := uint(12), uint(2), "hello"
+	conversations := []entity.Conversation{
+		{
+			MatchID:             7,
+			UserID:              2,
+			LastMessageID:       &lastMessageID,
+			LastMessageSenderID: &lastMessageSenderID,
+			LastMessageSnippet:  &lastMessageSnippet,
+			LastMessageAt:       &timestamp,
+			LastActivityAt:      timestamp,
+			Unread:              3,
+		},
+		{
+			MatchID:        6,
+			UserID:         3,
+			LastActivityAt: timestamp.Add(-time.Hour),
+		},
+	}
+	tests := []struct {
+		name             string
+		args             utils.PageParams
+		expectedError    error
+		expectedResult   []entity.Conversation
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - next page of conversations",
+			args:           utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 8}},
+			expectedResult: conversations,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_CONVERSATIONS_QUERY + " AND (c.last_message_at, c.match_id) < (?, ?) ORDER BY c.last_message_at DESC, c.match_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.Conversation{}, query, entity.MESSAGE_SNIPPET_LENGTH, uint(1), timestamp, uint(8), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Conversation)
+					*arg = append(*arg, conversations...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			args:           utils.PageParams{Limit: 10},
+			expectedResult: []entity.Conversation{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_CONVERSATIONS_QUERY + " AND TRUE ORDER BY c.last_message_at DESC, c.match_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.Conversation{}, query, entity.MESSAGE_SNIPPET_LENGTH, uint(1), 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get conversations: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetConversations(entity.ConversationListParams{UserID: 1, Page: tc.args})
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_IsModerator(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   bool
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - user is a moderator",
+			expectedResult: true,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]bool{}, repository.SELECT_MODERATOR_QUERY, uint(9)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]bool)
+					*arg = append(*arg, true)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - unknown user",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]bool{}, repository.SELECT_MODERATOR_QUERY, uint(9)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]bool{}, repository.SELECT_MODERATOR_QUERY, uint(9)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get moderator: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.IsModerator(9)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetFlaggedMessages(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	flaggedMessages := []entity.FlaggedMessage{{ID: 3, MatchID: 7, SenderID: 2, ReceiverID: 1, Body: "hello", Reason: "link", Status: "pending"}}
+	tests := []struct {
+		name             string
+		args             utils.PageParams
+		expectedError    error
+		expectedResult   []entity.FlaggedMessage
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - next page of flagged messages",
+			args:           utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 8}},
+			expectedResult: flaggedMessages,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_FLAGGED_MESSAGES_QUERY + " AND (f.created_at, f.id) < (?, ?) ORDER BY f.created_at DESC, f.id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, query, "pending", timestamp, uint(8), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.FlaggedMessage)
+					*arg = append(*arg, flaggedMessages...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			args:           utils.PageParams{Limit: 10},
+			expectedResult: []entity.FlaggedMessage{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_FLAGGED_MESSAGES_QUERY + " AND TRUE ORDER BY f.created_at DESC, f.id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, query, "pending", 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get flagged messages: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetFlaggedMessages(entity.FlaggedQueueParams{ModeratorID: 9, Status: "pending", Page: tc.args})
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_ApproveFlaggedMessage(t *testing.T) {
+	params := entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 9}
+	moderatorID := uint(9)
+	approved := entity.FlaggedMessage{
+		ID:              3,
+		MatchID:         7,
+		SenderID:        2,
+		ReceiverID:      1,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+		Reason:          "link",
+		Status:          "approved",
+		ReviewedBy:      &moderatorID,
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedFlagged  *entity.FlaggedMessage
+		expectedMessage  *entity.Message
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:            "normal case - message approved and delivered",
+			expectedFlagged: &approved,
+			expectedMessage: &testMessage,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", &[]entity.FlaggedMessage{}, repository.APPROVE_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.FlaggedMessage)
+					*arg = append(*arg, approved)
+				}).Return(nil)
+				tx.On("Select", &[]entity.Message{}, repository.INSERT_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", uint(1), uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Message)
+					*arg = append(*arg, testMessage)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "normal case - message already reviewed or match ended",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", &[]entity.FlaggedMessage{}, repository.APPROVE_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "error case - approved message not delivered",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", &[]entity.FlaggedMessage{}, repository.APPROVE_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.FlaggedMessage)
+					*arg = append(*arg, approved)
+				}).Return(nil)
+				tx.On("Select", &[]entity.Message{}, repository.INSERT_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", uint(1), uint(2)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when approve flagged message: approved message could not be delivered"),
+		},
+		{
+			name: "error case - error when delivering",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", &[]entity.FlaggedMessage{}, repository.APPROVE_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.FlaggedMessage)
+					*arg = append(*arg, approved)
+				}).Return(nil)
+				tx.On("Select", &[]entity.Message{}, repository.INSERT_MESSAGE_QUERY, uint(2), uint(1), "client-1", "hello", uint(1), uint(2)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when approve flagged message: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			flagged, message, err := repo.ApproveFlaggedMessage(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedFlagged, flagged)
+			assert.Equal(t, tc.expectedMessage, message)
+		})
+	}
+}
+
+func TestPostgresRepository_RejectFlaggedMessage(t *testing.T) {
+	params := entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 9}
+	moderatorID := uint(9)
+	rejected := entity.FlaggedMessage{ID: 3, MatchID: 7, SenderID: 2, ReceiverID: 1, Body: "hello", Reason: "link", Status: "rejected", ReviewedBy: &moderatorID}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.FlaggedMessage
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - message rejected",
+			expectedResult: &rejected,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, repository.REJECT_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.FlaggedMessage)
+					*arg = append(*arg, rejected)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - message already reviewed",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, repository.REJECT_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.FlaggedMessage{}, repository.REJECT_FLAGGED_MESSAGE_QUERY, uint(9), uint(3)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when reject flagged message: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.RejectFlaggedMessage(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
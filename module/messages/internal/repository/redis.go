@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"timble/internal/connection/redis"
+	"timble/internal/utils"
+)
+
+type RedisRepository struct {
+	redisClient redis.RedisInterface
+}
+
+func NewRedisRepository(redisClient redis.RedisInterface) *RedisRepository {
+	return &RedisRepository{redisClient}
+}
+
+func (repo *RedisRepository) Incr(ctx context.Context, key string, expire time.Duration) (int64, error) {
+	res, err := repo.redisClient.Incr(ctx, key)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when incr")
+	}
+
+	if res == 1 && expire != 0 {
+		repo.redisClient.Expire(ctx, key, expire)
+	}
+
+	return res, nil
+}
+
+// PublishEvent publishes the event to the user and keeps it in their event log, it returns the ID of the logged event
+func (repo *RedisRepository) PublishEvent(ctx context.Context, userID uint, payload string) (string, error) {
+	res, err := repo.redisClient.PublishEvent(ctx, utils.BuildEventStreamKey(userID), utils.BuildNotificationChannel(userID), payload, utils.EVENT_LOG_MAX_LENGTH, utils.EVENT_LOG_TTL)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when publish event")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error) {
+	res, err := repo.redisClient.Set(ctx, key, value, expire)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when set")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) Get(ctx context.Context, key string) (string, error) {
+	res, err := repo.redisClient.Get(ctx, key)
+	if err != nil {
+		return "", errors.Wrap(err, "redis client error when get")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) Del(ctx context.Context, key string) (int64, error) {
+	res, err := repo.redisClient.Del(ctx, key)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when del")
+	}
+
+	return res, nil
+}
+
+// PublishLiveEvent publishes the event to the user without keeping it in their event log, for events only useful
+// while they happen. It returns how many connections received it
+func (repo *RedisRepository) PublishLiveEvent(ctx context.Context, userID uint, payload string) (int64, error) {
+	res, err := repo.redisClient.Publish(ctx, utils.BuildNotificationChannel(userID), payload)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when publish live event")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+)
+
+type RedisRepository interface {
+	Incr(ctx context.Context, key string, expire time.Duration) (int64, error)
+	Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error)
+	Get(ctx context.Context, key string) (string, error)
+	Del(ctx context.Context, key string) (int64, error)
+	PublishEvent(ctx context.Context, userID uint, payload string) (string, error)
+	PublishLiveEvent(ctx context.Context, userID uint, payload string) (int64, error)
+}
+
+type PostgresRepository interface {
+	InsertMessage(params entity.SendMessageParams) (*entity.Message, error)
+	GetSentMessage(senderID uint, clientMessageID string) (*entity.Message, error)
+	GetActiveMatchID(userID, otherUserID uint) (uint, error)
+	GetMessages(matchID uint, page utils.PageParams) ([]entity.Message, error)
+	UpsertReadCursor(matchID, userID, messageID uint) (*entity.ReadReceipt, error)
+	GetUnreadCounts(userID uint) ([]entity.ConversationUnread, error)
+	GetConversations(params entity.ConversationListParams) ([]entity.Conversation, error)
+	InsertFlaggedMessage(params entity.SendMessageParams, reason string) (*entity.FlaggedMessage, error)
+	IsModerator(userID uint) (bool, error)
+	GetFlaggedMessages(params entity.FlaggedQueueParams) ([]entity.FlaggedMessage, error)
+	ApproveFlaggedMessage(params entity.FlaggedReviewParams) (*entity.FlaggedMessage, *entity.Message, error)
+	RejectFlaggedMessage(params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error)
+}
+
+func NoActiveMatchError() *utils.StandardError {
+	return utils.NewStandardError("No active match with this user", "NO_ACTIVE_MATCH", "user_id")
+}
+
+func BlockedMessageError(reason string) *utils.StandardError {
+	return utils.NewStandardError("Message not allowed: "+reason, "MESSAGE_BLOCKED", "body")
+}
+
+func BuildTypingRedisKey(matchID, userID uint) string {
+	return fmt.Sprintf("typing:%d:%d", matchID, userID)
+}
+
+// BuildDuplicateRedisKey counts the messages the sender delivered to the receiver with the same body, whatever its
+// case or spacing
+func BuildDuplicateRedisKey(senderID, receiverID uint, body string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(body)), " ")
+	hash := sha256.Sum256([]byte(normalized))
+	return fmt.Sprintf("message_duplicate:%d:%d:%s", senderID, receiverID, hex.EncodeToString(hash[:]))
+}
+
+// publishNotification publishes the notification to the user and their event log, delivery is best effort
+func publishNotification(ctx context.Context, redis RedisRepository, userID uint, notification entity.Notification) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return
+	}
+	redis.PublishEvent(ctx, userID, string(payload))
+}
+
+// publishLiveNotification publishes the notification to the user only, it is not replayed to a client resuming
+// from their event log. Delivery is best effort
+func publishLiveNotification(ctx context.Context, redis RedisRepository, userID uint, notification entity.Notification) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return
+	}
+	redis.PublishLiveEvent(ctx, userID, string(payload))
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"timble/module/messages/entity"
+)
+
+var (
+	linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|io|co|me|ly|gg|app|link|xyz|info|biz|site|online|ru)\b`)
+	// eight digits or more, however they are spaced or separated
+	phonePattern = regexp.MustCompile(`\+?\d(?:[\s.\-()]*\d){7,}`)
+)
+
+// MessageFilter checks a message before it is sent, and is told once a message was delivered
+type MessageFilter interface {
+	Check(ctx context.Context, params entity.SendMessageParams) (entity.FilterVerdict, error)
+	Delivered(ctx context.Context, message entity.Message) error
+}
+
+// FilterPipeline runs its filters in order and merges their verdicts, the first block stops it
+type FilterPipeline []MessageFilter
+
+// NewDefaultFilters checks the keyword list, links and phone numbers, then identical messages sent in a row
+func NewDefaultFilters(settings entity.FilterSettings, redis RedisRepository) FilterPipeline {
+	return FilterPipeline{
+		NewKeywordFilter(settings.Keywords, settings.KeywordAction),
+		NewPatternFilter(linkPattern, settings.LinkAction, entity.FILTER_REASON_LINK),
+		NewPatternFilter(phonePattern, settings.PhoneAction, entity.FILTER_REASON_PHONE),
+		NewDuplicateFilter(redis, settings.DuplicateLimit, settings.DuplicateWindow),
+	}
+}
+
+func (pipeline FilterPipeline) Check(ctx context.Context, params entity.SendMessageParams) (entity.FilterVerdict, error) {
+	verdict := entity.AllowVerdict()
+	for _, filter := range pipeline {
+		result, err := filter.Check(ctx, params)
+		if err != nil {
+			return verdict, errors.WithStack(err)
+		}
+
+		verdict = verdict.Merge(result)
+		if verdict.Action == entity.FILTER_ACTION_BLOCK {
+			break
+		}
+	}
+	return verdict, nil
+}
+
+// Delivered tells every filter, even when one of them fails
+func (pipeline FilterPipeline) Delivered(ctx context.Context, message entity.Message) error {
+	var result error
+	for _, filter := range pipeline {
+		if err := filter.Delivered(ctx, message); err != nil && result == nil {
+			result = errors.WithStack(err)
+		}
+	}
+	return result
+}
+
+// KeywordFilter catches messages containing any of the keywords as a whole word, ignoring case
+type KeywordFilter struct {
+	pattern *regexp.Regexp
+	action  entity.FilterAction
+}
+
+func NewKeywordFilter(keywords []string, action entity.FilterAction) *KeywordFilter {
+	quoted := []string{}
+	for _, keyword := range keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" {
+			quoted = append(quoted, regexp.QuoteMeta(keyword))
+		}
+	}
+
+	filter := &KeywordFilter{
+		action: action,
+	}
+	if len(quoted) > 0 {
+		filter.pattern = regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return filter
+}
+
+func (filter KeywordFilter) Check(ctx context.Context, params entity.SendMessageParams) (entity.FilterVerdict, error) {
+	if filter.pattern == nil || !filter.pattern.MatchString(params.Body) {
+		return entity.AllowVerdict(), nil
+	}
+	return entity.NewFilterVerdict(filter.action, entity.FILTER_REASON_KEYWORD), nil
+}
+
+func (filter KeywordFilter) Delivered(ctx context.Context, message entity.Message) error {
+	return nil
+}
+
+// PatternFilter catches messages matching the pattern, such as links or phone numbers
+type PatternFilter struct {
+	pattern *regexp.Regexp
+	action  entity.FilterAction
+	reason  string
+}
+
+func NewPatternFilter(pattern *regexp.Regexp, action entity.FilterAction, reason string) *PatternFilter {
+	return &PatternFilter{
+		pattern: pattern,
+		action:  action,
+		reason:  reason,
+	}
+}
+
+func (filter PatternFilter) Check(ctx context.Context, params entity.SendMessageParams) (entity.FilterVerdict, error) {
+	if !filter.pattern.MatchString(params.Body) {
+		return entity.AllowVerdict(), nil
+	}
+	return entity.NewFilterVerdict(filter.action, filter.reason), nil
+}
+
+func (filter PatternFilter) Delivered(ctx context.Context, message entity.Message) error {
+	return nil
+}
+
+// DuplicateFilter blocks a sender repeating the same message to the same receiver more than the limit within the
+// window. Only delivered messages are counted, so a blocked, held or failed send does not use up the limit
+type DuplicateFilter struct {
+	redis  RedisRepository
+	limit  int
+	window time.Duration
+}
+
+func NewDuplicateFilter(redis RedisRepository, limit int, window time.Duration) *DuplicateFilter {
+	return &DuplicateFilter{
+		redis:  redis,
+		limit:  limit,
+		window: window,
+	}
+}
+
+func (filter DuplicateFilter) Check(ctx context.Context, params entity.SendMessageParams) (entity.FilterVerdict, error) {
+	if filter.limit <= 0 {
+		return entity.AllowVerdict(), nil
+	}
+
+	res, err := filter.redis.Get(ctx, BuildDuplicateRedisKey(params.SenderID, params.ReceiverID, params.Body))
+	if err != nil {
+		return entity.AllowVerdict(), errors.WithStack(err)
+	}
+
+	// a missing key reads as empty, nothing was delivered within the window
+	count, _ := strconv.Atoi(res)
+	if count >= filter.limit {
+		return entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_DUPLICATE), nil
+	}
+	return entity.AllowVerdict(), nil
+}
+
+func (filter DuplicateFilter) Delivered(ctx context.Context, message entity.Message) error {
+	if filter.limit <= 0 {
+		return nil
+	}
+
+	_, err := filter.redis.Incr(ctx, BuildDuplicateRedisKey(message.SenderID, message.ReceiverID, message.Body), filter.window)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,365 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	mocksrepo "timble/mocks/module/messages/internal_/usecase"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/repository"
+	uc "timble/module/messages/internal/usecase"
+)
+
+var (
+	defaultFilterSettings = entity.FilterSettings{
+		Keywords:        []string{"scam", "send money"},
+		KeywordAction:   entity.FILTER_ACTION_BLOCK,
+		LinkAction:      entity.FILTER_ACTION_FLAG,
+		PhoneAction:     entity.FILTER_ACTION_FLAG,
+		DuplicateLimit:  3,
+		DuplicateWindow: 10 * time.Minute,
+	}
+)
+
+func TestNewDefaultFilters(t *testing.T) {
+	t.Run("new default filters", func(t *testing.T) {
+		pipeline := uc.NewDefaultFilters(defaultFilterSettings, &repository.RedisRepository{})
+
+		assert.Len(t, pipeline, 4)
+	})
+}
+
+func TestFilterPipeline_Check(t *testing.T) {
+	params := entity.SendMessageParams{SenderID: 1, ReceiverID: 2, Body: "hello"}
+	link := entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK)
+	phone := entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_PHONE)
+	keyword := entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_KEYWORD)
+
+	type mocked struct {
+		verdict entity.FilterVerdict
+		err     error
+	}
+	tests := []struct {
+		name           string
+		mocked         []mocked
+		expectedResult entity.FilterVerdict
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - no filters",
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:           "normal case - every filter allows",
+			mocked:         []mocked{{verdict: entity.AllowVerdict()}, {verdict: entity.AllowVerdict()}},
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:   "normal case - flags are merged",
+			mocked: []mocked{{verdict: link}, {verdict: entity.AllowVerdict()}, {verdict: phone}},
+			expectedResult: entity.FilterVerdict{
+				Action:  entity.FILTER_ACTION_FLAG,
+				Reasons: []string{entity.FILTER_REASON_LINK, entity.FILTER_REASON_PHONE},
+			},
+		},
+		{
+			name:   "normal case - a block stops the pipeline",
+			mocked: []mocked{{verdict: link}, {verdict: keyword}},
+			expectedResult: entity.FilterVerdict{
+				Action:  entity.FILTER_ACTION_BLOCK,
+				Reasons: []string{entity.FILTER_REASON_LINK, entity.FILTER_REASON_KEYWORD},
+			},
+		},
+		{
+			name:        "error case - error from a filter",
+			mocked:      []mocked{{verdict: entity.AllowVerdict()}, {err: errors.New("Error Check")}},
+			expectedErr: errors.New("Error Check"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			pipeline := uc.FilterPipeline{}
+			for _, m := range tc.mocked {
+				filter := mocksrepo.NewMessageFilter(t)
+				filter.On("Check", ctx, params).Return(m.verdict, m.err)
+				pipeline = append(pipeline, filter)
+			}
+			if len(tc.mocked) > 0 && tc.expectedResult.Action == entity.FILTER_ACTION_BLOCK {
+				// the filter after the block is never called
+				pipeline = append(pipeline, mocksrepo.NewMessageFilter(t))
+			}
+
+			result, err := pipeline.Check(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestKeywordFilter_Check(t *testing.T) {
+	tests := []struct {
+		name           string
+		keywords       []string
+		body           string
+		expectedResult entity.FilterVerdict
+	}{
+		{
+			name:           "keyword ignoring case",
+			keywords:       defaultFilterSettings.Keywords,
+			body:           "this is not a SCAM",
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_KEYWORD),
+		},
+		{
+			name:           "keyword of several words",
+			keywords:       defaultFilterSettings.Keywords,
+			body:           "please send money today",
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_KEYWORD),
+		},
+		{
+			name:           "keyword inside another word",
+			keywords:       defaultFilterSettings.Keywords,
+			body:           "scampi for dinner?",
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:           "no keywords",
+			keywords:       []string{" "},
+			body:           "this is not a scam",
+			expectedResult: entity.AllowVerdict(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := uc.NewKeywordFilter(tc.keywords, entity.FILTER_ACTION_BLOCK)
+
+			result, err := filter.Check(context.Background(), entity.SendMessageParams{Body: tc.body})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestDefaultFilters_LinksAndPhoneNumbers(t *testing.T) {
+	settings := defaultFilterSettings
+	settings.DuplicateLimit = 0
+	pipeline := uc.NewDefaultFilters(settings, &repository.RedisRepository{})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedResult entity.FilterVerdict
+	}{
+		{
+			name:           "plain message",
+			body:           "see you at 10.30 on 12/10?",
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:           "link with scheme",
+			body:           "check https://example.test/win",
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK),
+		},
+		{
+			name:           "bare domain",
+			body:           "go to Free-Gifts.com now",
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK),
+		},
+		{
+			name:           "phone number with separators",
+			body:           "call me +62 812-3456-7890",
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_PHONE),
+		},
+		{
+			name: "link and phone number",
+			body: "www.example.test or (021) 555 1234",
+			expectedResult: entity.FilterVerdict{
+				Action:  entity.FILTER_ACTION_FLAG,
+				Reasons: []string{entity.FILTER_REASON_LINK, entity.FILTER_REASON_PHONE},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := pipeline.Check(context.Background(), entity.SendMessageParams{Body: tc.body})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPatternFilter_Check(t *testing.T) {
+	t.Run("allowed action lets the message through", func(t *testing.T) {
+		settings := defaultFilterSettings
+		settings.LinkAction = entity.FILTER_ACTION_ALLOW
+		settings.DuplicateLimit = 0
+		pipeline := uc.NewDefaultFilters(settings, &repository.RedisRepository{})
+
+		result, err := pipeline.Check(context.Background(), entity.SendMessageParams{Body: "https://example.test"})
+		assert.Nil(t, err)
+		assert.Equal(t, entity.AllowVerdict(), result)
+	})
+}
+
+func TestDuplicateFilter_Check(t *testing.T) {
+	params := entity.SendMessageParams{SenderID: 1, ReceiverID: 2, Body: "Hey  there"}
+	key := uc.BuildDuplicateRedisKey(1, 2, "hey there")
+
+	tests := []struct {
+		name           string
+		limit          int
+		mockGet        bool
+		count          string
+		getError       error
+		expectedResult entity.FilterVerdict
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - nothing delivered",
+			limit:          3,
+			mockGet:        true,
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:           "normal case - under the limit",
+			limit:          3,
+			mockGet:        true,
+			count:          "2",
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:           "normal case - limit reached",
+			limit:          3,
+			mockGet:        true,
+			count:          "3",
+			expectedResult: entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_DUPLICATE),
+		},
+		{
+			name:           "normal case - check disabled",
+			expectedResult: entity.AllowVerdict(),
+		},
+		{
+			name:        "error case - error from redis",
+			limit:       3,
+			mockGet:     true,
+			getError:    errors.New("Error Get"),
+			expectedErr: errors.New("Error Get"),
+		},
+	}
+	for _, tc := range tests {
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.mockGet {
+				redis.On("Get", ctx, key).Return(tc.count, tc.getError)
+			}
+
+			filter := uc.NewDuplicateFilter(redis, tc.limit, 10*time.Minute)
+
+			result, err := filter.Check(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestDuplicateFilter_Delivered(t *testing.T) {
+	message := entity.Message{SenderID: 1, ReceiverID: 2, Body: "Hey  there"}
+	key := uc.BuildDuplicateRedisKey(1, 2, "hey there")
+
+	tests := []struct {
+		name        string
+		limit       int
+		mockIncr    bool
+		incrError   error
+		expectedErr error
+	}{
+		{
+			name:     "normal case - delivered message is counted",
+			limit:    3,
+			mockIncr: true,
+		},
+		{
+			name: "normal case - check disabled",
+		},
+		{
+			name:        "error case - error from redis",
+			limit:       3,
+			mockIncr:    true,
+			incrError:   errors.New("Error Incr"),
+			expectedErr: errors.New("Error Incr"),
+		},
+	}
+	for _, tc := range tests {
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.mockIncr {
+				redis.On("Incr", ctx, key, 10*time.Minute).Return(int64(1), tc.incrError)
+			}
+
+			filter := uc.NewDuplicateFilter(redis, tc.limit, 10*time.Minute)
+
+			err := filter.Delivered(ctx, message)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFilterPipeline_Delivered(t *testing.T) {
+	message := entity.Message{SenderID: 1, ReceiverID: 2, Body: "hello"}
+
+	tests := []struct {
+		name        string
+		mocked      []error
+		expectedErr error
+	}{
+		{
+			name:   "normal case - every filter is told",
+			mocked: []error{nil, nil},
+		},
+		{
+			name:        "error case - the other filters are still told",
+			mocked:      []error{errors.New("Error Delivered"), nil},
+			expectedErr: errors.New("Error Delivered"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			pipeline := uc.FilterPipeline{}
+			for _, err := range tc.mocked {
+				filter := mocksrepo.NewMessageFilter(t)
+				filter.On("Delivered", ctx, message).Return(err)
+				pipeline = append(pipeline, filter)
+			}
+
+			err := pipeline.Delivered(ctx, message)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
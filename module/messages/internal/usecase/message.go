@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+)
+
+type MessageUsecase interface {
+	Send(ctx context.Context, params entity.SendMessageParams) (entity.Message, error)
+	List(ctx context.Context, params entity.MessageListParams) ([]entity.Message, utils.Page, error)
+	MarkRead(ctx context.Context, params entity.ReadParams) error
+	Unread(ctx context.Context, userID uint) (entity.UnreadCounts, error)
+	Typing(ctx context.Context, params entity.TypingParams) (entity.Typing, error)
+	Conversations(ctx context.Context, params entity.ConversationListParams) ([]entity.Conversation, utils.Page, error)
+}
+
+type MessageUc struct {
+	filter   MessageFilter
+	settings entity.Settings
+	redis    RedisRepository
+	db       PostgresRepository
+	logger   *log.Logger
+}
+
+func NewMessageUsecase(filter MessageFilter, settings entity.Settings, redis RedisRepository, db PostgresRepository, logger *log.Logger) *MessageUc {
+	return &MessageUc{
+		filter:   filter,
+		settings: settings,
+		redis:    redis,
+		db:       db,
+		logger:   logger,
+	}
+}
+
+// Send saves the message to the other side of an active match. Sending the same client message ID again
+// returns the message saved the first time. A message caught by the filters is refused or held for moderation
+func (usecase MessageUc) Send(ctx context.Context, params entity.SendMessageParams) (entity.Message, error) {
+	if utf8.RuneCountInString(params.Body) > usecase.settings.MaxLength {
+		return entity.Message{}, utils.BadRequestParamError(fmt.Sprintf("Message must be at most %d characters", usecase.settings.MaxLength), "body")
+	}
+
+	// a retry is answered before the filters, so it is not filtered or counted as a duplicate again
+	sent, err := usecase.db.GetSentMessage(params.SenderID, params.ClientMessageID)
+	if err != nil {
+		return entity.Message{}, errors.WithStack(err)
+	}
+
+	if sent != nil {
+		return retried(params, *sent)
+	}
+
+	verdict, err := usecase.filter.Check(ctx, params)
+	if err != nil {
+		return entity.Message{}, errors.WithStack(err)
+	}
+
+	switch verdict.Action {
+	case entity.FILTER_ACTION_BLOCK:
+		return entity.Message{}, BlockedMessageError(verdict.Reason())
+	case entity.FILTER_ACTION_FLAG:
+		return usecase.hold(params, verdict)
+	}
+
+	message, err := usecase.db.InsertMessage(params)
+	if err != nil {
+		return entity.Message{}, errors.WithStack(err)
+	}
+
+	if message == nil {
+		return entity.Message{}, NoActiveMatchError()
+	}
+
+	// a retry sent at the same time as the first send
+	if !message.Created {
+		return retried(params, *message)
+	}
+
+	// only a delivered message counts towards the duplicate limit, counting is best effort
+	usecase.filter.Delivered(ctx, *message)
+
+	// the message ends the typing of the sender, the receiver hides it on the message event
+	usecase.redis.Del(ctx, BuildTypingRedisKey(message.MatchID, message.SenderID))
+	publishNotification(ctx, usecase.redis, message.ReceiverID, entity.Notification{
+		Type: entity.NOTIFICATION_TYPE_MESSAGE,
+		Data: message,
+	})
+
+	return *message, nil
+}
+
+// List returns the conversation of an active match, the most recent message first
+func (usecase MessageUc) List(ctx context.Context, params entity.MessageListParams) ([]entity.Message, utils.Page, error) {
+	matchID, err := usecase.activeMatchID(params.UserID, params.OtherUserID)
+	if err != nil {
+		return nil, utils.Page{}, err
+	}
+
+	messages, err := usecase.db.GetMessages(matchID, params.Page)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
+	}
+
+	messages, page := utils.NewPage(messages, params.Page.Limit, func(message entity.Message) utils.Cursor {
+		return utils.Cursor{CreatedAt: message.CreatedAt, ID: message.ID}
+	})
+
+	return messages, page, nil
+}
+
+// MarkRead moves the read cursor of the user forward, the other side is told only when it moved
+func (usecase MessageUc) MarkRead(ctx context.Context, params entity.ReadParams) error {
+	matchID, err := usecase.activeMatchID(params.UserID, params.OtherUserID)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := usecase.db.UpsertReadCursor(matchID, params.UserID, params.MessageID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if receipt != nil {
+		publishLiveNotification(ctx, usecase.redis, params.OtherUserID, entity.Notification{
+			Type: entity.NOTIFICATION_TYPE_READ,
+			Data: receipt,
+		})
+	}
+
+	return nil
+}
+
+// Unread counts the received messages past the read cursor of every active conversation of the user
+func (usecase MessageUc) Unread(ctx context.Context, userID uint) (entity.UnreadCounts, error) {
+	conversations, err := usecase.db.GetUnreadCounts(userID)
+	if err != nil {
+		return entity.UnreadCounts{}, errors.WithStack(err)
+	}
+
+	return entity.NewUnreadCounts(conversations), nil
+}
+
+// Typing keeps the typing state of the user in Redis for TypingTTL and tells the other side of the match,
+// clients are expected to signal again while the user keeps typing
+func (usecase MessageUc) Typing(ctx context.Context, params entity.TypingParams) (entity.Typing, error) {
+	matchID, err := usecase.activeMatchID(params.UserID, params.OtherUserID)
+	if err != nil {
+		return entity.Typing{}, err
+	}
+
+	_, err = usecase.redis.Set(ctx, BuildTypingRedisKey(matchID, params.UserID), 1, usecase.settings.TypingTTL)
+	if err != nil {
+		return entity.Typing{}, errors.WithStack(err)
+	}
+
+	typing := entity.Typing{
+		MatchID:   matchID,
+		UserID:    params.UserID,
+		ExpiresAt: time.Now().Add(usecase.settings.TypingTTL),
+	}
+	publishLiveNotification(ctx, usecase.redis, params.OtherUserID, entity.Notification{
+		Type: entity.NOTIFICATION_TYPE_TYPING,
+		Data: typing,
+	})
+
+	return typing, nil
+}
+
+// Conversations returns the inbox of the user, the conversation with the latest message or match first
+func (usecase MessageUc) Conversations(ctx context.Context, params entity.ConversationListParams) ([]entity.Conversation, utils.Page, error) {
+	conversations, err := usecase.db.GetConversations(params)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
+	}
+
+	conversations, page := utils.NewPage(conversations, params.Page.Limit, func(conversation entity.Conversation) utils.Cursor {
+		return utils.Cursor{CreatedAt: conversation.LastActivityAt, ID: conversation.MatchID}
+	})
+
+	return conversations, page, nil
+}
+
+// hold queues the flagged message for moderation, the receiver is not told about it
+func (usecase MessageUc) hold(params entity.SendMessageParams, verdict entity.FilterVerdict) (entity.Message, error) {
+	flagged, err := usecase.db.InsertFlaggedMessage(params, verdict.Reason())
+	if err != nil {
+		return entity.Message{}, errors.WithStack(err)
+	}
+
+	if flagged == nil {
+		return entity.Message{}, NoActiveMatchError()
+	}
+
+	return entity.Message{
+		MatchID:         flagged.MatchID,
+		SenderID:        flagged.SenderID,
+		ReceiverID:      flagged.ReceiverID,
+		ClientMessageID: flagged.ClientMessageID,
+		Body:            flagged.Body,
+		CreatedAt:       flagged.CreatedAt,
+		Held:            true,
+	}, nil
+}
+
+// retried answers a send reusing a client message ID with the message sent first, unless the ID was used for another message
+func retried(params entity.SendMessageParams, message entity.Message) (entity.Message, error) {
+	if message.ReceiverID != params.ReceiverID || message.Body != params.Body {
+		return entity.Message{}, utils.BadRequestParamError("Client message ID already used for another message", "client_message_id")
+	}
+
+	return message, nil
+}
+
+// activeMatchID returns the active match of the pair, or an error when they are not matched anymore
+func (usecase MessageUc) activeMatchID(userID, otherUserID uint) (uint, error) {
+	matchID, err := usecase.db.GetActiveMatchID(userID, otherUserID)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	if matchID == 0 {
+		return 0, NoActiveMatchError()
+	}
+
+	return matchID, nil
+}
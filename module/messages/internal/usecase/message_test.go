@@ -0,0 +1,576 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	mocksrepo "timble/mocks/module/messages/internal_/usecase"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/repository"
+	uc "timble/module/messages/internal/usecase"
+)
+
+var (
+	defaultSettings = entity.Settings{MaxLength: 10, TypingTTL: 5 * time.Second}
+)
+
+func TestNewMessageUsecase(t *testing.T) {
+	t.Run("new message usecase", func(t *testing.T) {
+		usecase := uc.NewMessageUsecase(uc.FilterPipeline{}, entity.Settings{}, &repository.RedisRepository{}, &repository.PostgresRepository{}, &log.Logger{})
+
+		assert.IsType(t, &uc.MessageUc{}, usecase)
+	})
+}
+
+func TestMessageUc_Send(t *testing.T) {
+	params := entity.SendMessageParams{
+		SenderID:        1,
+		ReceiverID:      2,
+		ClientMessageID: "client-1",
+		Body:            "hello",
+	}
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	message := entity.Message{ID: 5, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", CreatedAt: createdAt, Created: true}
+	notification := `{"type":"message","data":{"id":5,"match_id":7,"sender_id":1,"receiver_id":2,"client_message_id":"client-1","body":"hello","created_at":"2026-10-18T09:00:00Z"}}`
+	retried := message
+	retried.Created = false
+	reused := retried
+	reused.Body = "bye"
+	allowed := entity.AllowVerdict()
+	flagged := entity.FlaggedMessage{ID: 3, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", Reason: "link", Status: "pending", CreatedAt: createdAt}
+	held := entity.Message{MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", CreatedAt: createdAt, Held: true}
+
+	tests := []struct {
+		name           string
+		params         entity.SendMessageParams
+		mockSent       bool
+		sentResult     *entity.Message
+		sentError      error
+		mockFilter     bool
+		verdict        entity.FilterVerdict
+		filterError    error
+		mockHold       bool
+		holdResult     *entity.FlaggedMessage
+		holdError      error
+		mockDB         bool
+		dbResult       *entity.Message
+		dbError        error
+		expectedNotify bool
+		expectedResult entity.Message
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - new message notifies the receiver",
+			params:         params,
+			mockFilter:     true,
+			verdict:        allowed,
+			mockDB:         true,
+			dbResult:       &message,
+			expectedNotify: true,
+			expectedResult: message,
+		},
+		{
+			name:           "normal case - retried message is returned without being filtered again",
+			params:         params,
+			mockSent:       true,
+			sentResult:     &retried,
+			expectedResult: retried,
+		},
+		{
+			name:           "normal case - retried held message is still held without being filtered again",
+			params:         params,
+			mockSent:       true,
+			sentResult:     &held,
+			expectedResult: held,
+		},
+		{
+			name:           "normal case - retry racing the first send does not notify again",
+			params:         params,
+			mockFilter:     true,
+			verdict:        allowed,
+			mockDB:         true,
+			dbResult:       &retried,
+			expectedResult: retried,
+		},
+		{
+			name:        "error case - client message ID already sent with another message",
+			params:      params,
+			mockSent:    true,
+			sentResult:  &reused,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Client message ID already used for another message; field: client_message_id"),
+		},
+		{
+			name:        "error case - error when looking up the sent message",
+			params:      params,
+			mockSent:    true,
+			sentError:   errors.New("Error GetSentMessage"),
+			expectedErr: errors.New("Error GetSentMessage"),
+		},
+		{
+			name: "error case - message too long",
+			params: entity.SendMessageParams{
+				SenderID:        1,
+				ReceiverID:      2,
+				ClientMessageID: "client-1",
+				Body:            strings.Repeat("é", 11),
+			},
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Message must be at most 10 characters; field: body"),
+		},
+		{
+			name:        "error case - no active match",
+			params:      params,
+			mockFilter:  true,
+			verdict:     allowed,
+			mockDB:      true,
+			expectedErr: errors.New("Error on\ncode: NO_ACTIVE_MATCH; error: No active match with this user; field: user_id"),
+		},
+		{
+			name:        "error case - client message ID used for another message",
+			params:      params,
+			mockFilter:  true,
+			verdict:     allowed,
+			mockDB:      true,
+			dbResult:    &reused,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Client message ID already used for another message; field: client_message_id"),
+		},
+		{
+			name:           "normal case - flagged message is held for moderation",
+			params:         params,
+			mockFilter:     true,
+			verdict:        entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK),
+			mockHold:       true,
+			holdResult:     &flagged,
+			expectedResult: held,
+		},
+		{
+			name:        "error case - blocked message",
+			params:      params,
+			mockFilter:  true,
+			verdict:     entity.NewFilterVerdict(entity.FILTER_ACTION_BLOCK, entity.FILTER_REASON_KEYWORD),
+			expectedErr: errors.New("Error on\ncode: MESSAGE_BLOCKED; error: Message not allowed: keyword; field: body"),
+		},
+		{
+			name:        "error case - error from filter",
+			params:      params,
+			mockFilter:  true,
+			filterError: errors.New("Error Check"),
+			expectedErr: errors.New("Error Check"),
+		},
+		{
+			name:        "error case - flagged message without active match",
+			params:      params,
+			mockFilter:  true,
+			verdict:     entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK),
+			mockHold:    true,
+			expectedErr: errors.New("Error on\ncode: NO_ACTIVE_MATCH; error: No active match with this user; field: user_id"),
+		},
+		{
+			name:        "error case - error when holding flagged message",
+			params:      params,
+			mockFilter:  true,
+			verdict:     entity.NewFilterVerdict(entity.FILTER_ACTION_FLAG, entity.FILTER_REASON_LINK),
+			mockHold:    true,
+			holdError:   errors.New("Error InsertFlaggedMessage"),
+			expectedErr: errors.New("Error InsertFlaggedMessage"),
+		},
+		{
+			name:        "error case - error from db",
+			params:      params,
+			mockFilter:  true,
+			verdict:     allowed,
+			mockDB:      true,
+			dbError:     errors.New("Error InsertMessage"),
+			expectedErr: errors.New("Error InsertMessage"),
+		},
+	}
+	for _, tc := range tests {
+		filter := mocksrepo.NewMessageFilter(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.mockSent || tc.mockFilter {
+				db.On("GetSentMessage", tc.params.SenderID, tc.params.ClientMessageID).Return(tc.sentResult, tc.sentError)
+			}
+			if tc.mockFilter {
+				filter.On("Check", ctx, tc.params).Return(tc.verdict, tc.filterError)
+			}
+			if tc.mockHold {
+				db.On("InsertFlaggedMessage", tc.params, "link").Return(tc.holdResult, tc.holdError)
+			}
+			if tc.mockDB {
+				db.On("InsertMessage", tc.params).Return(tc.dbResult, tc.dbError)
+			}
+			if tc.expectedNotify {
+				filter.On("Delivered", ctx, *tc.dbResult).Return(nil).Once()
+				redis.On("Del", ctx, "typing:7:1").Return(int64(1), nil).Once()
+				redis.On("PublishEvent", ctx, uint(2), notification).Return("1-0", nil).Once()
+			}
+
+			usecase := uc.NewMessageUsecase(filter, defaultSettings, redis, db, &log.Logger{})
+
+			result, err := usecase.Send(ctx, tc.params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestMessageUc_List(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	params := entity.MessageListParams{
+		UserID:      1,
+		OtherUserID: 2,
+		Page:        utils.PageParams{Limit: 2},
+	}
+	messages := []entity.Message{
+		{ID: 9, MatchID: 7, CreatedAt: timestamp.Add(2 * time.Minute)},
+		{ID: 8, MatchID: 7, CreatedAt: timestamp.Add(time.Minute)},
+		{ID: 7, MatchID: 7, CreatedAt: timestamp},
+	}
+
+	type mocked struct {
+		matchID       uint
+		matchError    error
+		mockMessages  bool
+		dbResult      []entity.Message
+		messagesError error
+	}
+	tests := []struct {
+		name           string
+		mocked         mocked
+		expectedResult []entity.Message
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name: "normal case - page with more results",
+			mocked: mocked{
+				matchID:      7,
+				mockMessages: true,
+				dbResult:     messages,
+			},
+			expectedResult: messages[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: messages[1].CreatedAt, ID: 8},
+			},
+		},
+		{
+			name: "normal case - last page",
+			mocked: mocked{
+				matchID:      7,
+				mockMessages: true,
+				dbResult:     messages[2:],
+			},
+			expectedResult: messages[2:],
+		},
+		{
+			name:        "error case - no active match",
+			expectedErr: errors.New("Error on\ncode: NO_ACTIVE_MATCH; error: No active match with this user; field: user_id"),
+		},
+		{
+			name: "error case - error when get active match",
+			mocked: mocked{
+				matchError: errors.New("Error GetActiveMatchID"),
+			},
+			expectedErr: errors.New("Error GetActiveMatchID"),
+		},
+		{
+			name: "error case - error when get messages",
+			mocked: mocked{
+				matchID:       7,
+				mockMessages:  true,
+				messagesError: errors.New("Error GetMessages"),
+			},
+			expectedErr: errors.New("Error GetMessages"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			db.On("GetActiveMatchID", params.UserID, params.OtherUserID).Return(tc.mocked.matchID, tc.mocked.matchError)
+			if tc.mocked.mockMessages {
+				db.On("GetMessages", tc.mocked.matchID, params.Page).Return(tc.mocked.dbResult, tc.mocked.messagesError)
+			}
+
+			usecase := uc.NewMessageUsecase(uc.FilterPipeline{}, defaultSettings, &repository.RedisRepository{}, db, &log.Logger{})
+
+			result, page, err := usecase.List(context.Background(), params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestMessageUc_MarkRead(t *testing.T) {
+	readAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	params := entity.ReadParams{UserID: 1, OtherUserID: 2}
+	receipt := &entity.ReadReceipt{MatchID: 7, UserID: 1, LastReadMessageID: 12, ReadAt: readAt}
+	notification := `{"type":"read","data":{"match_id":7,"user_id":1,"last_read_message_id":12,"read_at":"2026-10-18T09:00:00Z"}}`
+
+	type mocked struct {
+		matchID      uint
+		matchError   error
+		mockUpsert   bool
+		upsertResult *entity.ReadReceipt
+		upsertError  error
+	}
+	tests := []struct {
+		name           string
+		mocked         mocked
+		expectedNotify bool
+		expectedErr    error
+	}{
+		{
+			name: "normal case - moved cursor notifies the other user",
+			mocked: mocked{
+				matchID:      7,
+				mockUpsert:   true,
+				upsertResult: receipt,
+			},
+			expectedNotify: true,
+		},
+		{
+			name: "normal case - cursor already past the messages",
+			mocked: mocked{
+				matchID:    7,
+				mockUpsert: true,
+			},
+		},
+		{
+			name:        "error case - no active match",
+			expectedErr: errors.New("Error on\ncode: NO_ACTIVE_MATCH; error: No active match with this user; field: user_id"),
+		},
+		{
+			name: "error case - error when upserting read cursor",
+			mocked: mocked{
+				matchID:     7,
+				mockUpsert:  true,
+				upsertError: errors.New("Error UpsertReadCursor"),
+			},
+			expectedErr: errors.New("Error UpsertReadCursor"),
+		},
+	}
+	for _, tc := range tests {
+		redis := mocksrepo.NewRedisRepository(t)
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			db.On("GetActiveMatchID", params.UserID, params.OtherUserID).Return(tc.mocked.matchID, tc.mocked.matchError)
+			if tc.mocked.mockUpsert {
+				db.On("UpsertReadCursor", tc.mocked.matchID, params.UserID, params.MessageID).Return(tc.mocked.upsertResult, tc.mocked.upsertError)
+			}
+			if tc.expectedNotify {
+				redis.On("PublishLiveEvent", ctx, uint(2), notification).Return(int64(1), nil).Once()
+			}
+
+			usecase := uc.NewMessageUsecase(uc.FilterPipeline{}, defaultSettings, redis, db, &log.Logger{})
+
+			err := usecase.MarkRead(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestMessageUc_Unread(t *testing.T) {
+	conversations := []entity.ConversationUnread{
+		{MatchID: 7, UserID: 2, Unread: 3},
+		{MatchID: 8, UserID: 3, Unread: 1},
+	}
+	tests := []struct {
+		name           string
+		dbResult       []entity.ConversationUnread
+		dbError        error
+		expectedResult entity.UnreadCounts
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - unread messages in several conversations",
+			dbResult:       conversations,
+			expectedResult: entity.UnreadCounts{Total: 4, Conversations: conversations},
+		},
+		{
+			name:        "error case - error from db",
+			dbError:     errors.New("Error GetUnreadCounts"),
+			expectedErr: errors.New("Error GetUnreadCounts"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			db.On("GetUnreadCounts", uint(1)).Return(tc.dbResult, tc.dbError)
+
+			usecase := uc.NewMessageUsecase(uc.FilterPipeline{}, defaultSettings, &repository.RedisRepository{}, db, &log.Logger{})
+
+			result, err := usecase.Unread(context.Background(), 1)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestMessageUc_Typing(t *testing.T) {
+	params := entity.TypingParams{UserID: 1, OtherUserID: 2}
+
+	type mocked struct {
+		matchID    uint
+		matchError error
+		mockSet    bool
+		setError   error
+	}
+	tests := []struct {
+		name           string
+		mocked         mocked
+		expectedNotify bool
+		expectedErr    error
+	}{
+		{
+			name: "normal case - typing is kept in redis and told to the other user",
+			mocked: mocked{
+				matchID: 7,
+				mockSet: true,
+			},
+			expectedNotify: true,
+		},
+		{
+			name:        "error case - no active match",
+			expectedErr: errors.New("Error on\ncode: NO_ACTIVE_MATCH; error: No active match with this user; field: user_id"),
+		},
+		{
+			name: "error case - error when get active match",
+			mocked: mocked{
+				matchError: errors.New("Error GetActiveMatchID"),
+			},
+			expectedErr: errors.New("Error GetActiveMatchID"),
+		},
+		{
+			name: "error case - error when setting typing state",
+			mocked: mocked{
+				matchID:  7,
+				mockSet:  true,
+				setError: errors.New("Error Set"),
+			},
+			expectedErr: errors.New("Error Set"),
+		},
+	}
+	for _, tc := range tests {
+		redis := mocksrepo.NewRedisRepository(t)
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			db.On("GetActiveMatchID", params.UserID, params.OtherUserID).Return(tc.mocked.matchID, tc.mocked.matchError)
+			if tc.mocked.mockSet {
+				redis.On("Set", ctx, "typing:7:1", 1, 5*time.Second).Return("OK", tc.mocked.setError)
+			}
+			if tc.expectedNotify {
+				redis.On("PublishLiveEvent", ctx, uint(2), mock.MatchedBy(func(payload string) bool {
+					return strings.HasPrefix(payload, `{"type":"typing","data":{"match_id":7,"user_id":1,"expires_at":`)
+				})).Return(int64(1), nil).Once()
+			}
+
+			usecase := uc.NewMessageUsecase(uc.FilterPipeline{}, defaultSettings, redis, db, &log.Logger{})
+
+			start := time.Now()
+			result, err := usecase.Typing(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, uint(7), result.MatchID)
+				assert.Equal(t, uint(1), result.UserID)
+				assert.WithinDuration(t, start.Add(5*time.Second), result.ExpiresAt, time.Second)
+			}
+		})
+	}
+}
+
+func TestMessageUc_Conversations(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	params := entity.ConversationListParams{
+		UserID: 1,
+		Page:   utils.PageParams{Limit: 2},
+	}
+	conversations := []entity.Conversation{
+		{MatchID: 9, UserID: 4, LastActivityAt: timestamp.Add(2 * time.Minute), Unread: 1},
+		{MatchID: 7, UserID: 2, LastActivityAt: timestamp.Add(time.Minute)},
+		{MatchID: 8, UserID: 3, LastActivityAt: timestamp, Unread: 5},
+	}
+
+	tests := []struct {
+		name           string
+		dbResult       []entity.Conversation
+		dbError        error
+		expectedResult []entity.Conversation
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - page with more results",
+			dbResult:       conversations,
+			expectedResult: conversations[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: conversations[1].LastActivityAt, ID: 7},
+			},
+		},
+		{
+			name:           "normal case - last page",
+			dbResult:       conversations[2:],
+			expectedResult: conversations[2:],
+		},
+		{
+			name:        "error case - error from db",
+			dbError:     errors.New("Error GetConversations"),
+			expectedErr: errors.New("Error GetConversations"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			db.On("GetConversations", params).Return(tc.dbResult, tc.dbError)
+
+			usecase := uc.NewMessageUsecase(uc.FilterPipeline{}, defaultSettings, &repository.RedisRepository{}, db, &log.Logger{})
+
+			result, page, err := usecase.Conversations(context.Background(), params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/messages/entity"
+)
+
+type ModerationUsecase interface {
+	FlaggedQueue(ctx context.Context, params entity.FlaggedQueueParams) ([]entity.FlaggedMessage, utils.Page, error)
+	Approve(ctx context.Context, params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error)
+	Reject(ctx context.Context, params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error)
+}
+
+type ModerationUc struct {
+	redis  RedisRepository
+	db     PostgresRepository
+	logger *log.Logger
+}
+
+func NewModerationUsecase(redis RedisRepository, db PostgresRepository, logger *log.Logger) *ModerationUc {
+	return &ModerationUc{
+		redis:  redis,
+		db:     db,
+		logger: logger,
+	}
+}
+
+// FlaggedQueue lists the flagged messages with the status to a moderator, the latest first
+func (usecase ModerationUc) FlaggedQueue(ctx context.Context, params entity.FlaggedQueueParams) ([]entity.FlaggedMessage, utils.Page, error) {
+	err := usecase.checkModerator(params.ModeratorID)
+	if err != nil {
+		return nil, utils.Page{}, err
+	}
+
+	flaggedMessages, err := usecase.db.GetFlaggedMessages(params)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
+	}
+
+	flaggedMessages, page := utils.NewPage(flaggedMessages, params.Page.Limit, func(flagged entity.FlaggedMessage) utils.Cursor {
+		return utils.Cursor{CreatedAt: flagged.CreatedAt, ID: flagged.ID}
+	})
+
+	return flaggedMessages, page, nil
+}
+
+// Approve delivers a pending flagged message to its receiver as if it was just sent
+func (usecase ModerationUc) Approve(ctx context.Context, params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error) {
+	err := usecase.checkModerator(params.ModeratorID)
+	if err != nil {
+		return nil, err
+	}
+
+	flagged, message, err := usecase.db.ApproveFlaggedMessage(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if flagged == nil {
+		return nil, utils.NewStandardError("Flagged message not found, already reviewed or its match is no longer active", "NOT FOUND", "")
+	}
+
+	if message.Created {
+		publishNotification(ctx, usecase.redis, message.ReceiverID, entity.Notification{
+			Type: entity.NOTIFICATION_TYPE_MESSAGE,
+			Data: message,
+		})
+	}
+
+	return flagged, nil
+}
+
+// Reject drops a pending flagged message, the receiver never sees it
+func (usecase ModerationUc) Reject(ctx context.Context, params entity.FlaggedReviewParams) (*entity.FlaggedMessage, error) {
+	err := usecase.checkModerator(params.ModeratorID)
+	if err != nil {
+		return nil, err
+	}
+
+	flagged, err := usecase.db.RejectFlaggedMessage(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if flagged == nil {
+		return nil, utils.NewStandardError("Flagged message not found or already reviewed", "NOT FOUND", "")
+	}
+
+	return flagged, nil
+}
+
+// checkModerator refuses users who are not moderators, moderators are flagged in the users table
+func (usecase ModerationUc) checkModerator(userID uint) error {
+	moderator, err := usecase.db.IsModerator(userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !moderator {
+		return &utils.StandardError{
+			Message:    "Only moderators can review flagged messages",
+			Code:       "FORBIDDEN",
+			HttpStatus: http.StatusForbidden,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,299 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	mocksrepo "timble/mocks/module/messages/internal_/usecase"
+	"timble/module/messages/entity"
+	"timble/module/messages/internal/repository"
+	uc "timble/module/messages/internal/usecase"
+)
+
+var (
+	errForbidden = errors.New("Error on\ncode: FORBIDDEN; error: Only moderators can review flagged messages; field:")
+)
+
+func TestNewModerationUsecase(t *testing.T) {
+	t.Run("new moderation usecase", func(t *testing.T) {
+		usecase := uc.NewModerationUsecase(&repository.RedisRepository{}, &repository.PostgresRepository{}, &log.Logger{})
+
+		assert.IsType(t, &uc.ModerationUc{}, usecase)
+	})
+}
+
+func TestModerationUc_FlaggedQueue(t *testing.T) {
+	timestamp := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	params := entity.FlaggedQueueParams{
+		ModeratorID: 9,
+		Status:      entity.FLAGGED_STATUS_PENDING,
+		Page:        utils.PageParams{Limit: 2},
+	}
+	flaggedMessages := []entity.FlaggedMessage{
+		{ID: 7, Status: entity.FLAGGED_STATUS_PENDING, CreatedAt: timestamp.Add(2 * time.Minute)},
+		{ID: 6, Status: entity.FLAGGED_STATUS_PENDING, CreatedAt: timestamp.Add(time.Minute)},
+		{ID: 5, Status: entity.FLAGGED_STATUS_PENDING, CreatedAt: timestamp},
+	}
+
+	type shouldMock struct {
+		dbGetFlaggedMessages bool
+	}
+	type mocked struct {
+		dbIsModeratorResult        bool
+		dbIsModeratorError         error
+		dbGetFlaggedMessagesResult []entity.FlaggedMessage
+		dbGetFlaggedMessagesError  error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult []entity.FlaggedMessage
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - page with more results",
+			shouldMock: shouldMock{dbGetFlaggedMessages: true},
+			mocked: mocked{
+				dbIsModeratorResult:        true,
+				dbGetFlaggedMessagesResult: flaggedMessages,
+			},
+			expectedResult: flaggedMessages[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: flaggedMessages[1].CreatedAt, ID: 6},
+			},
+		},
+		{
+			name:       "normal case - last page",
+			shouldMock: shouldMock{dbGetFlaggedMessages: true},
+			mocked: mocked{
+				dbIsModeratorResult:        true,
+				dbGetFlaggedMessagesResult: flaggedMessages[2:],
+			},
+			expectedResult: flaggedMessages[2:],
+		},
+		{
+			name:        "error case - not a moderator",
+			expectedErr: errForbidden,
+		},
+		{
+			name: "error case - error when getting the moderator",
+			mocked: mocked{
+				dbIsModeratorError: errors.New("Error IsModerator"),
+			},
+			expectedErr: errors.New("Error IsModerator"),
+		},
+		{
+			name:       "error case - error when getting the flagged messages",
+			shouldMock: shouldMock{dbGetFlaggedMessages: true},
+			mocked: mocked{
+				dbIsModeratorResult:       true,
+				dbGetFlaggedMessagesError: errors.New("Error GetFlaggedMessages"),
+			},
+			expectedErr: errors.New("Error GetFlaggedMessages"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("IsModerator", uint(9)).Return(tc.mocked.dbIsModeratorResult, tc.mocked.dbIsModeratorError)
+			if tc.shouldMock.dbGetFlaggedMessages {
+				db.On("GetFlaggedMessages", params).Return(tc.mocked.dbGetFlaggedMessagesResult, tc.mocked.dbGetFlaggedMessagesError)
+			}
+
+			usecase := uc.NewModerationUsecase(redis, db, &log.Logger{})
+
+			result, page, err := usecase.FlaggedQueue(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestModerationUc_Approve(t *testing.T) {
+	params := entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 9}
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	approved := &entity.FlaggedMessage{ID: 3, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", Status: entity.FLAGGED_STATUS_APPROVED}
+	message := &entity.Message{ID: 5, MatchID: 7, SenderID: 1, ReceiverID: 2, ClientMessageID: "client-1", Body: "hello", CreatedAt: createdAt, Created: true}
+	notification := `{"type":"message","data":{"id":5,"match_id":7,"sender_id":1,"receiver_id":2,"client_message_id":"client-1","body":"hello","created_at":"2026-10-18T09:00:00Z"}}`
+	delivered := *message
+	delivered.Created = false
+
+	type shouldMock struct {
+		dbApprove bool
+		notify    bool
+	}
+	type mocked struct {
+		dbIsModeratorResult   bool
+		dbApproveFlagged      *entity.FlaggedMessage
+		dbApproveMessage      *entity.Message
+		dbApproveFlaggedError error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult *entity.FlaggedMessage
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - message approved and delivered",
+			shouldMock: shouldMock{dbApprove: true, notify: true},
+			mocked: mocked{
+				dbIsModeratorResult: true,
+				dbApproveFlagged:    approved,
+				dbApproveMessage:    message,
+			},
+			expectedResult: approved,
+		},
+		{
+			name:       "normal case - message already delivered by a retry",
+			shouldMock: shouldMock{dbApprove: true},
+			mocked: mocked{
+				dbIsModeratorResult: true,
+				dbApproveFlagged:    approved,
+				dbApproveMessage:    &delivered,
+			},
+			expectedResult: approved,
+		},
+		{
+			name:       "error case - message already reviewed",
+			shouldMock: shouldMock{dbApprove: true},
+			mocked: mocked{
+				dbIsModeratorResult: true,
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: Flagged message not found, already reviewed or its match is no longer active; field:"),
+		},
+		{
+			name:        "error case - not a moderator",
+			expectedErr: errForbidden,
+		},
+		{
+			name:       "error case - error when approving",
+			shouldMock: shouldMock{dbApprove: true},
+			mocked: mocked{
+				dbIsModeratorResult:   true,
+				dbApproveFlaggedError: errors.New("Error ApproveFlaggedMessage"),
+			},
+			expectedErr: errors.New("Error ApproveFlaggedMessage"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("IsModerator", uint(9)).Return(tc.mocked.dbIsModeratorResult, nil)
+			if tc.shouldMock.dbApprove {
+				db.On("ApproveFlaggedMessage", params).Return(tc.mocked.dbApproveFlagged, tc.mocked.dbApproveMessage, tc.mocked.dbApproveFlaggedError)
+			}
+			if tc.shouldMock.notify {
+				redis.On("PublishEvent", ctx, uint(2), notification).Return("1-0", nil).Once()
+			}
+
+			usecase := uc.NewModerationUsecase(redis, db, &log.Logger{})
+
+			result, err := usecase.Approve(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestModerationUc_Reject(t *testing.T) {
+	params := entity.FlaggedReviewParams{FlaggedMessageID: 3, ModeratorID: 9}
+	rejected := &entity.FlaggedMessage{ID: 3, Status: entity.FLAGGED_STATUS_REJECTED}
+
+	type shouldMock struct {
+		dbReject bool
+	}
+	type mocked struct {
+		dbIsModeratorResult bool
+		dbRejectResult      *entity.FlaggedMessage
+		dbRejectError       error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult *entity.FlaggedMessage
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - message rejected",
+			shouldMock: shouldMock{dbReject: true},
+			mocked: mocked{
+				dbIsModeratorResult: true,
+				dbRejectResult:      rejected,
+			},
+			expectedResult: rejected,
+		},
+		{
+			name:       "error case - message already reviewed",
+			shouldMock: shouldMock{dbReject: true},
+			mocked: mocked{
+				dbIsModeratorResult: true,
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: Flagged message not found or already reviewed; field:"),
+		},
+		{
+			name:        "error case - not a moderator",
+			expectedErr: errForbidden,
+		},
+		{
+			name:       "error case - error when rejecting",
+			shouldMock: shouldMock{dbReject: true},
+			mocked: mocked{
+				dbIsModeratorResult: true,
+				dbRejectError:       errors.New("Error RejectFlaggedMessage"),
+			},
+			expectedErr: errors.New("Error RejectFlaggedMessage"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("IsModerator", uint(9)).Return(tc.mocked.dbIsModeratorResult, nil)
+			if tc.shouldMock.dbReject {
+				db.On("RejectFlaggedMessage", params).Return(tc.mocked.dbRejectResult, tc.mocked.dbRejectError)
+			}
+
+			usecase := uc.NewModerationUsecase(redis, db, &log.Logger{})
+
+			result, err := usecase.Reject(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
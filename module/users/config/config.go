@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"net/http"
 
 	"go.uber.org/zap"
@@ -9,6 +10,7 @@ import (
 	postgres "timble/internal/connection/postgres"
 	redis "timble/internal/connection/redis"
 	"timble/internal/utils"
+	"timble/module/users/entity"
 	"timble/module/users/internal/handler"
 	"timble/module/users/internal/repository"
 	"timble/module/users/internal/usecase"
@@ -18,20 +20,56 @@ import (
 type UsersRESTInterface interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	Show(w http.ResponseWriter, r *http.Request)
+	UpdateTimezone(w http.ResponseWriter, r *http.Request)
 	React(w http.ResponseWriter, r *http.Request)
+	ReactBatch(w http.ResponseWriter, r *http.Request)
+	UndoReaction(w http.ResponseWriter, r *http.Request)
+	ReactionHistory(w http.ResponseWriter, r *http.Request)
+	ReceivedLikes(w http.ResponseWriter, r *http.Request)
+	Matches(w http.ResponseWriter, r *http.Request)
+	Unmatch(w http.ResponseWriter, r *http.Request)
+	Profile(w http.ResponseWriter, r *http.Request)
+	Block(w http.ResponseWriter, r *http.Request)
+	Unblock(w http.ResponseWriter, r *http.Request)
+	Blocks(w http.ResponseWriter, r *http.Request)
+	Report(w http.ResponseWriter, r *http.Request)
+	ReportQueue(w http.ResponseWriter, r *http.Request)
+	ClaimReport(w http.ResponseWriter, r *http.Request)
+	ResolveReport(w http.ResponseWriter, r *http.Request)
+	DismissReport(w http.ResponseWriter, r *http.Request)
+	Quota(w http.ResponseWriter, r *http.Request)
+	Discover(w http.ResponseWriter, r *http.Request)
 	GrantPremium(w http.ResponseWriter, r *http.Request)
 	UnsubscribePremium(w http.ResponseWriter, r *http.Request)
+	ActivateBoost(w http.ResponseWriter, r *http.Request)
+	BoostStatus(w http.ResponseWriter, r *http.Request)
 	Login(w http.ResponseWriter, r *http.Request)
 }
 
-func NewUsersHandler(auth *utils.AuthConfig, logger *zap.Logger, cache cache.CacheInterface, redisClient redis.RedisInterface, postgresClient postgres.PostgresInterface) *handler.UsersResource {
+// background jobs
+type UsersJobInterface interface {
+	RecomputeScores(ctx context.Context) error
+}
+
+func NewUsersHandler(auth *utils.AuthConfig, logger *zap.Logger, cache cache.CacheInterface, redisClient redis.RedisInterface, postgresClient postgres.PostgresInterface, settings entity.Settings) *handler.UsersResource {
 	redisRepository := repository.NewRedisRepository(redisClient)
 	cacheRepository := repository.NewCacheRepository(cache)
 	postgresRepository := repository.NewPostgresRepository(postgresClient)
 
 	authUsecase := usecase.NewAuthUsecase(auth, postgresRepository, logger)
-	premiumUsecase := usecase.NewPremiumUsecase(redisRepository, postgresRepository, cacheRepository, logger)
-	userUsecase := usecase.NewUserUsecase(auth, redisRepository, postgresRepository, cacheRepository, logger)
+	premiumUsecase := usecase.NewPremiumUsecase(settings, redisRepository, postgresRepository, cacheRepository, logger)
+	userUsecase := usecase.NewUserUsecase(auth, settings, redisRepository, postgresRepository, cacheRepository, logger)
+	discoveryUsecase := usecase.NewDiscoveryUsecase(usecase.NewDefaultRanker(settings.Ranking), settings, redisRepository, postgresRepository, logger)
+	reportUsecase := usecase.NewReportUsecase(settings, postgresRepository, logger)
+
+	cursorSigner := utils.NewCursorSigner(auth.SecretKey)
+
+	return handler.NewUsersResource(authUsecase, premiumUsecase, userUsecase, discoveryUsecase, reportUsecase, cursorSigner, logger)
+}
+
+func NewUsersJob(logger *zap.Logger, redisClient redis.RedisInterface, postgresClient postgres.PostgresInterface, settings entity.Settings) UsersJobInterface {
+	redisRepository := repository.NewRedisRepository(redisClient)
+	postgresRepository := repository.NewPostgresRepository(postgresClient)
 
-	return handler.NewUsersResource(authUsecase, premiumUsecase, userUsecase, logger)
+	return usecase.NewDiscoveryUsecase(usecase.NewDefaultRanker(settings.Ranking), settings, redisRepository, postgresRepository, logger)
 }
@@ -12,6 +12,7 @@ import (
 	mockscache "timble/mocks/internal_/connection/cache"
 	mockspostgre "timble/mocks/internal_/connection/postgres"
 	"timble/module/users/config"
+	"timble/module/users/entity"
 	"timble/module/users/internal/handler"
 )
 
@@ -30,7 +31,7 @@ func TestNewSearchTuningRESTHandler(t *testing.T) {
 			cacheClient := mockscache.NewCacheInterface(t)
 			postgresClient := mockspostgre.NewPostgresInterface(t)
 
-			result := config.NewUsersHandler(&utils.AuthConfig{}, &zap.Logger{}, cacheClient, redisClient, postgresClient)
+			result := config.NewUsersHandler(&utils.AuthConfig{}, &zap.Logger{}, cacheClient, redisClient, postgresClient, entity.Settings{})
 
 			assert.NotNil(t, result)
 			assert.IsType(t, &handler.UsersResource{}, result)
@@ -38,3 +39,16 @@ func TestNewSearchTuningRESTHandler(t *testing.T) {
 	}
 	defer s.Close()
 }
+
+func TestNewUsersJob(t *testing.T) {
+	s := miniredis.RunT(t)
+	t.Run("normal case", func(t *testing.T) {
+		redisClient, _ := redis.NewClient(s.Host(), s.Port(), "200ms", "0")
+		postgresClient := mockspostgre.NewPostgresInterface(t)
+
+		result := config.NewUsersJob(&zap.Logger{}, redisClient, postgresClient, entity.Settings{})
+
+		assert.NotNil(t, result)
+	})
+	defer s.Close()
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+
+	"timble/internal/utils"
+)
+
+// MATCH_END_REASON_BLOCKED ends the match of a pair when either side blocks the other, it can not be picked when unmatching
+const MATCH_END_REASON_BLOCKED = "blocked"
+
+// BlockParams is a block of TargetID on behalf of UserID, a block hides each user from the other
+type BlockParams struct {
+	UserID   uint
+	TargetID uint
+}
+
+// BlockListItem is a user blocked by the caller
+type BlockListItem struct {
+	User      UserSummary `json:"user" gorm:"embedded;embeddedPrefix:user_"`
+	CreatedAt time.Time   `json:"blocked_at"`
+}
+
+type BlockListParams struct {
+	UserID uint
+	Page   utils.PageParams
+}
+
+func NewBlockParams(targetID string, userID uint) (BlockParams, error) {
+	params := BlockParams{
+		UserID: userID,
+	}
+
+	id, err := ParseTargetID(targetID, userID)
+	if err != nil {
+		return params, err
+	}
+	params.TargetID = id
+
+	return params, nil
+}
+
+// ParseTargetID reads the ID of another user from the path
+func ParseTargetID(value string, userID uint) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || id == 0 || uint(id) == userID {
+		return 0, utils.BadRequestParamError("Invalid target user", "id")
+	}
+
+	return uint(id), nil
+}
@@ -0,0 +1,51 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/users/entity"
+)
+
+func TestBlock_NewBlockParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		targetID       string
+		expectedResult entity.BlockParams
+		expectedErr    error
+	}{
+		{
+			name:           "normal case",
+			targetID:       "2",
+			expectedResult: entity.BlockParams{UserID: 1, TargetID: 2},
+		},
+		{
+			name:        "error case with invalid target ID",
+			targetID:    "abc",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid target user; field: id"),
+		},
+		{
+			name:        "error case with zero target ID",
+			targetID:    "0",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid target user; field: id"),
+		},
+		{
+			name:        "error case with same target ID as user ID",
+			targetID:    "1",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid target user; field: id"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewBlockParams(tc.targetID, 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Boost is a window during which the user is ranked higher in other users' discovery feeds,
+// it counts the impressions and likes the user got while it was active
+type Boost struct {
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"-"`
+	StartedAt   time.Time `json:"started_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Impressions int64     `json:"impressions"`
+	Likes       int64     `json:"likes"`
+}
+
+type BoostStatus struct {
+	Balance int    `json:"balance"`
+	Active  *Boost `json:"active,omitempty"`
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+const earthRadiusKm = 6371.0
+
+type Candidate struct {
+	ID           uint      `json:"id"`
+	Username     string    `json:"username"`
+	Bio          string    `json:"bio,omitempty"`
+	PhotoURL     string    `json:"photo_url,omitempty"`
+	Latitude     *float64  `json:"-"`
+	Longitude    *float64  `json:"-"`
+	EloScore     float64   `json:"-"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	SuperLiked   bool      `json:"super_liked"`
+	Boosted      bool      `json:"-"`
+	BoostID      uint      `json:"-"`
+	Premium      bool      `json:"-"`
+	Hidden       bool      `json:"-"`
+	Score        float64   `json:"-"`
+}
+
+type UserScore struct {
+	UserID   uint    `json:"user_id"`
+	EloScore float64 `json:"elo_score"`
+}
+
+// HasLocation reports whether the candidate shared their coordinates
+func (c Candidate) HasLocation() bool {
+	return c.Latitude != nil && c.Longitude != nil
+}
+
+// Completeness returns the ratio of optional profile fields that are filled, from 0 to 1
+func (c Candidate) Completeness() float64 {
+	filled := 0
+	if c.Bio != "" {
+		filled++
+	}
+	if c.PhotoURL != "" {
+		filled++
+	}
+	if c.HasLocation() {
+		filled++
+	}
+	return float64(filled) / 3
+}
+
+// DistanceKm returns the great-circle distance between two candidates.
+// The second return value is false when either side has no location.
+func (c Candidate) DistanceKm(other Candidate) (float64, bool) {
+	if !c.HasLocation() || !other.HasLocation() {
+		return 0, false
+	}
+
+	lat1 := *c.Latitude * math.Pi / 180
+	lat2 := *other.Latitude * math.Pi / 180
+	deltaLat := lat2 - lat1
+	deltaLon := (*other.Longitude - *c.Longitude) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a)), true
+}
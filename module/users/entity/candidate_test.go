@@ -0,0 +1,91 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/users/entity"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestCandidate_Completeness(t *testing.T) {
+	tests := []struct {
+		name           string
+		candidate      entity.Candidate
+		expectedResult float64
+	}{
+		{
+			name: "complete profile",
+			candidate: entity.Candidate{
+				Bio:       "hello",
+				PhotoURL:  "https://example.com/photo.jpg",
+				Latitude:  floatPtr(-6.2),
+				Longitude: floatPtr(106.8),
+			},
+			expectedResult: 1,
+		},
+		{
+			name: "profile with bio only",
+			candidate: entity.Candidate{
+				Bio: "hello",
+			},
+			expectedResult: float64(1) / 3,
+		},
+		{
+			name: "profile with half a location",
+			candidate: entity.Candidate{
+				Latitude: floatPtr(-6.2),
+			},
+			expectedResult: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, tc.candidate.Completeness())
+		})
+	}
+}
+
+func TestCandidate_DistanceKm(t *testing.T) {
+	jakarta := entity.Candidate{Latitude: floatPtr(-6.2088), Longitude: floatPtr(106.8456)}
+	bandung := entity.Candidate{Latitude: floatPtr(-6.9175), Longitude: floatPtr(107.6191)}
+
+	tests := []struct {
+		name           string
+		from           entity.Candidate
+		to             entity.Candidate
+		expectedResult float64
+		expectedOk     bool
+	}{
+		{
+			name:           "both candidates have location",
+			from:           jakarta,
+			to:             bandung,
+			expectedResult: 116.4,
+			expectedOk:     true,
+		},
+		{
+			name:           "same location",
+			from:           jakarta,
+			to:             jakarta,
+			expectedResult: 0,
+			expectedOk:     true,
+		},
+		{
+			name: "target without location",
+			from: jakarta,
+			to:   entity.Candidate{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok := tc.from.DistanceKm(tc.to)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.InDelta(t, tc.expectedResult, result, 0.5)
+		})
+	}
+}
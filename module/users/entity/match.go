@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"timble/internal/utils"
+)
+
+const (
+	UNMATCH_REASON_NOT_INTERESTED = "not_interested"
+	UNMATCH_REASON_INAPPROPRIATE  = "inappropriate"
+	UNMATCH_REASON_SPAM           = "spam"
+	UNMATCH_REASON_FAKE_PROFILE   = "fake_profile"
+	UNMATCH_REASON_OTHER          = "other"
+)
+
+// MATCH_END_REASON_UNDONE ends the match made by a like that was undone, it can not be picked when unmatching
+const MATCH_END_REASON_UNDONE = "undone"
+
+var UnmatchReasons = map[string]bool{
+	UNMATCH_REASON_NOT_INTERESTED: true,
+	UNMATCH_REASON_INAPPROPRIATE:  true,
+	UNMATCH_REASON_SPAM:           true,
+	UNMATCH_REASON_FAKE_PROFILE:   true,
+	UNMATCH_REASON_OTHER:          true,
+}
+
+// Match is a mutual like, stored once per pair with the lower user ID first
+type Match struct {
+	ID           uint      `json:"id"`
+	FirstUserID  uint      `json:"first_user_id"`
+	SecondUserID uint      `json:"second_user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	// Created tells apart the match made by this reaction from one that already existed
+	Created bool `json:"-"`
+}
+
+// MatchListItem is a match seen by one of its users, User is the other side. The list is ordered by LastActivityAt,
+// the time of the last message or of the match when no message was sent yet
+type MatchListItem struct {
+	ID             uint        `json:"id"`
+	User           UserSummary `json:"user" gorm:"embedded;embeddedPrefix:user_"`
+	CreatedAt      time.Time   `json:"matched_at"`
+	LastActiveAt   time.Time   `json:"last_active_at"`
+	LastActivityAt time.Time   `json:"last_activity_at"`
+	UnreadCount    int64       `json:"unread_count"`
+}
+
+type MatchListParams struct {
+	UserID uint
+	Page   utils.PageParams
+}
+
+// UnmatchParams ends a match on behalf of one of its users, the reason is never shown to the other side
+type UnmatchParams struct {
+	MatchID uint   `json:"-"`
+	UserID  uint   `json:"-"`
+	Reason  string `json:"reason"`
+}
+
+func NewUnmatchPayload(body io.Reader, matchID string, userID uint) (UnmatchParams, error) {
+	params := UnmatchParams{
+		UserID: userID,
+	}
+	id, err := strconv.ParseUint(matchID, 10, 0)
+	if err != nil || id == 0 {
+		return params, utils.BadRequestParamError("Invalid match", "id")
+	}
+	params.MatchID = uint(id)
+
+	err = json.NewDecoder(body).Decode(&params)
+	if err != nil {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	if !UnmatchReasons[params.Reason] {
+		return params, utils.BadRequestParamError("Invalid unmatch reason", "reason")
+	}
+
+	return params, nil
+}
+
+// ReactionResult is returned once a reaction is saved, RateLimit is sent as headers instead of in the body
+type ReactionResult struct {
+	Matched   bool         `json:"matched"`
+	MatchID   uint         `json:"match_id,omitempty"`
+	RateLimit *QuotaStatus `json:"-"`
+}
+
+// NewReactionResult reports whether the reaction made or completed a match
+func NewReactionResult(match *Match, rateLimit *QuotaStatus) ReactionResult {
+	result := ReactionResult{
+		RateLimit: rateLimit,
+	}
+	if match != nil {
+		result.Matched = true
+		result.MatchID = match.ID
+	}
+	return result
+}
+
+// MatchPair orders two user IDs the way a match stores them
+func MatchPair(userID, otherUserID uint) (uint, uint) {
+	if userID < otherUserID {
+		return userID, otherUserID
+	}
+	return otherUserID, userID
+}
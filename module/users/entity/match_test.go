@@ -0,0 +1,129 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/users/entity"
+)
+
+func TestMatch_NewReactionResult(t *testing.T) {
+	rateLimit := &entity.QuotaStatus{Name: "reaction", Limit: 10, Remaining: 3}
+	tests := []struct {
+		name           string
+		match          *entity.Match
+		expectedResult entity.ReactionResult
+	}{
+		{
+			name:           "reaction without match",
+			expectedResult: entity.ReactionResult{RateLimit: rateLimit},
+		},
+		{
+			name:           "reaction with match",
+			match:          &entity.Match{ID: 9, FirstUserID: 1, SecondUserID: 2},
+			expectedResult: entity.ReactionResult{Matched: true, MatchID: 9, RateLimit: rateLimit},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, entity.NewReactionResult(tc.match, rateLimit))
+		})
+	}
+}
+
+func TestMatch_MatchPair(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         uint
+		otherUserID    uint
+		expectedFirst  uint
+		expectedSecond uint
+	}{
+		{
+			name:           "lower user first",
+			userID:         1,
+			otherUserID:    2,
+			expectedFirst:  1,
+			expectedSecond: 2,
+		},
+		{
+			name:           "higher user first",
+			userID:         5,
+			otherUserID:    3,
+			expectedFirst:  3,
+			expectedSecond: 5,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first, second := entity.MatchPair(tc.userID, tc.otherUserID)
+			assert.Equal(t, tc.expectedFirst, first)
+			assert.Equal(t, tc.expectedSecond, second)
+		})
+	}
+}
+
+func TestMatch_NewUnmatchPayload(t *testing.T) {
+	tests := []struct {
+		name           string
+		matchID        string
+		body           string
+		expectedResult entity.UnmatchParams
+		expectedErr    error
+	}{
+		{
+			name:    "normal case",
+			matchID: "7",
+			body:    `{"reason": "spam"}`,
+			expectedResult: entity.UnmatchParams{
+				MatchID: 7,
+				UserID:  1,
+				Reason:  entity.UNMATCH_REASON_SPAM,
+			},
+		},
+		{
+			name:        "error case with invalid match ID",
+			matchID:     "abc",
+			body:        `{"reason": "spam"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid match; field: id"),
+		},
+		{
+			name:        "error case with zero match ID",
+			matchID:     "0",
+			body:        `{"reason": "spam"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid match; field: id"),
+		},
+		{
+			name:        "error case with invalid body",
+			matchID:     "7",
+			body:        `{"reason": `,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: unexpected EOF; field: payload"),
+		},
+		{
+			name:        "error case with unknown reason",
+			matchID:     "7",
+			body:        `{"reason": "bored"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid unmatch reason; field: reason"),
+		},
+		{
+			name:        "error case with missing reason",
+			matchID:     "7",
+			body:        `{}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid unmatch reason; field: reason"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewUnmatchPayload(strings.NewReader(tc.body), tc.matchID, 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
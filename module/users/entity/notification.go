@@ -0,0 +1,20 @@
+package entity
+
+const (
+	NOTIFICATION_TYPE_LIKE       = "like"
+	NOTIFICATION_TYPE_SUPER_LIKE = "super_like"
+	NOTIFICATION_TYPE_MATCH      = "match"
+	NOTIFICATION_TYPE_UNMATCH    = "unmatch"
+	NOTIFICATION_TYPE_PREMIUM    = "premium"
+)
+
+// Notification is an event pushed to a single user
+type Notification struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// PremiumStatus is the data of a premium notification, sent when the user's premium starts or ends
+type PremiumStatus struct {
+	Premium bool `json:"premium"`
+}
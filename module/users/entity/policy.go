@@ -0,0 +1,228 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+const (
+	TIER_FREE    = "free"
+	TIER_PREMIUM = "premium"
+
+	QUOTA_WINDOW_DAILY   = "daily"
+	QUOTA_WINDOW_HOURLY  = "hourly"
+	QUOTA_WINDOW_ROLLING = "rolling"
+
+	// QUOTA_REWIND is the quota charged when a reaction is undone
+	QUOTA_REWIND = "rewind"
+
+	ENTITLEMENT_SEE_RECEIVED_LIKES = "see_received_likes"
+)
+
+// Policy defines the quotas and entitlements of every tier
+type Policy struct {
+	Tiers map[string]TierPolicy `json:"tiers"`
+}
+
+// TierPolicy lists the quotas and entitlements of a tier. PassCooldown is how long a passed profile stays hidden
+// from discovery, passes never resurface without it
+type TierPolicy struct {
+	Quotas       []QuotaPolicy `json:"quotas"`
+	Entitlements []string      `json:"entitlements"`
+	PassCooldown Duration      `json:"pass_cooldown,omitempty"`
+}
+
+// QuotaPolicy limits how many times an action can be taken within a window.
+// Reactions are charged to the quotas named after their quota buckets, other actions to the quota named after them
+type QuotaPolicy struct {
+	Name    string   `json:"name"`
+	Limit   int      `json:"limit"`
+	Window  string   `json:"window"`
+	Period  Duration `json:"period,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// QuotaStatus is the usage of a quota in its current window
+type QuotaStatus struct {
+	Name      string    `json:"name"`
+	Window    string    `json:"window"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// QuotaSummary lists the quotas of the user's tier, actions without a quota are unlimited
+type QuotaSummary struct {
+	Tier   string        `json:"tier"`
+	Quotas []QuotaStatus `json:"quotas"`
+}
+
+// Duration is a time.Duration written as a string such as "6h" in the policy file
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UserTier returns the tier a user belongs to
+func UserTier(premium bool) string {
+	if premium {
+		return TIER_PREMIUM
+	}
+	return TIER_FREE
+}
+
+// DefaultPolicy is used when no policy file is configured
+func DefaultPolicy() Policy {
+	superLike := QuotaPolicy{
+		Name:    QUOTA_BUCKET_SUPER_LIKE,
+		Limit:   3,
+		Window:  QUOTA_WINDOW_DAILY,
+		Message: "Super like limit exceeded, try again tomorrow",
+	}
+
+	return Policy{
+		Tiers: map[string]TierPolicy{
+			TIER_FREE: {
+				Quotas: []QuotaPolicy{
+					{
+						Name:    QUOTA_BUCKET_REACTION,
+						Limit:   10,
+						Window:  QUOTA_WINDOW_DAILY,
+						Message: "Reaction limit exceeded, try again tommorow",
+					},
+					superLike,
+					{
+						// rewinding is a premium feature, a zero limit turns it down for free users
+						Name:    QUOTA_REWIND,
+						Limit:   0,
+						Window:  QUOTA_WINDOW_DAILY,
+						Message: "Rewind is available for premium users",
+					},
+				},
+				PassCooldown: Duration(30 * 24 * time.Hour),
+			},
+			TIER_PREMIUM: {
+				Quotas: []QuotaPolicy{
+					superLike,
+					{
+						Name:    QUOTA_REWIND,
+						Limit:   10,
+						Window:  QUOTA_WINDOW_DAILY,
+						Message: "Rewind limit exceeded, try again tomorrow",
+					},
+				},
+				Entitlements: []string{ENTITLEMENT_SEE_RECEIVED_LIKES},
+				PassCooldown: Duration(7 * 24 * time.Hour),
+			},
+		},
+	}
+}
+
+// NewPolicy reads and validates a policy written as json
+func NewPolicy(body io.Reader) (Policy, error) {
+	policy := Policy{}
+	err := json.NewDecoder(body).Decode(&policy)
+	if err != nil {
+		return policy, err
+	}
+
+	return policy, policy.Validate()
+}
+
+func (policy Policy) Validate() error {
+	for _, tier := range []string{TIER_FREE, TIER_PREMIUM} {
+		if _, ok := policy.Tiers[tier]; !ok {
+			return fmt.Errorf("policy is missing the %s tier", tier)
+		}
+	}
+
+	for tier, tierPolicy := range policy.Tiers {
+		if tierPolicy.PassCooldown < 0 {
+			return fmt.Errorf("tier %s has a negative pass cooldown", tier)
+		}
+
+		names := map[string]bool{}
+		for _, quota := range tierPolicy.Quotas {
+			if quota.Name == "" {
+				return fmt.Errorf("tier %s has a quota without name", tier)
+			}
+			if names[quota.Name] {
+				return fmt.Errorf("tier %s has duplicated quota %s", tier, quota.Name)
+			}
+			names[quota.Name] = true
+
+			if quota.Limit < 0 {
+				return fmt.Errorf("quota %s of tier %s has a negative limit", quota.Name, tier)
+			}
+
+			switch quota.Window {
+			case QUOTA_WINDOW_DAILY, QUOTA_WINDOW_HOURLY:
+			case QUOTA_WINDOW_ROLLING:
+				if quota.Period <= 0 {
+					return fmt.Errorf("rolling quota %s of tier %s needs a period", quota.Name, tier)
+				}
+			default:
+				return fmt.Errorf("quota %s of tier %s has unknown window %q", quota.Name, tier, quota.Window)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReactionQuotas lists the quotas of the tier a reaction type is charged to
+func (policy Policy) ReactionQuotas(tier string, reactionType ReactionType) []QuotaPolicy {
+	quotas := []QuotaPolicy{}
+	for _, quota := range policy.Tiers[tier].Quotas {
+		if reactionType.ChargedTo(quota.Name) {
+			quotas = append(quotas, quota)
+		}
+	}
+	return quotas
+}
+
+// Quota finds the quota of the tier by name, a tier without it is unlimited
+func (policy Policy) Quota(tier, name string) (QuotaPolicy, bool) {
+	for _, quota := range policy.Tiers[tier].Quotas {
+		if quota.Name == name {
+			return quota, true
+		}
+	}
+	return QuotaPolicy{}, false
+}
+
+// PassCooldown returns how long the tier waits before passed profiles resurface, zero when they never do
+func (policy Policy) PassCooldown(tier string) time.Duration {
+	return time.Duration(policy.Tiers[tier].PassCooldown)
+}
+
+func (policy Policy) Entitled(tier, entitlement string) bool {
+	return slices.Contains(policy.Tiers[tier].Entitlements, entitlement)
+}
+
+// ExceededMessage is returned to the user once the quota is used up
+func (quota QuotaPolicy) ExceededMessage() string {
+	if quota.Message != "" {
+		return quota.Message
+	}
+	return fmt.Sprintf("Limit of %s exceeded, try again later", quota.Name)
+}
@@ -0,0 +1,204 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/users/entity"
+)
+
+func TestPolicy_NewPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedResult entity.Policy
+		expectedErr    string
+	}{
+		{
+			name: "normal case",
+			body: `{
+			  "tiers": {
+			    "free": {
+			      "quotas": [
+			        {"name": "reaction", "limit": 20, "window": "hourly"},
+			        {"name": "super_like", "limit": 1, "window": "rolling", "period": "12h"}
+			      ]
+			    },
+			    "premium": {"entitlements": ["see_received_likes"], "pass_cooldown": "168h"}
+			  }
+			}`,
+			expectedResult: entity.Policy{
+				Tiers: map[string]entity.TierPolicy{
+					entity.TIER_FREE: {
+						Quotas: []entity.QuotaPolicy{
+							{Name: "reaction", Limit: 20, Window: entity.QUOTA_WINDOW_HOURLY},
+							{Name: "super_like", Limit: 1, Window: entity.QUOTA_WINDOW_ROLLING, Period: entity.Duration(12 * time.Hour)},
+						},
+					},
+					entity.TIER_PREMIUM: {
+						Entitlements: []string{entity.ENTITLEMENT_SEE_RECEIVED_LIKES},
+						PassCooldown: entity.Duration(7 * 24 * time.Hour),
+					},
+				},
+			},
+		},
+		{
+			name:        "error case with invalid body",
+			body:        `{"tiers": `,
+			expectedErr: "unexpected EOF",
+		},
+		{
+			name:        "error case with invalid period",
+			body:        `{"tiers": {"free": {"quotas": [{"name": "reaction", "window": "rolling", "period": "soon"}]}}}`,
+			expectedErr: `time: invalid duration "soon"`,
+		},
+		{
+			name:        "error case with missing tier",
+			body:        `{"tiers": {"free": {}}}`,
+			expectedErr: "policy is missing the premium tier",
+		},
+		{
+			name:        "error case with quota without name",
+			body:        `{"tiers": {"free": {"quotas": [{"limit": 1, "window": "daily"}]}, "premium": {}}}`,
+			expectedErr: "tier free has a quota without name",
+		},
+		{
+			name:        "error case with duplicated quota",
+			body:        `{"tiers": {"free": {}, "premium": {"quotas": [{"name": "rewind", "window": "daily"}, {"name": "rewind", "window": "hourly"}]}}}`,
+			expectedErr: "tier premium has duplicated quota rewind",
+		},
+		{
+			name:        "error case with negative pass cooldown",
+			body:        `{"tiers": {"free": {"pass_cooldown": "-1h"}, "premium": {}}}`,
+			expectedErr: "tier free has a negative pass cooldown",
+		},
+		{
+			name:        "error case with negative limit",
+			body:        `{"tiers": {"free": {"quotas": [{"name": "reaction", "limit": -1, "window": "daily"}]}, "premium": {}}}`,
+			expectedErr: "quota reaction of tier free has a negative limit",
+		},
+		{
+			name:        "error case with unknown window",
+			body:        `{"tiers": {"free": {"quotas": [{"name": "reaction", "window": "weekly"}]}, "premium": {}}}`,
+			expectedErr: `quota reaction of tier free has unknown window "weekly"`,
+		},
+		{
+			name:        "error case with rolling window without period",
+			body:        `{"tiers": {"free": {"quotas": [{"name": "reaction", "window": "rolling"}]}, "premium": {}}}`,
+			expectedErr: "rolling quota reaction of tier free needs a period",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewPolicy(strings.NewReader(tc.body))
+			if tc.expectedErr != "" {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr, err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestPolicy_DefaultPolicy(t *testing.T) {
+	assert.Nil(t, entity.DefaultPolicy().Validate())
+}
+
+func TestPolicy_ReactionQuotas(t *testing.T) {
+	reaction := entity.QuotaPolicy{Name: "reaction", Limit: 10, Window: entity.QUOTA_WINDOW_DAILY}
+	superLike := entity.QuotaPolicy{Name: "super_like", Limit: 3, Window: entity.QUOTA_WINDOW_DAILY}
+	rewind := entity.QuotaPolicy{Name: "rewind", Limit: 1, Window: entity.QUOTA_WINDOW_DAILY}
+	policy := entity.Policy{
+		Tiers: map[string]entity.TierPolicy{
+			entity.TIER_FREE:    {Quotas: []entity.QuotaPolicy{reaction, superLike, rewind}},
+			entity.TIER_PREMIUM: {Quotas: []entity.QuotaPolicy{superLike}},
+		},
+	}
+	tests := []struct {
+		name           string
+		tier           string
+		reactionType   entity.ReactionType
+		expectedResult []entity.QuotaPolicy
+	}{
+		{
+			name:           "free like",
+			tier:           entity.TIER_FREE,
+			reactionType:   entity.REACTION_TYPE_LIKE,
+			expectedResult: []entity.QuotaPolicy{reaction},
+		},
+		{
+			name:           "free super like",
+			tier:           entity.TIER_FREE,
+			reactionType:   entity.REACTION_TYPE_SUPER_LIKE,
+			expectedResult: []entity.QuotaPolicy{reaction, superLike},
+		},
+		{
+			name:           "free undecided is not charged",
+			tier:           entity.TIER_FREE,
+			reactionType:   entity.REACTION_TYPE_UNDECIDED,
+			expectedResult: []entity.QuotaPolicy{},
+		},
+		{
+			name:           "premium like is unlimited",
+			tier:           entity.TIER_PREMIUM,
+			reactionType:   entity.REACTION_TYPE_LIKE,
+			expectedResult: []entity.QuotaPolicy{},
+		},
+		{
+			name:           "unknown tier is unlimited",
+			tier:           "gold",
+			reactionType:   entity.REACTION_TYPE_SUPER_LIKE,
+			expectedResult: []entity.QuotaPolicy{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, policy.ReactionQuotas(tc.tier, tc.reactionType))
+		})
+	}
+}
+
+func TestPolicy_Quota(t *testing.T) {
+	policy := entity.DefaultPolicy()
+	tests := []struct {
+		name          string
+		tier          string
+		quota         string
+		expectedLimit int
+		expectedFound bool
+	}{
+		{name: "free rewind", tier: entity.TIER_FREE, quota: entity.QUOTA_REWIND, expectedLimit: 0, expectedFound: true},
+		{name: "premium rewind", tier: entity.TIER_PREMIUM, quota: entity.QUOTA_REWIND, expectedLimit: 10, expectedFound: true},
+		{name: "premium reaction is unlimited", tier: entity.TIER_PREMIUM, quota: "reaction"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quota, found := policy.Quota(tc.tier, tc.quota)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedLimit, quota.Limit)
+		})
+	}
+}
+
+func TestPolicy_Entitled(t *testing.T) {
+	policy := entity.DefaultPolicy()
+	assert.True(t, policy.Entitled(entity.UserTier(true), entity.ENTITLEMENT_SEE_RECEIVED_LIKES))
+	assert.False(t, policy.Entitled(entity.UserTier(false), entity.ENTITLEMENT_SEE_RECEIVED_LIKES))
+}
+
+func TestPolicy_PassCooldown(t *testing.T) {
+	policy := entity.DefaultPolicy()
+	assert.Equal(t, 7*24*time.Hour, policy.PassCooldown(entity.UserTier(true)))
+	assert.Equal(t, 30*24*time.Hour, policy.PassCooldown(entity.UserTier(false)))
+	assert.Equal(t, time.Duration(0), entity.Policy{}.PassCooldown(entity.TIER_FREE))
+}
+
+func TestPolicy_ExceededMessage(t *testing.T) {
+	assert.Equal(t, "No more rewinds", entity.QuotaPolicy{Name: "rewind", Message: "No more rewinds"}.ExceededMessage())
+	assert.Equal(t, "Limit of rewind exceeded, try again later", entity.QuotaPolicy{Name: "rewind"}.ExceededMessage())
+}
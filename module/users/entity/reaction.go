@@ -2,31 +2,104 @@ package entity
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"timble/internal/utils"
 	"time"
 )
 
 type UserReaction struct {
-	UserID    uint      `json:"user_id"`
-	TargetID  uint      `json:"target_id"`
-	Type      int       `json:"type"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	UserID       uint          `json:"user_id"`
+	TargetID     uint          `json:"target_id"`
+	Type         ReactionType  `json:"type"`
+	PreviousType *ReactionType `json:"-"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
-type ReactionParams struct {
-	UserID   uint `json:"user_id"`
-	TargetID uint `json:"target_id"`
-	Type     int  `json:"type"`
+// Undoable tells whether undoing the reaction changes anything, a new reaction is deleted and a replacing one
+// gets its previous type back. A restored reaction and one repeating the type it replaced are left as they are
+func (reaction UserReaction) Undoable() bool {
+	if reaction.PreviousType == nil {
+		return reaction.CreatedAt.Equal(reaction.UpdatedAt)
+	}
+
+	return *reaction.PreviousType != reaction.Type
 }
 
-var (
-	ReactionTypes = map[int]bool{
-		0: true, // undecided
-		1: true, // not interested (pass)
-		2: true, // like
+// UndoEndsMatch tells whether undoing the reaction takes away the like a match of the pair stands on,
+// which is when the reaction counts toward a match and the type it goes back to does not
+func (reaction UserReaction) UndoEndsMatch() bool {
+	if !reaction.Type.CountsTowardMatch() {
+		return false
 	}
+
+	return reaction.PreviousType == nil || !reaction.PreviousType.CountsTowardMatch()
+}
+
+type ReactionParams struct {
+	UserID   uint         `json:"user_id"`
+	TargetID uint         `json:"target_id"`
+	Type     ReactionType `json:"type"`
+}
+
+// BatchReactionItem is a reaction the client queued while offline, ReactedAt is the client time of the swipe
+type BatchReactionItem struct {
+	TargetID  uint         `json:"target_id"`
+	Type      ReactionType `json:"type"`
+	ReactedAt time.Time    `json:"reacted_at"`
+}
+
+type BatchReactionParams struct {
+	UserID    uint                `json:"-"`
+	Reactions []BatchReactionItem `json:"reactions"`
+}
+
+// BatchReactionResult is the outcome of a batch item, Error tells why a rejected item was not applied
+type BatchReactionResult struct {
+	TargetID  uint                 `json:"target_id"`
+	Type      ReactionType         `json:"type"`
+	ReactedAt time.Time            `json:"reacted_at"`
+	Status    string               `json:"status"`
+	Matched   bool                 `json:"matched"`
+	MatchID   uint                 `json:"match_id,omitempty"`
+	Error     *utils.StandardError `json:"error,omitempty"`
+}
+
+type ReactionHistoryItem struct {
+	Type      ReactionType `json:"type"`
+	Target    UserSummary  `json:"target" gorm:"embedded;embeddedPrefix:target_"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type ReactionHistoryParams struct {
+	UserID uint
+	Type   *ReactionType
+	Page   utils.PageParams
+}
+
+type ReceivedLike struct {
+	User      *UserSummary `json:"user,omitempty" gorm:"embedded;embeddedPrefix:user_"`
+	Blurred   bool         `json:"blurred" gorm:"-"`
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+}
+
+type ReceivedLikes struct {
+	Count int64          `json:"count"`
+	Likes []ReceivedLike `json:"likes"`
+}
+
+type ReceivedLikesParams struct {
+	UserID uint
+	Page   utils.PageParams
+}
+
+const (
+	MAX_BATCH_REACTIONS = 100
+
+	BATCH_REACTION_STATUS_APPLIED  = "applied"
+	BATCH_REACTION_STATUS_REJECTED = "rejected"
 )
 
 func NewReactionPayload(body io.Reader, userID uint) (ReactionParams, error) {
@@ -38,12 +111,71 @@ func NewReactionPayload(body io.Reader, userID uint) (ReactionParams, error) {
 		return params, utils.BadRequestParamError(err.Error(), "payload")
 	}
 
-	if params.TargetID <= 0 || params.TargetID == userID {
-		return params, utils.BadRequestParamError("Invalid target user", "target_id")
+	return params, params.Validate()
+}
+
+// NewBatchReactionPayload only checks the batch itself, its items are validated one by one when applied
+func NewBatchReactionPayload(body io.Reader, userID uint) (BatchReactionParams, error) {
+	params := BatchReactionParams{
+		UserID: userID,
+	}
+	err := json.NewDecoder(body).Decode(&params)
+	if err != nil {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	if len(params.Reactions) == 0 {
+		return params, utils.BadRequestParamError("Empty reaction batch", "reactions")
+	}
+
+	if len(params.Reactions) > MAX_BATCH_REACTIONS {
+		return params, utils.BadRequestParamError(fmt.Sprintf("At most %d reactions per batch", MAX_BATCH_REACTIONS), "reactions")
+	}
+	return params, nil
+}
+
+func (params ReactionParams) Validate() error {
+	if params.TargetID <= 0 || params.TargetID == params.UserID {
+		return utils.BadRequestParamError("Invalid target user", "target_id")
+	}
+
+	if !params.Type.IsValid() {
+		return utils.BadRequestParamError("Invalid reaction type", "type")
+	}
+	return nil
+}
+
+func (item BatchReactionItem) Validate(userID uint) error {
+	if item.ReactedAt.IsZero() {
+		return utils.BadRequestParamError("Missing reaction time", "reacted_at")
+	}
+
+	return item.Reaction(userID).Validate()
+}
+
+func (item BatchReactionItem) Reaction(userID uint) ReactionParams {
+	return ReactionParams{
+		UserID:   userID,
+		TargetID: item.TargetID,
+		Type:     item.Type,
+	}
+}
+
+func NewReactionHistoryParams(typeStr string, userID uint, page utils.PageParams) (ReactionHistoryParams, error) {
+	params := ReactionHistoryParams{
+		UserID: userID,
+		Page:   page,
 	}
 
-	if !ReactionTypes[params.Type] {
+	if typeStr == "" {
+		return params, nil
+	}
+
+	reactionType := ParseReactionType(typeStr)
+	if !reactionType.IsValid() {
 		return params, utils.BadRequestParamError("Invalid reaction type", "type")
 	}
+	params.Type = &reactionType
+
 	return params, nil
 }
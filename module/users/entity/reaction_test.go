@@ -4,9 +4,11 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"timble/internal/utils"
 	"timble/module/users/entity"
 )
 
@@ -29,6 +31,30 @@ func TestReaction_NewReactionPayload(t *testing.T) {
 				Type:     1,
 			},
 		},
+		{
+			name: "normal case with super like",
+			body: `{
+		      "target_id":  2,
+		      "type": 3
+		    }`,
+			expectedResult: entity.ReactionParams{
+				UserID:   1,
+				TargetID: 2,
+				Type:     entity.REACTION_TYPE_SUPER_LIKE,
+			},
+		},
+		{
+			name: "normal case with type name",
+			body: `{
+		      "target_id":  2,
+		      "type": "like"
+		    }`,
+			expectedResult: entity.ReactionParams{
+				UserID:   1,
+				TargetID: 2,
+				Type:     entity.REACTION_TYPE_LIKE,
+			},
+		},
 		{
 			name: "error case with invalid body",
 			body: `{
@@ -57,11 +83,31 @@ func TestReaction_NewReactionPayload(t *testing.T) {
 			body: `
 		    {
 		      "target_id":  2,
-		      "type": 3
+		      "type": 4
 		    }
 		  `,
 			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid reaction type; field: type"),
 		},
+		{
+			name: "error case with unknown type name",
+			body: `
+		    {
+		      "target_id":  2,
+		      "type": "wink"
+		    }
+		  `,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid reaction type; field: type"),
+		},
+		{
+			name: "error case with type of wrong kind",
+			body: `
+		    {
+		      "target_id":  2,
+		      "type": true
+		    }
+		  `,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: reaction type must be a name or a code, got true; field: payload"),
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -75,3 +121,217 @@ func TestReaction_NewReactionPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestReaction_NewBatchReactionPayload(t *testing.T) {
+	reactedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		body           string
+		expectedResult entity.BatchReactionParams
+		expectedErr    error
+	}{
+		{
+			name: "normal case",
+			body: `{
+		      "reactions": [
+		        {"target_id": 2, "type": "like", "reacted_at": "2026-10-18T09:00:00Z"},
+		        {"target_id": 2, "type": "wink", "reacted_at": "2026-10-18T09:00:00Z"}
+		      ]
+		    }`,
+			expectedResult: entity.BatchReactionParams{
+				UserID: 1,
+				Reactions: []entity.BatchReactionItem{
+					{TargetID: 2, Type: entity.REACTION_TYPE_LIKE, ReactedAt: reactedAt},
+					{TargetID: 2, Type: entity.REACTION_TYPE_UNKNOWN, ReactedAt: reactedAt},
+				},
+			},
+		},
+		{
+			name:        "error case with invalid body",
+			body:        `{"reactions": [`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: unexpected EOF; field: payload"),
+		},
+		{
+			name:        "error case with empty batch",
+			body:        `{"reactions": []}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Empty reaction batch; field: reactions"),
+		},
+		{
+			name:        "error case with too many reactions",
+			body:        `{"reactions": [` + strings.Repeat(`{"target_id": 2, "type": "pass"},`, entity.MAX_BATCH_REACTIONS) + `{"target_id": 2, "type": "pass"}]}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: At most 100 reactions per batch; field: reactions"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewBatchReactionPayload(strings.NewReader(tc.body), 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestReaction_BatchReactionItem_Validate(t *testing.T) {
+	reactedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		item        entity.BatchReactionItem
+		expectedErr error
+	}{
+		{
+			name: "valid item",
+			item: entity.BatchReactionItem{TargetID: 2, Type: entity.REACTION_TYPE_LIKE, ReactedAt: reactedAt},
+		},
+		{
+			name:        "missing reaction time",
+			item:        entity.BatchReactionItem{TargetID: 2, Type: entity.REACTION_TYPE_LIKE},
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Missing reaction time; field: reacted_at"),
+		},
+		{
+			name:        "reaction to self",
+			item:        entity.BatchReactionItem{TargetID: 1, Type: entity.REACTION_TYPE_LIKE, ReactedAt: reactedAt},
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid target user; field: target_id"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.item.Validate(1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestReaction_NewReactionHistoryParams(t *testing.T) {
+	likeType := entity.REACTION_TYPE_LIKE
+	page := utils.PageParams{Limit: 10}
+	tests := []struct {
+		name           string
+		typeStr        string
+		expectedResult entity.ReactionHistoryParams
+		expectedErr    error
+	}{
+		{
+			name:    "normal case with type",
+			typeStr: "2",
+			expectedResult: entity.ReactionHistoryParams{
+				UserID: 1,
+				Type:   &likeType,
+				Page:   page,
+			},
+		},
+		{
+			name:    "normal case with type name",
+			typeStr: "like",
+			expectedResult: entity.ReactionHistoryParams{
+				UserID: 1,
+				Type:   &likeType,
+				Page:   page,
+			},
+		},
+		{
+			name:    "normal case without type",
+			typeStr: "",
+			expectedResult: entity.ReactionHistoryParams{
+				UserID: 1,
+				Page:   page,
+			},
+		},
+		{
+			name:        "error case with unknown type",
+			typeStr:     "4",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid reaction type; field: type"),
+		},
+		{
+			name:        "error case with unknown type name",
+			typeStr:     "wink",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid reaction type; field: type"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewReactionHistoryParams(tc.typeStr, 1, page)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestReaction_Undoable(t *testing.T) {
+	now := time.Now()
+	likeType := entity.REACTION_TYPE_LIKE
+	tests := []struct {
+		name           string
+		reaction       entity.UserReaction
+		expectedResult bool
+	}{
+		{
+			name:           "new reaction",
+			reaction:       entity.UserReaction{Type: entity.REACTION_TYPE_PASS, CreatedAt: now, UpdatedAt: now},
+			expectedResult: true,
+		},
+		{
+			name:           "reaction replacing another type",
+			reaction:       entity.UserReaction{Type: entity.REACTION_TYPE_PASS, PreviousType: &likeType, CreatedAt: now, UpdatedAt: now.Add(time.Minute)},
+			expectedResult: true,
+		},
+		{
+			name:     "reaction repeating the type it replaced",
+			reaction: entity.UserReaction{Type: entity.REACTION_TYPE_LIKE, PreviousType: &likeType, CreatedAt: now, UpdatedAt: now.Add(time.Minute)},
+		},
+		{
+			name:     "restored reaction",
+			reaction: entity.UserReaction{Type: entity.REACTION_TYPE_LIKE, CreatedAt: now, UpdatedAt: now.Add(time.Minute)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, tc.reaction.Undoable())
+		})
+	}
+}
+
+func TestReaction_UndoEndsMatch(t *testing.T) {
+	likeType := entity.REACTION_TYPE_LIKE
+	passType := entity.REACTION_TYPE_PASS
+	tests := []struct {
+		name           string
+		reaction       entity.UserReaction
+		expectedResult bool
+	}{
+		{
+			name:           "new like",
+			reaction:       entity.UserReaction{Type: entity.REACTION_TYPE_LIKE},
+			expectedResult: true,
+		},
+		{
+			name:           "super like replacing a pass",
+			reaction:       entity.UserReaction{Type: entity.REACTION_TYPE_SUPER_LIKE, PreviousType: &passType},
+			expectedResult: true,
+		},
+		{
+			name:     "super like replacing a like",
+			reaction: entity.UserReaction{Type: entity.REACTION_TYPE_SUPER_LIKE, PreviousType: &likeType},
+		},
+		{
+			name:     "pass replacing a like",
+			reaction: entity.UserReaction{Type: entity.REACTION_TYPE_PASS, PreviousType: &likeType},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, tc.reaction.UndoEndsMatch())
+		})
+	}
+}
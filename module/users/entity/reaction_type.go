@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// ReactionType is stored by its code and written by its name in the API
+type ReactionType int
+
+const (
+	REACTION_TYPE_UNKNOWN    ReactionType = -1
+	REACTION_TYPE_UNDECIDED  ReactionType = 0
+	REACTION_TYPE_PASS       ReactionType = 1 // not interested
+	REACTION_TYPE_LIKE       ReactionType = 2
+	REACTION_TYPE_SUPER_LIKE ReactionType = 3
+)
+
+// quota buckets reactions are charged to, the policy sets their limits per tier
+const (
+	QUOTA_BUCKET_REACTION   = "reaction"
+	QUOTA_BUCKET_SUPER_LIKE = "super_like"
+)
+
+// ReactionTypeInfo describes a reaction type
+type ReactionTypeInfo struct {
+	Type              ReactionType
+	Name              string
+	QuotaBuckets      []string
+	CountsTowardMatch bool
+}
+
+// ReactionTypeRegistry lists every reaction type, a new type only needs to be added here
+var ReactionTypeRegistry = []ReactionTypeInfo{
+	{
+		Type: REACTION_TYPE_UNDECIDED,
+		Name: "undecided",
+	},
+	{
+		Type:         REACTION_TYPE_PASS,
+		Name:         "pass",
+		QuotaBuckets: []string{QUOTA_BUCKET_REACTION},
+	},
+	{
+		Type:              REACTION_TYPE_LIKE,
+		Name:              "like",
+		QuotaBuckets:      []string{QUOTA_BUCKET_REACTION},
+		CountsTowardMatch: true,
+	},
+	{
+		Type:              REACTION_TYPE_SUPER_LIKE,
+		Name:              "super_like",
+		QuotaBuckets:      []string{QUOTA_BUCKET_REACTION, QUOTA_BUCKET_SUPER_LIKE},
+		CountsTowardMatch: true,
+	},
+}
+
+// ParseReactionType accepts the name of the type, or its code while integers are deprecated
+func ParseReactionType(s string) ReactionType {
+	for _, info := range ReactionTypeRegistry {
+		if info.Name == s {
+			return info.Type
+		}
+	}
+
+	code, err := strconv.Atoi(s)
+	if err != nil {
+		return REACTION_TYPE_UNKNOWN
+	}
+
+	reactionType := ReactionType(code)
+	if !reactionType.IsValid() {
+		return REACTION_TYPE_UNKNOWN
+	}
+	return reactionType
+}
+
+// MatchReactionTypes lists the types that count toward a match
+func MatchReactionTypes() []ReactionType {
+	result := []ReactionType{}
+	for _, info := range ReactionTypeRegistry {
+		if info.CountsTowardMatch {
+			result = append(result, info.Type)
+		}
+	}
+	return result
+}
+
+func (t ReactionType) Info() (ReactionTypeInfo, bool) {
+	for _, info := range ReactionTypeRegistry {
+		if info.Type == t {
+			return info, true
+		}
+	}
+	return ReactionTypeInfo{}, false
+}
+
+func (t ReactionType) IsValid() bool {
+	_, ok := t.Info()
+	return ok
+}
+
+func (t ReactionType) CountsTowardMatch() bool {
+	info, _ := t.Info()
+	return info.CountsTowardMatch
+}
+
+func (t ReactionType) ChargedTo(bucket string) bool {
+	info, _ := t.Info()
+	return slices.Contains(info.QuotaBuckets, bucket)
+}
+
+func (t ReactionType) String() string {
+	info, ok := t.Info()
+	if !ok {
+		return strconv.Itoa(int(t))
+	}
+	return info.Name
+}
+
+func (t ReactionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON reads the name of the type, integers are still read as the type code during the deprecation period.
+// Unknown names are kept as REACTION_TYPE_UNKNOWN for the validation to reject
+func (t *ReactionType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*t = ParseReactionType(name)
+		return nil
+	}
+
+	var code int
+	if err := json.Unmarshal(b, &code); err != nil {
+		return fmt.Errorf("reaction type must be a name or a code, got %s", b)
+	}
+	*t = ReactionType(code)
+	return nil
+}
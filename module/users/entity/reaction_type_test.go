@@ -0,0 +1,82 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/users/entity"
+)
+
+func TestReactionType_ParseReactionType(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected entity.ReactionType
+	}{
+		{name: "name", s: "super_like", expected: entity.REACTION_TYPE_SUPER_LIKE},
+		{name: "deprecated code", s: "1", expected: entity.REACTION_TYPE_PASS},
+		{name: "unknown name", s: "wink", expected: entity.REACTION_TYPE_UNKNOWN},
+		{name: "unknown code", s: "9", expected: entity.REACTION_TYPE_UNKNOWN},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, entity.ParseReactionType(tc.s))
+		})
+	}
+}
+
+func TestReactionType_JSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expected    entity.ReactionType
+		expectedErr string
+	}{
+		{name: "name", body: `"like"`, expected: entity.REACTION_TYPE_LIKE},
+		{name: "deprecated code", body: `3`, expected: entity.REACTION_TYPE_SUPER_LIKE},
+		{name: "unknown name", body: `"wink"`, expected: entity.REACTION_TYPE_UNKNOWN},
+		{name: "wrong kind", body: `[1]`, expectedErr: "reaction type must be a name or a code, got [1]"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual entity.ReactionType
+			err := json.Unmarshal([]byte(tc.body), &actual)
+			if tc.expectedErr != "" {
+				assert.Equal(t, tc.expectedErr, err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	body, err := json.Marshal(entity.UserReaction{Type: entity.REACTION_TYPE_PASS})
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), `"type":"pass"`)
+}
+
+func TestReactionType_Registry(t *testing.T) {
+	tests := []struct {
+		reactionType      entity.ReactionType
+		countsTowardMatch bool
+		chargedToReaction bool
+		chargedToSuper    bool
+	}{
+		{reactionType: entity.REACTION_TYPE_UNDECIDED},
+		{reactionType: entity.REACTION_TYPE_PASS, chargedToReaction: true},
+		{reactionType: entity.REACTION_TYPE_LIKE, countsTowardMatch: true, chargedToReaction: true},
+		{reactionType: entity.REACTION_TYPE_SUPER_LIKE, countsTowardMatch: true, chargedToReaction: true, chargedToSuper: true},
+		{reactionType: entity.REACTION_TYPE_UNKNOWN},
+	}
+	for _, tc := range tests {
+		t.Run(tc.reactionType.String(), func(t *testing.T) {
+			assert.Equal(t, tc.countsTowardMatch, tc.reactionType.CountsTowardMatch())
+			assert.Equal(t, tc.chargedToReaction, tc.reactionType.ChargedTo(entity.QUOTA_BUCKET_REACTION))
+			assert.Equal(t, tc.chargedToSuper, tc.reactionType.ChargedTo(entity.QUOTA_BUCKET_SUPER_LIKE))
+		})
+	}
+
+	assert.Equal(t, []entity.ReactionType{entity.REACTION_TYPE_LIKE, entity.REACTION_TYPE_SUPER_LIKE}, entity.MatchReactionTypes())
+}
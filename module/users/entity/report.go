@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"timble/internal/utils"
+)
+
+const (
+	REPORT_REASON_SPAM          = "spam"
+	REPORT_REASON_HARASSMENT    = "harassment"
+	REPORT_REASON_INAPPROPRIATE = "inappropriate"
+	REPORT_REASON_FAKE_PROFILE  = "fake_profile"
+	REPORT_REASON_UNDERAGE      = "underage"
+	REPORT_REASON_OTHER         = "other"
+
+	REPORT_STATUS_PENDING   = "pending"
+	REPORT_STATUS_CLAIMED   = "claimed"
+	REPORT_STATUS_RESOLVED  = "resolved"
+	REPORT_STATUS_DISMISSED = "dismissed"
+
+	REPORT_DETAILS_MAX_LENGTH = 1000
+)
+
+var ReportReasons = map[string]bool{
+	REPORT_REASON_SPAM:          true,
+	REPORT_REASON_HARASSMENT:    true,
+	REPORT_REASON_INAPPROPRIATE: true,
+	REPORT_REASON_FAKE_PROFILE:  true,
+	REPORT_REASON_UNDERAGE:      true,
+	REPORT_REASON_OTHER:         true,
+}
+
+var ReportStatuses = map[string]bool{
+	REPORT_STATUS_PENDING:   true,
+	REPORT_STATUS_CLAIMED:   true,
+	REPORT_STATUS_RESOLVED:  true,
+	REPORT_STATUS_DISMISSED: true,
+}
+
+// Report is a user reported to trust and safety, it is pending until a moderator claims it and then resolves or dismisses it
+type Report struct {
+	ID         uint       `json:"id"`
+	ReporterID uint       `json:"reporter_id"`
+	ReportedID uint       `json:"reported_id"`
+	Reason     string     `json:"reason"`
+	Details    string     `json:"details,omitempty"`
+	Status     string     `json:"status"`
+	ClaimedBy  *uint      `json:"claimed_by,omitempty"`
+	ClaimedAt  *time.Time `json:"claimed_at,omitempty"`
+	ResolvedBy *uint      `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	Note       string     `json:"note,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	// Created tells apart a new report from one the reporter already has open
+	Created bool `json:"-"`
+}
+
+// ReportQueueItem is a report as listed to moderators, with how many distinct users reported the same user
+type ReportQueueItem struct {
+	Report         `gorm:"embedded"`
+	Reporters      int  `json:"reporters"`
+	ReportedHidden bool `json:"reported_hidden"`
+}
+
+type ReportParams struct {
+	ReporterID uint   `json:"-"`
+	ReportedID uint   `json:"-"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details"`
+}
+
+type ReportQueueParams struct {
+	ModeratorID uint
+	Status      string
+	Page        utils.PageParams
+}
+
+// ReportActionParams is a moderator acting on a report, the note is kept with a resolved or dismissed one
+type ReportActionParams struct {
+	ReportID    uint   `json:"-"`
+	ModeratorID uint   `json:"-"`
+	Note        string `json:"note"`
+}
+
+func NewReportPayload(body io.Reader, targetID string, userID uint) (ReportParams, error) {
+	params := ReportParams{
+		ReporterID: userID,
+	}
+
+	id, err := ParseTargetID(targetID, userID)
+	if err != nil {
+		return params, err
+	}
+	params.ReportedID = id
+
+	err = json.NewDecoder(body).Decode(&params)
+	if err != nil {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	if !ReportReasons[params.Reason] {
+		return params, utils.BadRequestParamError("Invalid report reason", "reason")
+	}
+
+	params.Details = strings.TrimSpace(params.Details)
+	if params.Reason == REPORT_REASON_OTHER && params.Details == "" {
+		return params, utils.BadRequestParamError("Details can not be blank when the reason is other", "details")
+	}
+
+	if len([]rune(params.Details)) > REPORT_DETAILS_MAX_LENGTH {
+		return params, utils.BadRequestParamError("Details must be at most 1000 characters", "details")
+	}
+
+	return params, nil
+}
+
+// NewReportQueueParams lists the pending reports unless another status is asked for
+func NewReportQueueParams(status string, page utils.PageParams, moderatorID uint) (ReportQueueParams, error) {
+	params := ReportQueueParams{
+		ModeratorID: moderatorID,
+		Status:      status,
+		Page:        page,
+	}
+	if params.Status == "" {
+		params.Status = REPORT_STATUS_PENDING
+	}
+
+	if !ReportStatuses[params.Status] {
+		return params, utils.BadRequestParamError("Invalid report status", "status")
+	}
+
+	return params, nil
+}
+
+// NewReportActionPayload reads the action of a moderator, the body is optional
+func NewReportActionPayload(body io.Reader, reportID string, moderatorID uint) (ReportActionParams, error) {
+	params := ReportActionParams{
+		ModeratorID: moderatorID,
+	}
+
+	id, err := strconv.ParseUint(reportID, 10, 0)
+	if err != nil || id == 0 {
+		return params, utils.BadRequestParamError("Invalid report", "id")
+	}
+	params.ReportID = uint(id)
+
+	err = json.NewDecoder(body).Decode(&params)
+	if err != nil && err != io.EOF {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	params.Note = strings.TrimSpace(params.Note)
+	if len([]rune(params.Note)) > REPORT_DETAILS_MAX_LENGTH {
+		return params, utils.BadRequestParamError("Note must be at most 1000 characters", "note")
+	}
+
+	return params, nil
+}
@@ -0,0 +1,162 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/internal/utils"
+	"timble/module/users/entity"
+)
+
+func TestReport_NewReportPayload(t *testing.T) {
+	tests := []struct {
+		name           string
+		targetID       string
+		body           string
+		expectedResult entity.ReportParams
+		expectedErr    error
+	}{
+		{
+			name:           "normal case",
+			targetID:       "2",
+			body:           `{"reason": "spam", "details": "  sends the same link to everyone  "}`,
+			expectedResult: entity.ReportParams{ReporterID: 1, ReportedID: 2, Reason: "spam", Details: "sends the same link to everyone"},
+		},
+		{
+			name:           "normal case without details",
+			targetID:       "2",
+			body:           `{"reason": "fake_profile"}`,
+			expectedResult: entity.ReportParams{ReporterID: 1, ReportedID: 2, Reason: "fake_profile"},
+		},
+		{
+			name:        "error case with same target ID as user ID",
+			targetID:    "1",
+			body:        `{"reason": "spam"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid target user; field: id"),
+		},
+		{
+			name:        "error case with invalid payload",
+			targetID:    "2",
+			body:        `{`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: unexpected EOF; field: payload"),
+		},
+		{
+			name:        "error case with unknown reason",
+			targetID:    "2",
+			body:        `{"reason": "boring"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid report reason; field: reason"),
+		},
+		{
+			name:        "error case with other reason without details",
+			targetID:    "2",
+			body:        `{"reason": "other", "details": "   "}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Details can not be blank when the reason is other; field: details"),
+		},
+		{
+			name:        "error case with details too long",
+			targetID:    "2",
+			body:        `{"reason": "other", "details": "` + strings.Repeat("a", 1001) + `"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Details must be at most 1000 characters; field: details"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewReportPayload(strings.NewReader(tc.body), tc.targetID, 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestReport_NewReportQueueParams(t *testing.T) {
+	page := utils.PageParams{Limit: 20}
+	tests := []struct {
+		name           string
+		status         string
+		expectedResult entity.ReportQueueParams
+		expectedErr    error
+	}{
+		{
+			name:           "pending by default",
+			expectedResult: entity.ReportQueueParams{ModeratorID: 9, Status: "pending", Page: page},
+		},
+		{
+			name:           "normal case with status",
+			status:         "claimed",
+			expectedResult: entity.ReportQueueParams{ModeratorID: 9, Status: "claimed", Page: page},
+		},
+		{
+			name:        "error case with unknown status",
+			status:      "open",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid report status; field: status"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewReportQueueParams(tc.status, page, 9)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestReport_NewReportActionPayload(t *testing.T) {
+	tests := []struct {
+		name           string
+		reportID       string
+		body           string
+		expectedResult entity.ReportActionParams
+		expectedErr    error
+	}{
+		{
+			name:           "normal case",
+			reportID:       "5",
+			body:           `{"note": " warned the user "}`,
+			expectedResult: entity.ReportActionParams{ReportID: 5, ModeratorID: 9, Note: "warned the user"},
+		},
+		{
+			name:           "normal case without body",
+			reportID:       "5",
+			expectedResult: entity.ReportActionParams{ReportID: 5, ModeratorID: 9},
+		},
+		{
+			name:        "error case with invalid report ID",
+			reportID:    "abc",
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid report; field: id"),
+		},
+		{
+			name:        "error case with invalid payload",
+			reportID:    "5",
+			body:        `{`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: unexpected EOF; field: payload"),
+		},
+		{
+			name:        "error case with note too long",
+			reportID:    "5",
+			body:        `{"note": "` + strings.Repeat("a", 1001) + `"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Note must be at most 1000 characters; field: note"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewReportActionPayload(strings.NewReader(tc.body), tc.reportID, 9)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// Settings contains the tunable values of the users module, loaded from the environment on startup
+type Settings struct {
+	Ranking         RankingWeights
+	Rewind          RewindSettings
+	Policy          Policy
+	PremiumCacheTTL time.Duration
+	Boost           BoostSettings
+	Reports         ReportSettings
+}
+
+// RankingWeights controls how much each signal contributes to a discovery candidate score
+type RankingWeights struct {
+	Elo             float64
+	Recency         float64
+	Completeness    float64
+	Distance        float64
+	RecencyHalfLife time.Duration
+	DistanceScaleKm float64
+	Boost           float64
+}
+
+// RewindSettings controls how long a reaction can be undone, the number of undos is limited by the policy
+type RewindSettings struct {
+	Window time.Duration
+}
+
+// BoostSettings controls how long a boost lasts and how many boosts come with a premium grant
+type BoostSettings struct {
+	Duration     time.Duration
+	PremiumGrant int
+}
+
+// ReportSettings controls when reports hide a user and how long a moderator keeps a report claimed
+type ReportSettings struct {
+	// HideThreshold is how many distinct users have to report a user before they are hidden, zero disables hiding
+	HideThreshold int
+	ClaimTTL      time.Duration
+}
@@ -8,29 +8,61 @@ import (
 	"time"
 )
 
+const DEFAULT_TIMEZONE = "Asia/Jakarta"
+
 type User struct {
 	ID             uint      `json:"id"`
 	Username       string    `json:"username"`
 	Email          string    `json:"email"`
 	Premium        bool      `json:"premium"`
+	Timezone       string    `json:"timezone"`
+	BoostBalance   int       `json:"boost_balance"`
 	HashedPassword string    `json:"hashed_password"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	// HiddenAt is set once enough users reported the user, a hidden user is not shown to anyone else
+	HiddenAt  *time.Time `json:"-"`
+	Moderator bool       `json:"-"`
 }
 
 type UserPublic struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Premium   bool      `json:"premium"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           uint      `json:"id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	Premium      bool      `json:"premium"`
+	Timezone     string    `json:"timezone"`
+	QuotaResetAt time.Time `json:"quota_reset_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type UserSummary struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	Bio      string `json:"bio,omitempty"`
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// UserProfile is another user as seen by the caller
+type UserProfile struct {
+	ID           uint      `json:"id"`
+	Username     string    `json:"username"`
+	Bio          string    `json:"bio,omitempty"`
+	PhotoURL     string    `json:"photo_url,omitempty"`
+	LastActiveAt time.Time `json:"last_active_at"`
 }
 
 type UserRegistrationParams struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Timezone string `json:"timezone"`
+}
+
+// UserTimezoneParams moves the user's daily quota resets to the local midnight of the timezone
+type UserTimezoneParams struct {
+	UserID   uint   `json:"-"`
+	Timezone string `json:"timezone"`
 }
 
 type UserLoginParams struct {
@@ -66,6 +98,15 @@ func NewUserRegistrationPayload(body io.Reader) (UserRegistrationParams, error)
 	if len(params.Password) < 10 {
 		return params, utils.BadRequestParamError("Password must be more than 10 characters", "password")
 	}
+
+	if len(params.Timezone) == 0 {
+		params.Timezone = DEFAULT_TIMEZONE
+	}
+
+	_, err = time.LoadLocation(params.Timezone)
+	if err != nil {
+		return params, utils.BadRequestParamError("Invalid timezone", "timezone")
+	}
 	return params, nil
 }
 
@@ -84,3 +125,37 @@ func NewUserLoginPayload(body io.Reader) (UserLoginParams, error) {
 	}
 	return params, nil
 }
+
+func NewUserTimezonePayload(body io.Reader, userID uint) (UserTimezoneParams, error) {
+	params := UserTimezoneParams{
+		UserID: userID,
+	}
+	err := json.NewDecoder(body).Decode(&params)
+	if err != nil {
+		return params, utils.BadRequestParamError(err.Error(), "payload")
+	}
+
+	if len(params.Timezone) == 0 {
+		return params, utils.BadRequestParamError("Timezone can not be blank", "timezone")
+	}
+
+	_, err = time.LoadLocation(params.Timezone)
+	if err != nil {
+		return params, utils.BadRequestParamError("Invalid timezone", "timezone")
+	}
+	return params, nil
+}
+
+// LoadTimezone returns the location of the timezone, falling back to the default one when it is unknown
+func LoadTimezone(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err == nil && name != "" {
+		return loc
+	}
+
+	loc, err = time.LoadLocation(DEFAULT_TIMEZONE)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
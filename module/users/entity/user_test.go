@@ -30,8 +30,38 @@ func TestUser_NewUserRegistrationPayload(t *testing.T) {
 				Username: "testuser",
 				Email:    "test@email.com",
 				Password: "testpassword",
+				Timezone: entity.DEFAULT_TIMEZONE,
 			},
 		},
+		{
+			name: "normal case with timezone",
+			body: `
+		    {
+		      "username":  "testuser",
+		      "email": "test@email.com",
+		      "password": "testpassword",
+		      "timezone": "Europe/Berlin"
+		    }
+		  `,
+			expectedResult: entity.UserRegistrationParams{
+				Username: "testuser",
+				Email:    "test@email.com",
+				Password: "testpassword",
+				Timezone: "Europe/Berlin",
+			},
+		},
+		{
+			name: "error case with invalid timezone",
+			body: `
+		    {
+		      "username":  "testuser",
+		      "email": "test@email.com",
+		      "password": "testpassword",
+		      "timezone": "Mars/Olympus"
+		    }
+		  `,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid timezone; field: timezone"),
+		},
 		{
 			name: "error case with invalid payload",
 			body: `
@@ -153,3 +183,64 @@ func TestUser_NewUserLoginPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_NewUserTimezonePayload(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedResult entity.UserTimezoneParams
+		expectedErr    error
+	}{
+		{
+			name: "normal case",
+			body: `{"timezone": "Europe/Berlin"}`,
+			expectedResult: entity.UserTimezoneParams{
+				UserID:   1,
+				Timezone: "Europe/Berlin",
+			},
+		},
+		{
+			name:        "error case with missing timezone",
+			body:        `{}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Timezone can not be blank; field: timezone"),
+		},
+		{
+			name:        "error case with invalid timezone",
+			body:        `{"timezone": "Mars/Olympus"}`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: Invalid timezone; field: timezone"),
+		},
+		{
+			name:        "error case with invalid payload",
+			body:        `{"timezone": "Europe/Berlin"`,
+			expectedErr: errors.New("Error on\ncode: PARAMETER_PARSING_FAILS; error: unexpected EOF; field: payload"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := entity.NewUserTimezonePayload(strings.NewReader(tc.body), 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestUser_LoadTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		expected string
+	}{
+		{name: "known timezone", timezone: "Europe/Berlin", expected: "Europe/Berlin"},
+		{name: "blank timezone", timezone: "", expected: entity.DEFAULT_TIMEZONE},
+		{name: "unknown timezone", timezone: "Mars/Olympus", expected: entity.DEFAULT_TIMEZONE},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, entity.LoadTimezone(tc.timezone).String())
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/pkg/errors"
 
 	log "go.uber.org/zap"
@@ -13,18 +16,24 @@ import (
 )
 
 type UsersResource struct {
-	AuthUsecase    usecase.AuthUsecase
-	PremiumUsecase usecase.PremiumUsecase
-	UserUsecase    usecase.UserUsecase
-	logger         *log.Logger
+	AuthUsecase      usecase.AuthUsecase
+	PremiumUsecase   usecase.PremiumUsecase
+	UserUsecase      usecase.UserUsecase
+	DiscoveryUsecase usecase.DiscoveryUsecase
+	ReportUsecase    usecase.ReportUsecase
+	cursorSigner     *utils.CursorSigner
+	logger           *log.Logger
 }
 
-func NewUsersResource(authUsecase usecase.AuthUsecase, premiumUsecase usecase.PremiumUsecase, userUsecase usecase.UserUsecase, logger *log.Logger) *UsersResource {
+func NewUsersResource(authUsecase usecase.AuthUsecase, premiumUsecase usecase.PremiumUsecase, userUsecase usecase.UserUsecase, discoveryUsecase usecase.DiscoveryUsecase, reportUsecase usecase.ReportUsecase, cursorSigner *utils.CursorSigner, logger *log.Logger) *UsersResource {
 	return &UsersResource{
-		AuthUsecase:    authUsecase,
-		PremiumUsecase: premiumUsecase,
-		UserUsecase:    userUsecase,
-		logger:         logger,
+		AuthUsecase:      authUsecase,
+		PremiumUsecase:   premiumUsecase,
+		UserUsecase:      userUsecase,
+		DiscoveryUsecase: discoveryUsecase,
+		ReportUsecase:    reportUsecase,
+		cursorSigner:     cursorSigner,
+		logger:           logger,
 	}
 }
 
@@ -108,6 +117,35 @@ func (resource *UsersResource) Show(w http.ResponseWriter, r *http.Request) {
 	body.WriteAPIResponse(w, r, http.StatusOK)
 }
 
+// UpdateTimezone changes the timezone the user's daily quotas reset in
+func (resource *UsersResource) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewUserTimezonePayload(r.Body, userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	userData, err := resource.UserUsecase.UpdateTimezone(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusOK
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(userData, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
 func (resource *UsersResource) React(w http.ResponseWriter, r *http.Request) {
 	userID := resource.getUserIDFromContext(r)
 	m := utils.NewRestMetric(r)
@@ -122,7 +160,60 @@ func (resource *UsersResource) React(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = resource.UserUsecase.React(r.Context(), params)
+	result, err := resource.UserUsecase.React(r.Context(), params)
+	writeRateLimitHeaders(w, result.RateLimit)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusOK
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(result, meta)
+	body.Message = "Reaction saved"
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+// ReactBatch applies the reactions queued while offline, the response has the outcome of every item
+func (resource *UsersResource) ReactBatch(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewBatchReactionPayload(r.Body, userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	results, err := resource.UserUsecase.ReactBatch(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusOK
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(results, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) UndoReaction(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	reaction, err := resource.UserUsecase.UndoReaction(r.Context(), userID)
 	if err != nil {
 		m = m.SetFail(resource.returnErrorResponse(w, r, err))
 		return
@@ -132,7 +223,382 @@ func (resource *UsersResource) React(w http.ResponseWriter, r *http.Request) {
 	meta := utils.Meta{
 		HTTPStatus: http.StatusOK,
 	}
-	body := utils.NewMessageResponse("Reaction saved", meta)
+	body := utils.NewDataResponse(reaction, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) ReactionHistory(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params, err := entity.NewReactionHistoryParams(r.URL.Query().Get("type"), userID, page)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	reactions, nextPage, err := resource.UserUsecase.ReactionHistory(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(reactions, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) ReceivedLikes(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params := entity.ReceivedLikesParams{
+		UserID: userID,
+		Page:   page,
+	}
+	likes, nextPage, err := resource.UserUsecase.ReceivedLikes(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(likes, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Matches(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params := entity.MatchListParams{
+		UserID: userID,
+		Page:   page,
+	}
+	matches, nextPage, err := resource.UserUsecase.Matches(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(matches, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Unmatch(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewUnmatchPayload(r.Body, chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	err = resource.UserUsecase.Unmatch(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusOK
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewMessageResponse("Match ended", meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Profile(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	targetID, err := entity.ParseTargetID(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	profile, err := resource.UserUsecase.Profile(r.Context(), userID, targetID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(profile, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Block(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewBlockParams(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	err = resource.UserUsecase.Block(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusOK
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewMessageResponse("User blocked", meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Unblock(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewBlockParams(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	err = resource.UserUsecase.Unblock(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	m.HTTPStatus = http.StatusOK
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewMessageResponse("User unblocked", meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Blocks(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params := entity.BlockListParams{
+		UserID: userID,
+		Page:   page,
+	}
+	blocks, nextPage, err := resource.UserUsecase.Blocks(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(blocks, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+// Report answers with 201 for a new report and 200 when the user already has an open report of the same user
+func (resource *UsersResource) Report(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewReportPayload(r.Body, chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	report, err := resource.ReportUsecase.Report(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	httpStatus := http.StatusOK
+	if report != nil && report.Created {
+		httpStatus = http.StatusCreated
+	}
+	m.HTTPStatus = httpStatus
+	meta := utils.Meta{
+		HTTPStatus: httpStatus,
+	}
+	body := utils.NewMessageResponse("Report received", meta)
+	body.WriteAPIResponse(w, r, httpStatus)
+}
+
+func (resource *UsersResource) ReportQueue(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	page, err := resource.cursorSigner.ParsePageParams(r)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	params, err := entity.NewReportQueueParams(r.URL.Query().Get("status"), page, userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	reports, nextPage, err := resource.ReportUsecase.Queue(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := resource.cursorSigner.PaginatedMeta(http.StatusOK, nextPage)
+	body := utils.NewDataResponse(reports, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) ClaimReport(w http.ResponseWriter, r *http.Request) {
+	resource.actOnReport(w, r, resource.ReportUsecase.Claim)
+}
+
+func (resource *UsersResource) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	resource.actOnReport(w, r, resource.ReportUsecase.Resolve)
+}
+
+func (resource *UsersResource) DismissReport(w http.ResponseWriter, r *http.Request) {
+	resource.actOnReport(w, r, resource.ReportUsecase.Dismiss)
+}
+
+// actOnReport runs a moderator action on the report of the path and answers with the updated report
+func (resource *UsersResource) actOnReport(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error)) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	params, err := entity.NewReportActionPayload(r.Body, chi.URLParam(r, "id"), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	report, err := action(r.Context(), params)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(report, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Quota(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	summary, err := resource.UserUsecase.Quota(r.Context(), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(summary, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) Discover(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	candidates, err := resource.DiscoveryUsecase.Discover(r.Context(), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(candidates, meta)
 	body.WriteAPIResponse(w, r, http.StatusOK)
 }
 
@@ -182,6 +648,48 @@ func (resource *UsersResource) getUserIDFromContext(r *http.Request) uint {
 	return uint(r.Context().Value(utils.CtxUserIDKey).(float64))
 }
 
+func (resource *UsersResource) ActivateBoost(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	boost, err := resource.PremiumUsecase.ActivateBoost(r.Context(), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(boost, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
+func (resource *UsersResource) BoostStatus(w http.ResponseWriter, r *http.Request) {
+	userID := resource.getUserIDFromContext(r)
+	m := utils.NewRestMetric(r)
+
+	defer func() {
+		m.TrackRestService()
+	}()
+
+	status, err := resource.PremiumUsecase.BoostStatus(r.Context(), userID)
+	if err != nil {
+		m = m.SetFail(resource.returnErrorResponse(w, r, err))
+		return
+	}
+
+	meta := utils.Meta{
+		HTTPStatus: http.StatusOK,
+	}
+	body := utils.NewDataResponse(status, meta)
+	body.WriteAPIResponse(w, r, http.StatusOK)
+}
+
 func (resource *UsersResource) returnErrorResponse(w http.ResponseWriter, r *http.Request, err error) int {
 	errOrig, ok := err.(*utils.StandardError)
 	if !ok {
@@ -204,3 +712,14 @@ func writeErrorResponse(w http.ResponseWriter, r *http.Request, err *utils.Stand
 	body := utils.NewErrorResponse(err, statusCode)
 	body.WriteAPIResponse(w, r, statusCode)
 }
+
+// writeRateLimitHeaders tells the client how much of the quota is left, the reset is a unix timestamp in seconds
+func writeRateLimitHeaders(w http.ResponseWriter, status *entity.QuotaStatus) {
+	if status == nil {
+		return
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(status.ResetAt.Unix(), 10))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -58,6 +59,8 @@ var (
    	}
 	}`
 
+	testCursorSigner = utils.NewCursorSigner([]byte("secretz"))
+
 	messageResponseBase = `{
    	"meta":{
       	"http_status":%d
@@ -78,10 +81,13 @@ type expected struct {
 func Test_NewUsersResource(t *testing.T) {
 	t.Run("new users resource", func(t *testing.T) {
 		auc := usecase.NewAuthUsecase(&utils.AuthConfig{}, &repository.PostgresRepository{}, &log.Logger{})
-		puc := usecase.NewPremiumUsecase(&repository.RedisRepository{}, &repository.PostgresRepository{}, &repository.CacheRepository{}, &log.Logger{})
-		uuc := usecase.NewUserUsecase(&utils.AuthConfig{}, &repository.RedisRepository{}, &repository.PostgresRepository{}, &repository.CacheRepository{}, &log.Logger{})
+		puc := usecase.NewPremiumUsecase(entity.Settings{}, &repository.RedisRepository{}, &repository.PostgresRepository{}, &repository.CacheRepository{}, &log.Logger{})
+		uuc := usecase.NewUserUsecase(&utils.AuthConfig{}, entity.Settings{}, &repository.RedisRepository{}, &repository.PostgresRepository{}, &repository.CacheRepository{}, &log.Logger{})
+		duc := usecase.NewDiscoveryUsecase(usecase.NewDefaultRanker(entity.RankingWeights{}), entity.Settings{}, &repository.RedisRepository{}, &repository.PostgresRepository{}, &log.Logger{})
+
+		ruc := usecase.NewReportUsecase(entity.Settings{}, &repository.PostgresRepository{}, &log.Logger{})
 
-		res := handler.NewUsersResource(auc, puc, uuc, &log.Logger{})
+		res := handler.NewUsersResource(auc, puc, uuc, duc, ruc, testCursorSigner, &log.Logger{})
 
 		assert.IsType(t, &handler.UsersResource{}, res)
 	})
@@ -200,7 +206,7 @@ func TestUsersResource_Login(t *testing.T) {
 					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
 			}
 
-			st := handler.NewUsersResource(uc, mockshandler.NewPremiumUsecase(t), mockshandler.NewUserUsecase(t), logger)
+			st := handler.NewUsersResource(uc, mockshandler.NewPremiumUsecase(t), mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
 
 			hndlr := http.HandlerFunc(st.Login)
 			hndlr.ServeHTTP(recorder, req)
@@ -222,6 +228,7 @@ func TestUsersResource_Create(t *testing.T) {
 		Username: "testuser",
 		Email:    "test@email.com",
 		Password: "testpassword",
+		Timezone: entity.DEFAULT_TIMEZONE,
 	}
 
 	badRequestData := `{
@@ -326,7 +333,7 @@ func TestUsersResource_Create(t *testing.T) {
 					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
 			}
 
-			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, logger)
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
 
 			hndlr := http.HandlerFunc(st.Create)
 			hndlr.ServeHTTP(recorder, req)
@@ -340,12 +347,14 @@ func TestUsersResource_Create(t *testing.T) {
 func TestUsersResource_Show(t *testing.T) {
 	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
 	normalUser := &entity.UserPublic{
-		ID:        uint(1),
-		Email:     "test@email.com",
-		Username:  "testuser",
-		Premium:   true,
-		CreatedAt: timestamp,
-		UpdatedAt: timestamp,
+		ID:           uint(1),
+		Email:        "test@email.com",
+		Username:     "testuser",
+		Premium:      true,
+		Timezone:     "Asia/Jakarta",
+		QuotaResetAt: timestamp.Add(24 * time.Hour),
+		CreatedAt:    timestamp,
+		UpdatedAt:    timestamp,
 	}
 
 	normalUserResponseString := `{
@@ -357,6 +366,8 @@ func TestUsersResource_Show(t *testing.T) {
 	      "email":"test@email.com",
 	      "username":"testuser",
 	      "premium":true,
+	      "timezone":"Asia/Jakarta",
+	      "quota_reset_at":"2025-02-03T00:00:00Z",
 	      "created_at":"2025-02-02T00:00:00Z",
 	      "updated_at":"2025-02-02T00:00:00Z"
 	   }
@@ -458,7 +469,7 @@ func TestUsersResource_Show(t *testing.T) {
 					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
 			}
 
-			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, logger)
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
 
 			hndlr := http.HandlerFunc(st.Show)
 			hndlr.ServeHTTP(recorder, req)
@@ -469,30 +480,47 @@ func TestUsersResource_Show(t *testing.T) {
 	}
 }
 
-func TestUsersResource_React(t *testing.T) {
-	normalRequestData := `{
-		      "target_id":  2,
-		      "type": 1
-		    }`
-
-	normalRequestDataParsed := entity.ReactionParams{
+func TestUsersResource_UpdateTimezone(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	normalRequestData := `{"timezone": "Europe/Berlin"}`
+	normalRequestDataParsed := entity.UserTimezoneParams{
 		UserID:   1,
-		TargetID: 2,
-		Type:     1,
+		Timezone: "Europe/Berlin",
+	}
+	updatedUser := &entity.UserPublic{
+		ID:           uint(1),
+		Email:        "test@email.com",
+		Username:     "testuser",
+		Timezone:     "Europe/Berlin",
+		QuotaResetAt: timestamp.Add(23 * time.Hour),
+		CreatedAt:    timestamp,
+		UpdatedAt:    timestamp,
 	}
 
-	badRequestData := `{
-		      "type": 1
-		    }`
+	updatedUserResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "id":1,
+	      "email":"test@email.com",
+	      "username":"testuser",
+	      "premium":false,
+	      "timezone":"Europe/Berlin",
+	      "quota_reset_at":"2025-02-02T23:00:00Z",
+	      "created_at":"2025-02-02T00:00:00Z",
+	      "updated_at":"2025-02-02T00:00:00Z"
+	   }
+	}`
 
 	type args struct {
 		args              uint
 		requestData       string
-		requestDataParsed entity.ReactionParams
+		requestDataParsed entity.UserTimezoneParams
 	}
 
 	type mocked struct {
-		handlerResult string
+		handlerResult *entity.UserPublic
 		handlerError  error
 	}
 
@@ -504,7 +532,7 @@ func TestUsersResource_React(t *testing.T) {
 		expected   expected
 	}{
 		{
-			name: "normal case - successfully react",
+			name: "normal case - successfully update timezone",
 			args: args{
 				args:              1,
 				requestData:       normalRequestData,
@@ -514,40 +542,22 @@ func TestUsersResource_React(t *testing.T) {
 				handlerFunc: true,
 			},
 			mocked: mocked{
-				handlerResult: "Reaction saved",
+				handlerResult: updatedUser,
 			},
 			expected: expected{
 				expectedHTTPStatus: http.StatusOK,
-				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Reaction saved"),
+				expectedResponse:   updatedUserResponseString,
 			},
 		},
 		{
-			name: "error case - missing parameters",
+			name: "error case - invalid timezone",
 			args: args{
 				args:        1,
-				requestData: badRequestData,
-			},
-			expected: expected{
-				expectedHTTPStatus: http.StatusBadRequest,
-				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid target user", "PARAMETER_PARSING_FAILS", "target_id"),
-			},
-		},
-		{
-			name: "error case - handler returned standard error",
-			args: args{
-				args:              1,
-				requestData:       normalRequestData,
-				requestDataParsed: normalRequestDataParsed,
-			},
-			shouldMock: shouldMock{
-				handlerFunc: true,
-			},
-			mocked: mocked{
-				handlerError: utils.NewStandardError("unexpected", "DB ERROR", "server"),
+				requestData: `{"timezone": "Mars/Olympus"}`,
 			},
 			expected: expected{
 				expectedHTTPStatus: http.StatusBadRequest,
-				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "unexpected", "DB ERROR", "server"),
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid timezone", "PARAMETER_PARSING_FAILS", "timezone"),
 			},
 		},
 		{
@@ -574,22 +584,22 @@ func TestUsersResource_React(t *testing.T) {
 		uc := mockshandler.NewUserUsecase(t)
 		t.Run(tc.name, func(t *testing.T) {
 			logger := initLogger(t)
-			urlPath := "/api/protected/users/react"
+			urlPath := "/api/protected/users/timezone"
 
-			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer([]byte(tc.args.requestData)))
+			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBufferString(tc.args.requestData))
 			recorder := httptest.NewRecorder()
 			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
 			req = req.WithContext(ctx)
 
 			if tc.shouldMock.handlerFunc {
 				uc.
-					On("React", ctx, tc.args.requestDataParsed).
-					Return(tc.mocked.handlerError)
+					On("UpdateTimezone", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
 			}
 
-			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, logger)
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
 
-			hndlr := http.HandlerFunc(st.React)
+			hndlr := http.HandlerFunc(st.UpdateTimezone)
 			hndlr.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
@@ -598,13 +608,65 @@ func TestUsersResource_React(t *testing.T) {
 	}
 }
 
-func TestUsersResource_GrantPremium(t *testing.T) {
+func TestUsersResource_React(t *testing.T) {
+	normalRequestData := `{
+		      "target_id":  2,
+		      "type": "pass"
+		    }`
+
+	normalRequestDataParsed := entity.ReactionParams{
+		UserID:   1,
+		TargetID: 2,
+		Type:     entity.REACTION_TYPE_PASS,
+	}
+
+	badRequestData := `{
+		      "type": 1
+		    }`
+
+	resetAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	rateLimit := &entity.QuotaStatus{
+		Name:      "reaction",
+		Window:    entity.QUOTA_WINDOW_DAILY,
+		Limit:     10,
+		Remaining: 0,
+		ResetAt:   resetAt,
+	}
+	rateLimitHeaders := map[string]string{
+		"X-RateLimit-Limit":     "10",
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     "1792368000",
+	}
+
+	reactionResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "message":"Reaction saved",
+	   "data":{
+	      "matched":false
+	   }
+	}`
+
+	matchedResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "message":"Reaction saved",
+	   "data":{
+	      "matched":true,
+	      "match_id":9
+	   }
+	}`
+
 	type args struct {
-		args uint
+		args              uint
+		requestData       string
+		requestDataParsed entity.ReactionParams
 	}
 
 	type mocked struct {
-		handlerResult string
+		handlerResult entity.ReactionResult
 		handlerError  error
 	}
 
@@ -614,27 +676,108 @@ func TestUsersResource_GrantPremium(t *testing.T) {
 		mocked     mocked
 		shouldMock shouldMock
 		expected   expected
+		headers    map[string]string
 	}{
 		{
-			name: "normal case - successfully grant premium",
+			name: "normal case - successfully react",
 			args: args{
-				args: 1,
+				args:              1,
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   reactionResponseString,
+			},
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "",
+				"X-RateLimit-Remaining": "",
+				"X-RateLimit-Reset":     "",
+			},
+		},
+		{
+			name: "normal case - successfully react with rate limit headers",
+			args: args{
+				args:              1,
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
 			},
 			shouldMock: shouldMock{
 				handlerFunc: true,
 			},
 			mocked: mocked{
-				handlerResult: "Reaction saved",
+				handlerResult: entity.ReactionResult{
+					RateLimit: &entity.QuotaStatus{Name: "reaction", Window: entity.QUOTA_WINDOW_DAILY, Limit: 10, Remaining: 3, ResetAt: resetAt},
+				},
 			},
 			expected: expected{
 				expectedHTTPStatus: http.StatusOK,
-				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Premium granted"),
+				expectedResponse:   reactionResponseString,
+			},
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "10",
+				"X-RateLimit-Remaining": "3",
+				"X-RateLimit-Reset":     "1792368000",
+			},
+		},
+		{
+			name: "normal case - successfully react and match",
+			args: args{
+				args:              1,
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: entity.ReactionResult{Matched: true, MatchID: 9},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   matchedResponseString,
+			},
+		},
+		{
+			name: "error case - limit exceeded keeps the rate limit headers",
+			args: args{
+				args:              1,
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: entity.ReactionResult{RateLimit: rateLimit},
+				handlerError:  utils.NewStandardError("Reaction limit exceeded, try again tommorow", "LIMIT_EXCEEDED", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "Reaction limit exceeded, try again tommorow", "LIMIT_EXCEEDED"),
+			},
+			headers: rateLimitHeaders,
+		},
+		{
+			name: "error case - missing parameters",
+			args: args{
+				args:        1,
+				requestData: badRequestData,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid target user", "PARAMETER_PARSING_FAILS", "target_id"),
 			},
 		},
 		{
 			name: "error case - handler returned standard error",
 			args: args{
-				args: 1,
+				args:              1,
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
 			},
 			shouldMock: shouldMock{
 				handlerFunc: true,
@@ -650,7 +793,9 @@ func TestUsersResource_GrantPremium(t *testing.T) {
 		{
 			name: "error case - handler returned unexpected error",
 			args: args{
-				args: 1,
+				args:              1,
+				requestData:       normalRequestData,
+				requestDataParsed: normalRequestDataParsed,
 			},
 			shouldMock: shouldMock{
 				handlerFunc: true,
@@ -666,90 +811,115 @@ func TestUsersResource_GrantPremium(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		uc := mockshandler.NewPremiumUsecase(t)
+		uc := mockshandler.NewUserUsecase(t)
 		t.Run(tc.name, func(t *testing.T) {
 			logger := initLogger(t)
-			urlPath := "/api/protected/users/premium/grant"
+			urlPath := "/api/protected/users/react"
 
-			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer(nil))
+			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer([]byte(tc.args.requestData)))
 			recorder := httptest.NewRecorder()
 			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
 			req = req.WithContext(ctx)
 
 			if tc.shouldMock.handlerFunc {
 				uc.
-					On("Grant", ctx, tc.args.args).
-					Return(tc.mocked.handlerError)
+					On("React", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
 			}
 
-			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), uc, mockshandler.NewUserUsecase(t), logger)
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
 
-			hndlr := http.HandlerFunc(st.GrantPremium)
+			hndlr := http.HandlerFunc(st.React)
 			hndlr.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
 			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+			for header, value := range tc.headers {
+				assert.Equal(t, value, recorder.Result().Header.Get(header))
+			}
 		})
 	}
 }
 
-func TestUsersResource_UnsubscribePremium(t *testing.T) {
-	type args struct {
-		args uint
+func TestUsersResource_ReactBatch(t *testing.T) {
+	reactedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	normalRequestData := `{
+	   "reactions": [
+	      {"target_id": 2, "type": "like", "reacted_at": "2026-10-18T09:00:00Z"},
+	      {"target_id": 3, "type": "super_like", "reacted_at": "2026-10-18T09:00:00Z"}
+	   ]
+	}`
+
+	normalRequestDataParsed := entity.BatchReactionParams{
+		UserID: 1,
+		Reactions: []entity.BatchReactionItem{
+			{TargetID: 2, Type: entity.REACTION_TYPE_LIKE, ReactedAt: reactedAt},
+			{TargetID: 3, Type: entity.REACTION_TYPE_SUPER_LIKE, ReactedAt: reactedAt},
+		},
 	}
 
+	batchResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":[
+	      {"target_id":2, "type":"like", "reacted_at":"2026-10-18T09:00:00Z", "status":"applied", "matched":true, "match_id":9},
+	      {
+	         "target_id":3,
+	         "type":"super_like",
+	         "reacted_at":"2026-10-18T09:00:00Z",
+	         "status":"rejected",
+	         "matched":false,
+	         "error":{"message":"Super like limit exceeded, try again tomorrow", "code":"LIMIT_EXCEEDED"}
+	      }
+	   ]
+	}`
+
 	type mocked struct {
-		handlerResult string
+		handlerResult []entity.BatchReactionResult
 		handlerError  error
 	}
 
 	cases := []struct {
 		name       string
-		args       args
+		args       string
+		shouldMock bool
 		mocked     mocked
-		shouldMock shouldMock
 		expected   expected
 	}{
 		{
-			name: "normal case - successfully unsubscribed from premium",
-			args: args{
-				args: 1,
-			},
-			shouldMock: shouldMock{
-				handlerFunc: true,
-			},
+			name:       "normal case - successfully apply batch",
+			args:       normalRequestData,
+			shouldMock: true,
 			mocked: mocked{
-				handlerResult: "Reaction saved",
+				handlerResult: []entity.BatchReactionResult{
+					{TargetID: 2, Type: entity.REACTION_TYPE_LIKE, ReactedAt: reactedAt, Status: entity.BATCH_REACTION_STATUS_APPLIED, Matched: true, MatchID: 9},
+					{
+						TargetID:  3,
+						Type:      entity.REACTION_TYPE_SUPER_LIKE,
+						ReactedAt: reactedAt,
+						Status:    entity.BATCH_REACTION_STATUS_REJECTED,
+						Error:     utils.NewStandardError("Super like limit exceeded, try again tomorrow", "LIMIT_EXCEEDED", ""),
+					},
+				},
 			},
 			expected: expected{
 				expectedHTTPStatus: http.StatusOK,
-				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Unsubscribed from premium"),
+				expectedResponse:   batchResponseString,
 			},
 		},
 		{
-			name: "error case - handler returned standard error",
-			args: args{
-				args: 1,
-			},
-			shouldMock: shouldMock{
-				handlerFunc: true,
-			},
-			mocked: mocked{
-				handlerError: utils.NewStandardError("unexpected", "DB ERROR", "server"),
-			},
+			name: "error case - empty batch",
+			args: `{"reactions": []}`,
 			expected: expected{
 				expectedHTTPStatus: http.StatusBadRequest,
-				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "unexpected", "DB ERROR", "server"),
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Empty reaction batch", "PARAMETER_PARSING_FAILS", "reactions"),
 			},
 		},
 		{
-			name: "error case - handler returned unexpected error",
-			args: args{
-				args: 1,
-			},
-			shouldMock: shouldMock{
-				handlerFunc: true,
-			},
+			name:       "error case - handler returned unexpected error",
+			args:       normalRequestData,
+			shouldMock: true,
 			mocked: mocked{
 				handlerError: errors.New("unexpected"),
 			},
@@ -761,25 +931,22 @@ func TestUsersResource_UnsubscribePremium(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		uc := mockshandler.NewPremiumUsecase(t)
+		uc := mockshandler.NewUserUsecase(t)
 		t.Run(tc.name, func(t *testing.T) {
 			logger := initLogger(t)
-			urlPath := "/api/protected/users/premium/unsubscribe"
 
-			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer(nil))
+			req := httptest.NewRequest(http.MethodPost, "/api/protected/users/react/batch", bytes.NewBufferString(tc.args))
 			recorder := httptest.NewRecorder()
-			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
 			req = req.WithContext(ctx)
 
-			if tc.shouldMock.handlerFunc {
-				uc.
-					On("Unsubscribe", ctx, tc.args.args).
-					Return(tc.mocked.handlerError)
+			if tc.shouldMock {
+				uc.On("ReactBatch", ctx, normalRequestDataParsed).Return(tc.mocked.handlerResult, tc.mocked.handlerError)
 			}
 
-			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), uc, mockshandler.NewUserUsecase(t), logger)
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
 
-			hndlr := http.HandlerFunc(st.UnsubscribePremium)
+			hndlr := http.HandlerFunc(st.ReactBatch)
 			hndlr.ServeHTTP(recorder, req)
 
 			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
@@ -788,15 +955,2084 @@ func TestUsersResource_UnsubscribePremium(t *testing.T) {
 	}
 }
 
-func initRoutingContext(ctx context.Context) context.Context {
-	return context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
-}
+func TestUsersResource_UndoReaction(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
 
-func initLogger(t *testing.T) *log.Logger {
-	config := log.NewProductionConfig()
-	config.Level = log.NewAtomicLevelAt(log.FatalLevel)
+	undoResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "user_id":1,
+	      "target_id":2,
+	      "type":"pass",
+	      "created_at":"2025-02-02T00:00:00Z",
+	      "updated_at":"2025-02-02T00:00:00Z"
+	   }
+	}`
 
-	logger, err := config.Build()
-	assert.Nil(t, err)
-	return logger
+	type mocked struct {
+		handlerResult *entity.UserReaction
+		handlerError  error
+	}
+
+	cases := []struct {
+		name     string
+		args     uint
+		mocked   mocked
+		expected expected
+	}{
+		{
+			name: "normal case - successfully undo reaction",
+			args: 1,
+			mocked: mocked{
+				handlerResult: &entity.UserReaction{UserID: 1, TargetID: 2, Type: 1, CreatedAt: timestamp, UpdatedAt: timestamp},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   undoResponseString,
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: 1,
+			mocked: mocked{
+				handlerError: utils.NewStandardError("Rewind limit exceeded, try again tomorrow", "LIMIT_EXCEEDED", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "Rewind limit exceeded, try again tomorrow", "LIMIT_EXCEEDED"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: 1,
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/protected/users/react/undo", bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args)))
+			req = req.WithContext(ctx)
+
+			uc.On("UndoReaction", ctx, tc.args).Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.UndoReaction)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_ReactionHistory(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	likeType := entity.REACTION_TYPE_LIKE
+	history := []entity.ReactionHistoryItem{
+		{
+			Type:      likeType,
+			Target:    entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt: timestamp,
+			UpdatedAt: timestamp,
+		},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp, ID: 2}
+
+	historyResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "type":"like",
+	         "target":{
+	            "id":2,
+	            "username":"second"
+	         },
+	         "created_at":"2025-02-02T00:00:00Z",
+	         "updated_at":"2025-02-02T00:00:00Z"
+	      }
+	   ]
+	}`
+
+	type args struct {
+		args              uint
+		query             string
+		requestDataParsed entity.ReactionHistoryParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.ReactionHistoryItem
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully get reaction history",
+			args: args{
+				args:  1,
+				query: "?type=2&limit=1",
+				requestDataParsed: entity.ReactionHistoryParams{
+					UserID: 1,
+					Type:   &likeType,
+					Page:   utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: history,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(historyResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid limit",
+			args: args{
+				args:  1,
+				query: "?limit=0",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Limit must be between 1 and 100", "PARAMETER_PARSING_FAILS", "limit"),
+			},
+		},
+		{
+			name: "error case - invalid type",
+			args: args{
+				args:  1,
+				query: "?type=9",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid reaction type", "PARAMETER_PARSING_FAILS", "type"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+				requestDataParsed: entity.ReactionHistoryParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/reactions" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("ReactionHistory", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.ReactionHistory)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Matches(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	matches := []entity.MatchListItem{
+		{
+			ID:             7,
+			User:           entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt:      timestamp,
+			LastActiveAt:   timestamp.Add(time.Hour),
+			LastActivityAt: timestamp.Add(2 * time.Hour),
+			UnreadCount:    3,
+		},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp.Add(2 * time.Hour), ID: 7}
+
+	matchesResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "id":7,
+	         "user":{
+	            "id":2,
+	            "username":"second"
+	         },
+	         "matched_at":"2025-02-02T00:00:00Z",
+	         "last_active_at":"2025-02-02T01:00:00Z",
+	         "last_activity_at":"2025-02-02T02:00:00Z",
+	         "unread_count":3
+	      }
+	   ]
+	}`
+
+	type args struct {
+		args              uint
+		query             string
+		requestDataParsed entity.MatchListParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.MatchListItem
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully list matches",
+			args: args{
+				args:  1,
+				query: "?limit=1",
+				requestDataParsed: entity.MatchListParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: matches,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(matchesResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid limit",
+			args: args{
+				args:  1,
+				query: "?limit=0",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Limit must be between 1 and 100", "PARAMETER_PARSING_FAILS", "limit"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+				requestDataParsed: entity.MatchListParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/matches" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Matches", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Matches)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Unmatch(t *testing.T) {
+	type args struct {
+		args              uint
+		matchID           string
+		requestData       string
+		requestDataParsed entity.UnmatchParams
+	}
+
+	type mocked struct {
+		handlerError error
+	}
+
+	normalRequestDataParsed := entity.UnmatchParams{
+		MatchID: 7,
+		UserID:  1,
+		Reason:  entity.UNMATCH_REASON_NOT_INTERESTED,
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully end match",
+			args: args{
+				args:              1,
+				matchID:           "7",
+				requestData:       `{"reason": "not_interested"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Match ended"),
+			},
+		},
+		{
+			name: "error case - invalid match ID",
+			args: args{
+				args:        1,
+				matchID:     "abc",
+				requestData: `{"reason": "not_interested"}`,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid match", "PARAMETER_PARSING_FAILS", "id"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args:              1,
+				matchID:           "7",
+				requestData:       `{"reason": "not_interested"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.NewStandardError("Match not found", "NOT FOUND", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "Match not found", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args:              1,
+				matchID:           "7",
+				requestData:       `{"reason": "not_interested"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/matches/" + tc.args.matchID
+
+			req := httptest.NewRequest(http.MethodDelete, urlPath, bytes.NewBuffer([]byte(tc.args.requestData)))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			chi.RouteContext(ctx).URLParams.Add("id", tc.args.matchID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Unmatch", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Unmatch)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Profile(t *testing.T) {
+	lastActiveAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	profileResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "id":2,
+	      "username":"second",
+	      "bio":"hello",
+	      "last_active_at":"2026-10-18T09:00:00Z"
+	   }
+	}`
+
+	type args struct {
+		args     uint
+		targetID string
+	}
+
+	type mocked struct {
+		handlerResult *entity.UserProfile
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully show profile",
+			args: args{
+				args:     1,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.UserProfile{ID: 2, Username: "second", Bio: "hello", LastActiveAt: lastActiveAt},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   profileResponseString,
+			},
+		},
+		{
+			name: "error case - invalid target ID",
+			args: args{
+				args:     1,
+				targetID: "1",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid target user", "PARAMETER_PARSING_FAILS", "id"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args:     1,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.UserNotFoundError(2),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "User not found:2", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args:     1,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/" + tc.args.targetID
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			chi.RouteContext(ctx).URLParams.Add("id", tc.args.targetID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Profile", ctx, uint(1), uint(2)).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Profile)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Block(t *testing.T) {
+	type args struct {
+		method   string
+		targetID string
+	}
+
+	type mocked struct {
+		handlerError error
+	}
+
+	normalRequestDataParsed := entity.BlockParams{UserID: 1, TargetID: 2}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully block user",
+			args: args{
+				method:   http.MethodPost,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "User blocked"),
+			},
+		},
+		{
+			name: "normal case - successfully unblock user",
+			args: args{
+				method:   http.MethodDelete,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "User unblocked"),
+			},
+		},
+		{
+			name: "error case - blocking with invalid target ID",
+			args: args{
+				method:   http.MethodPost,
+				targetID: "abc",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid target user", "PARAMETER_PARSING_FAILS", "id"),
+			},
+		},
+		{
+			name: "error case - unblocking with invalid target ID",
+			args: args{
+				method:   http.MethodDelete,
+				targetID: "abc",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid target user", "PARAMETER_PARSING_FAILS", "id"),
+			},
+		},
+		{
+			name: "error case - unblocking returned standard error",
+			args: args{
+				method:   http.MethodDelete,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.NewStandardError("Block not found", "NOT FOUND", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "Block not found", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - blocking returned unexpected error",
+			args: args{
+				method:   http.MethodPost,
+				targetID: "2",
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/blocks/" + tc.args.targetID
+
+			req := httptest.NewRequest(tc.args.method, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			chi.RouteContext(ctx).URLParams.Add("id", tc.args.targetID)
+			req = req.WithContext(ctx)
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Block)
+			usecaseFunc := "Block"
+			if tc.args.method == http.MethodDelete {
+				hndlr = http.HandlerFunc(st.Unblock)
+				usecaseFunc = "Unblock"
+			}
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On(usecaseFunc, ctx, normalRequestDataParsed).
+					Return(tc.mocked.handlerError)
+			}
+
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Blocks(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	blocks := []entity.BlockListItem{
+		{
+			User:      entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt: timestamp,
+		},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp, ID: 2}
+
+	blocksResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "user":{
+	            "id":2,
+	            "username":"second"
+	         },
+	         "blocked_at":"2025-02-02T00:00:00Z"
+	      }
+	   ]
+	}`
+
+	type args struct {
+		query             string
+		requestDataParsed entity.BlockListParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.BlockListItem
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully list blocks",
+			args: args{
+				query: "?limit=1",
+				requestDataParsed: entity.BlockListParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: blocks,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(blocksResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid limit",
+			args: args{
+				query: "?limit=0",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Limit must be between 1 and 100", "PARAMETER_PARSING_FAILS", "limit"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				requestDataParsed: entity.BlockListParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/blocks" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Blocks", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Blocks)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_ReceivedLikes(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	nextCursor := utils.Cursor{CreatedAt: timestamp, ID: 2}
+
+	premiumResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":{
+	      "count":3,
+	      "likes":[
+	         {
+	            "user":{
+	               "id":2,
+	               "username":"second"
+	            },
+	            "blurred":false,
+	            "created_at":"2025-02-02T00:00:00Z"
+	         }
+	      ]
+	   }
+	}`
+
+	freeResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "has_more":false
+	   },
+	   "data":{
+	      "count":3,
+	      "likes":[
+	         {
+	            "blurred":true
+	         }
+	      ]
+	   }
+	}`
+
+	type args struct {
+		args              uint
+		query             string
+		requestDataParsed entity.ReceivedLikesParams
+	}
+
+	type mocked struct {
+		handlerResult entity.ReceivedLikes
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - premium user gets received likes",
+			args: args{
+				args:  1,
+				query: "?limit=1",
+				requestDataParsed: entity.ReceivedLikesParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: entity.ReceivedLikes{
+					Count: 3,
+					Likes: []entity.ReceivedLike{{User: &entity.UserSummary{ID: 2, Username: "second"}, CreatedAt: &timestamp}},
+				},
+				handlerPage: utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(premiumResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "normal case - free user gets blurred likes",
+			args: args{
+				args:  1,
+				query: "?limit=1",
+				requestDataParsed: entity.ReceivedLikesParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: entity.ReceivedLikes{
+					Count: 3,
+					Likes: []entity.ReceivedLike{{Blurred: true}},
+				},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   freeResponseString,
+			},
+		},
+		{
+			name: "error case - invalid limit",
+			args: args{
+				args:  1,
+				query: "?limit=abc",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Limit must be between 1 and 100", "PARAMETER_PARSING_FAILS", "limit"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+				requestDataParsed: entity.ReceivedLikesParams{
+					UserID: 1,
+					Page:   utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/likes/received" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("ReceivedLikes", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.ReceivedLikes)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Quota(t *testing.T) {
+	summary := entity.QuotaSummary{
+		Tier: entity.TIER_FREE,
+		Quotas: []entity.QuotaStatus{
+			{
+				Name:      "reaction",
+				Window:    entity.QUOTA_WINDOW_DAILY,
+				Limit:     10,
+				Remaining: 6,
+				ResetAt:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	summaryResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "tier":"free",
+	      "quotas":[
+	         {
+	            "name":"reaction",
+	            "window":"daily",
+	            "limit":10,
+	            "remaining":6,
+	            "reset_at":"2026-10-19T00:00:00Z"
+	         }
+	      ]
+	   }
+	}`
+
+	type args struct {
+		args uint
+	}
+
+	type mocked struct {
+		handlerResult entity.QuotaSummary
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully get quota",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: summary,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   summaryResponseString,
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewUserUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/quota"
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Quota", ctx, tc.args.args).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), uc, mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Quota)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Discover(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	candidates := []entity.Candidate{
+		{
+			ID:           2,
+			Username:     "candidate",
+			Bio:          "hello",
+			EloScore:     1600,
+			LastActiveAt: timestamp,
+			Score:        0.8,
+		},
+	}
+
+	candidatesResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":[
+	      {
+	         "id":2,
+	         "username":"candidate",
+	         "bio":"hello",
+	         "last_active_at":"2025-02-02T00:00:00Z",
+	         "super_liked":false
+	      }
+	   ]
+	}`
+
+	type args struct {
+		args uint
+	}
+
+	type mocked struct {
+		handlerResult []entity.Candidate
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully get candidates",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: candidates,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   candidatesResponseString,
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.UserNotFoundError(1),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "User not found:1", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewDiscoveryUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/discover"
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Discover", ctx, tc.args.args).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), mockshandler.NewUserUsecase(t), uc, mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Discover)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_GrantPremium(t *testing.T) {
+	type args struct {
+		args uint
+	}
+
+	type mocked struct {
+		handlerResult string
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully grant premium",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: "Reaction saved",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Premium granted"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.NewStandardError("unexpected", "DB ERROR", "server"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "unexpected", "DB ERROR", "server"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewPremiumUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/premium/grant"
+
+			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Grant", ctx, tc.args.args).
+					Return(tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), uc, mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.GrantPremium)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_UnsubscribePremium(t *testing.T) {
+	type args struct {
+		args uint
+	}
+
+	type mocked struct {
+		handlerResult string
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully unsubscribed from premium",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: "Reaction saved",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Unsubscribed from premium"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.NewStandardError("unexpected", "DB ERROR", "server"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "unexpected", "DB ERROR", "server"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				args: 1,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewPremiumUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/premium/unsubscribe"
+
+			req := httptest.NewRequest(http.MethodPatch, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(tc.args.args)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Unsubscribe", ctx, tc.args.args).
+					Return(tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), uc, mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.UnsubscribePremium)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func initRoutingContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+}
+
+func initLogger(t *testing.T) *log.Logger {
+	config := log.NewProductionConfig()
+	config.Level = log.NewAtomicLevelAt(log.FatalLevel)
+
+	logger, err := config.Build()
+	assert.Nil(t, err)
+	return logger
+}
+
+func TestUsersResource_ActivateBoost(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+
+	boostResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "id":7,
+	      "started_at":"2025-02-02T00:00:00Z",
+	      "ends_at":"2025-02-02T00:30:00Z",
+	      "impressions":0,
+	      "likes":0
+	   }
+	}`
+
+	type mocked struct {
+		handlerResult entity.Boost
+		handlerError  error
+	}
+
+	cases := []struct {
+		name     string
+		mocked   mocked
+		expected expected
+	}{
+		{
+			name: "normal case - successfully activate boost",
+			mocked: mocked{
+				handlerResult: entity.Boost{ID: 7, UserID: 1, StartedAt: timestamp, EndsAt: timestamp.Add(30 * time.Minute)},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   boostResponseString,
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			mocked: mocked{
+				handlerError: utils.NewStandardError("No boost left", "NO_BOOST_BALANCE", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "No boost left", "NO_BOOST_BALANCE"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewPremiumUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/protected/users/boost", bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			uc.On("ActivateBoost", ctx, uint(1)).Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), uc, mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.ActivateBoost)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_BoostStatus(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+
+	statusResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "balance":2,
+	      "active":{
+	         "id":7,
+	         "started_at":"2025-02-02T00:00:00Z",
+	         "ends_at":"2025-02-02T00:30:00Z",
+	         "impressions":40,
+	         "likes":6
+	      }
+	   }
+	}`
+
+	type mocked struct {
+		handlerResult entity.BoostStatus
+		handlerError  error
+	}
+
+	cases := []struct {
+		name     string
+		mocked   mocked
+		expected expected
+	}{
+		{
+			name: "normal case - successfully get boost status",
+			mocked: mocked{
+				handlerResult: entity.BoostStatus{
+					Balance: 2,
+					Active:  &entity.Boost{ID: 7, UserID: 1, StartedAt: timestamp, EndsAt: timestamp.Add(30 * time.Minute), Impressions: 40, Likes: 6},
+				},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   statusResponseString,
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewPremiumUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/protected/users/boost", bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			uc.On("BoostStatus", ctx, uint(1)).Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), uc, mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), mockshandler.NewReportUsecase(t), testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.BoostStatus)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_Report(t *testing.T) {
+	type args struct {
+		targetID          string
+		requestBody       string
+		requestDataParsed entity.ReportParams
+	}
+
+	type mocked struct {
+		handlerResult *entity.Report
+		handlerError  error
+	}
+
+	normalRequestDataParsed := entity.ReportParams{ReporterID: 1, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - new report",
+			args: args{
+				targetID:          "2",
+				requestBody:       `{"reason": "spam"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.Report{ID: 5, Created: true},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusCreated,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusCreated, "Report received"),
+			},
+		},
+		{
+			name: "normal case - report already open",
+			args: args{
+				targetID:          "2",
+				requestBody:       `{"reason": "spam"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.Report{ID: 5},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(messageResponseBase, http.StatusOK, "Report received"),
+			},
+		},
+		{
+			name: "error case - invalid reason",
+			args: args{
+				targetID:    "2",
+				requestBody: `{"reason": "boring"}`,
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid report reason", "PARAMETER_PARSING_FAILS", "reason"),
+			},
+		},
+		{
+			name: "error case - handler returned standard error",
+			args: args{
+				targetID:          "2",
+				requestBody:       `{"reason": "spam"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.UserNotFoundError(2),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "User not found:2", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				targetID:          "2",
+				requestBody:       `{"reason": "spam"}`,
+				requestDataParsed: normalRequestDataParsed,
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewReportUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/users/" + tc.args.targetID + "/report"
+
+			req := httptest.NewRequest(http.MethodPost, urlPath, bytes.NewBufferString(tc.args.requestBody))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			chi.RouteContext(ctx).URLParams.Add("id", tc.args.targetID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Report", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), uc, testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.Report)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_ReportQueue(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	reports := []entity.ReportQueueItem{
+		{
+			Report:         entity.Report{ID: 5, ReporterID: 1, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM, Status: entity.REPORT_STATUS_PENDING, CreatedAt: timestamp},
+			Reporters:      3,
+			ReportedHidden: true,
+		},
+	}
+	nextCursor := utils.Cursor{CreatedAt: timestamp, ID: 5}
+
+	reportsResponseString := `{
+	   "meta":{
+	      "http_status":200,
+	      "next_cursor":"%s",
+	      "has_more":true
+	   },
+	   "data":[
+	      {
+	         "id":5,
+	         "reporter_id":1,
+	         "reported_id":2,
+	         "reason":"spam",
+	         "status":"pending",
+	         "created_at":"2025-02-02T00:00:00Z",
+	         "reporters":3,
+	         "reported_hidden":true
+	      }
+	   ]
+	}`
+
+	type args struct {
+		query             string
+		requestDataParsed entity.ReportQueueParams
+	}
+
+	type mocked struct {
+		handlerResult []entity.ReportQueueItem
+		handlerPage   utils.Page
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully list pending reports",
+			args: args{
+				query: "?limit=1",
+				requestDataParsed: entity.ReportQueueParams{
+					ModeratorID: 1,
+					Status:      entity.REPORT_STATUS_PENDING,
+					Page:        utils.PageParams{Limit: 1},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: reports,
+				handlerPage:   utils.Page{HasMore: true, NextCursor: &nextCursor},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(reportsResponseString, testCursorSigner.Encode(nextCursor)),
+			},
+		},
+		{
+			name: "error case - invalid status",
+			args: args{
+				query: "?status=open",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid report status", "PARAMETER_PARSING_FAILS", "status"),
+			},
+		},
+		{
+			name: "error case - not a moderator",
+			args: args{
+				query: "?status=claimed",
+				requestDataParsed: entity.ReportQueueParams{
+					ModeratorID: 1,
+					Status:      entity.REPORT_STATUS_CLAIMED,
+					Page:        utils.PageParams{Limit: utils.DefaultPageLimit},
+				},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: &utils.StandardError{Message: "Only moderators can review reports", Code: "FORBIDDEN", HttpStatus: http.StatusForbidden},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusForbidden,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusForbidden, "Only moderators can review reports", "FORBIDDEN"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewReportUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/moderation/reports" + tc.args.query
+
+			req := httptest.NewRequest(http.MethodGet, urlPath, bytes.NewBuffer(nil))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On("Queue", ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerPage, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), uc, testCursorSigner, logger)
+
+			hndlr := http.HandlerFunc(st.ReportQueue)
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestUsersResource_ReportAction(t *testing.T) {
+	moderatorID := uint(1)
+	reportResponseString := `{
+	   "meta":{
+	      "http_status":200
+	   },
+	   "data":{
+	      "id":5,
+	      "reporter_id":3,
+	      "reported_id":2,
+	      "reason":"spam",
+	      "status":"%s",
+	      "claimed_by":1,%s
+	      "created_at":"0001-01-01T00:00:00Z"
+	   }
+	}`
+
+	type args struct {
+		action            string
+		reportID          string
+		requestBody       string
+		requestDataParsed entity.ReportActionParams
+	}
+
+	type mocked struct {
+		handlerResult *entity.Report
+		handlerError  error
+	}
+
+	cases := []struct {
+		name       string
+		args       args
+		mocked     mocked
+		shouldMock shouldMock
+		expected   expected
+	}{
+		{
+			name: "normal case - successfully claim report",
+			args: args{
+				action:            "Claim",
+				reportID:          "5",
+				requestDataParsed: entity.ReportActionParams{ReportID: 5, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.Report{ID: 5, ReporterID: 3, ReportedID: 2, Reason: "spam", Status: "claimed", ClaimedBy: &moderatorID},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(reportResponseString, "claimed", ""),
+			},
+		},
+		{
+			name: "normal case - successfully resolve report",
+			args: args{
+				action:            "Resolve",
+				reportID:          "5",
+				requestBody:       `{"note": "warned"}`,
+				requestDataParsed: entity.ReportActionParams{ReportID: 5, ModeratorID: 1, Note: "warned"},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.Report{ID: 5, ReporterID: 3, ReportedID: 2, Reason: "spam", Status: "resolved", ClaimedBy: &moderatorID, Note: "warned"},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(reportResponseString, "resolved", `"note":"warned",`),
+			},
+		},
+		{
+			name: "normal case - successfully dismiss report",
+			args: args{
+				action:            "Dismiss",
+				reportID:          "5",
+				requestBody:       `{"note": "no issue"}`,
+				requestDataParsed: entity.ReportActionParams{ReportID: 5, ModeratorID: 1, Note: "no issue"},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerResult: &entity.Report{ID: 5, ReporterID: 3, ReportedID: 2, Reason: "spam", Status: "dismissed", ClaimedBy: &moderatorID, Note: "no issue"},
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusOK,
+				expectedResponse:   fmt.Sprintf(reportResponseString, "dismissed", `"note":"no issue",`),
+			},
+		},
+		{
+			name: "error case - invalid report ID",
+			args: args{
+				action:   "Claim",
+				reportID: "abc",
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseBase, http.StatusBadRequest, "Invalid report", "PARAMETER_PARSING_FAILS", "id"),
+			},
+		},
+		{
+			name: "error case - report not claimed by the moderator",
+			args: args{
+				action:            "Resolve",
+				reportID:          "5",
+				requestDataParsed: entity.ReportActionParams{ReportID: 5, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: utils.NewStandardError("Report not found or not claimed by you", "NOT FOUND", ""),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusBadRequest,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusBadRequest, "Report not found or not claimed by you", "NOT FOUND"),
+			},
+		},
+		{
+			name: "error case - handler returned unexpected error",
+			args: args{
+				action:            "Dismiss",
+				reportID:          "5",
+				requestDataParsed: entity.ReportActionParams{ReportID: 5, ModeratorID: 1},
+			},
+			shouldMock: shouldMock{
+				handlerFunc: true,
+			},
+			mocked: mocked{
+				handlerError: errors.New("unexpected"),
+			},
+			expected: expected{
+				expectedHTTPStatus: http.StatusInternalServerError,
+				expectedResponse:   fmt.Sprintf(stdErrorResponseWithoutField, http.StatusInternalServerError, "internal server error, please check the server logs", "INTERNAL SERVER ERROR"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		uc := mockshandler.NewReportUsecase(t)
+		t.Run(tc.name, func(t *testing.T) {
+			logger := initLogger(t)
+			urlPath := "/api/protected/moderation/reports/" + tc.args.reportID + "/" + strings.ToLower(tc.args.action)
+
+			req := httptest.NewRequest(http.MethodPost, urlPath, bytes.NewBufferString(tc.args.requestBody))
+			recorder := httptest.NewRecorder()
+			ctx := initRoutingContext(context.WithValue(req.Context(), utils.CtxUserIDKey, float64(1)))
+			chi.RouteContext(ctx).URLParams.Add("id", tc.args.reportID)
+			req = req.WithContext(ctx)
+
+			if tc.shouldMock.handlerFunc {
+				uc.
+					On(tc.args.action, ctx, tc.args.requestDataParsed).
+					Return(tc.mocked.handlerResult, tc.mocked.handlerError)
+			}
+
+			st := handler.NewUsersResource(mockshandler.NewAuthUsecase(t), mockshandler.NewPremiumUsecase(t), mockshandler.NewUserUsecase(t), mockshandler.NewDiscoveryUsecase(t), uc, testCursorSigner, logger)
+
+			hndlr := map[string]http.HandlerFunc{
+				"Claim":   st.ClaimReport,
+				"Resolve": st.ResolveReport,
+				"Dismiss": st.DismissReport,
+			}[tc.args.action]
+			hndlr.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected.expectedHTTPStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, tc.expected.expectedResponse, recorder.Body.String())
+		})
+	}
 }
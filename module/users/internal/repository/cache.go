@@ -38,3 +38,13 @@ func (repo *CacheRepository) Set(ctx context.Context, key string, data []byte, e
 
 	return nil
 }
+
+// Delete data from cache
+func (repo *CacheRepository) Delete(ctx context.Context, key string) error {
+	err := repo.cacheClient.Delete(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "cache client error when delete")
+	}
+
+	return nil
+}
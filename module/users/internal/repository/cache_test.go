@@ -121,3 +121,46 @@ func TestCacheRepository_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		key           string
+		expectedError error
+		mockCacheCall func(cacheClient *mockscache.CacheInterface)
+	}{
+		{
+			name: "normal case - successfully delete key",
+			key:  testCacheKey,
+			mockCacheCall: func(cacheClient *mockscache.CacheInterface) {
+				cacheClient.On("Delete", ctx, testCacheKey).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when deleting key",
+			key:  testCacheKey,
+			mockCacheCall: func(cacheClient *mockscache.CacheInterface) {
+				cacheClient.On("Delete", ctx, testCacheKey).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("cache client error when delete: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		cacheClient := mockscache.NewCacheInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCacheCall(cacheClient)
+			repo := repository.NewCacheRepository(cacheClient)
+			err := repo.Delete(ctx, tc.key)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
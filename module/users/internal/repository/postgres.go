@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -10,10 +11,13 @@ import (
 	"timble/module/users/entity"
 )
 
+// USER_SCORES_INSERT_BATCH_SIZE keeps a single insert of scores under the bind parameter limit of postgres
+const USER_SCORES_INSERT_BATCH_SIZE = 1000
+
 const (
 	INSERT_USER_QUERY = `
       INSERT INTO users (
-        username, email, premium, hashed_password
+        username, email, premium, timezone, hashed_password
       )
       VALUES ?
     `
@@ -26,18 +30,660 @@ const (
         id = ?
     `
 
+	UPDATE_USER_TIMEZONE_QUERY = `
+      UPDATE
+        users
+      SET
+        timezone = ?
+      WHERE
+        id = ?
+    `
+
+	// reacting also makes the user active, last_active_at is only written once a minute at most to spare the users row
 	UPSERT_USER_REACTION = `
-      INSERT INTO user_reactions (
-        user_id, target_id, type
+      WITH reaction AS (
+        INSERT INTO user_reactions (
+          user_id, target_id, type
+        )
+        VALUES ?
+        ON CONFLICT(user_id, target_id)
+        DO UPDATE SET
+          type = ?,
+          previous_type = user_reactions.type
+        RETURNING
+          user_id
+      )
+      UPDATE
+        users
+      SET
+        last_active_at = NOW()
+      WHERE
+        id IN (SELECT user_id FROM reaction)
+        AND last_active_at < NOW() - INTERVAL '1 minute'
+    `
+
+	// mutual likes of a pair are serialized, so the later one always sees the earlier one and completes the match
+	LOCK_REACTION_PAIR_QUERY = `SELECT pg_advisory_xact_lock(?, ?)`
+
+	// the match is created when the target already liked the user back and neither side blocked the other, a match that
+	// already exists is returned as is and an ended one is not returned at all, so a pair never matches again.
+	// xmax is only zero on a row this statement inserted. A new match also starts the conversation of both sides, as
+	// recent as the match until a message is sent
+	UPSERT_MATCH_QUERY = `
+      WITH matched AS (
+        INSERT INTO matches (
+          first_user_id, second_user_id
+        )
+        SELECT
+          ?, ?
+        WHERE
+          EXISTS (
+            SELECT 1 FROM user_reactions r
+            WHERE r.user_id = ? AND r.target_id = ? AND r.type IN ?
+          )
+          AND NOT EXISTS (
+            SELECT 1 FROM user_blocks b
+            WHERE b.user_id IN (?, ?) AND b.blocked_id IN (?, ?)
+          )
+        ON CONFLICT(first_user_id, second_user_id)
+        DO UPDATE SET
+          first_user_id = matches.first_user_id
+        WHERE
+          matches.ended_at IS NULL
+        RETURNING
+          id, first_user_id, second_user_id, created_at, (xmax = 0) AS created
+      ), conversation AS (
+        INSERT INTO conversations (
+          match_id, user_id, other_user_id, last_message_at
+        )
+        SELECT
+          m.id, p.user_id, p.other_user_id, m.created_at
+        FROM
+          matched m
+        CROSS JOIN LATERAL
+          (VALUES (m.first_user_id, m.second_user_id), (m.second_user_id, m.first_user_id)) AS p(user_id, other_user_id)
+        WHERE
+          m.created
+        ON CONFLICT(match_id, user_id) DO NOTHING
+      )
+      SELECT
+        *
+      FROM
+        matched
+    `
+
+	// the latest activity of a match is its last message, or the match itself until a message is sent.
+	// Unread messages are counted by the conversation of the user
+	SELECT_MATCHES_QUERY = `
+      SELECT
+        m.id,
+        m.created_at,
+        u.id AS user_id,
+        u.username AS user_username,
+        COALESCE(u.bio, '') AS user_bio,
+        COALESCE(u.photo_url, '') AS user_photo_url,
+        u.last_active_at,
+        COALESCE(c.last_message_at, m.created_at) AS last_activity_at,
+        COALESCE(c.unread, 0) AS unread_count
+      FROM
+        matches m
+        JOIN users u ON u.id = CASE WHEN m.first_user_id = ? THEN m.second_user_id ELSE m.first_user_id END
+        LEFT JOIN conversations c ON c.match_id = m.id AND c.user_id = ?
+      WHERE
+        (m.first_user_id = ? OR m.second_user_id = ?)
+        AND m.ended_at IS NULL
+        AND u.hidden_at IS NULL
+    `
+
+	END_MATCH_QUERY = `
+      UPDATE
+        matches
+      SET
+        ended_at = NOW(),
+        ended_by = ?,
+        end_reason = ?
+      WHERE
+        id = ?
+        AND (first_user_id = ? OR second_user_id = ?)
+        AND ended_at IS NULL
+      RETURNING
+        id, first_user_id, second_user_id, created_at
+    `
+
+	// only a match made while the undone like stood is ended, one the pair made earlier is left as it is
+	END_UNDONE_MATCH_QUERY = `
+      UPDATE
+        matches
+      SET
+        ended_at = NOW(),
+        ended_by = ?,
+        end_reason = ?
+      WHERE
+        first_user_id = ? AND second_user_id = ?
+        AND created_at >= ?
+        AND ended_at IS NULL
+      RETURNING
+        id, first_user_id, second_user_id, created_at
+    `
+
+	SELECT_USER_REACTION_QUERY = `
+      SELECT
+        user_id, target_id, type, previous_type, created_at, updated_at
+      FROM
+        user_reactions
+      WHERE
+        user_id = ? AND target_id = ?
+    `
+
+	// only the latest reaction can be undone, whether it still can is up to entity.UserReaction.Undoable
+	SELECT_LAST_REACTION_QUERY = `
+      SELECT
+        user_id, target_id, type, previous_type, created_at, updated_at
+      FROM
+        user_reactions
+      WHERE
+        user_id = ?
+      ORDER BY
+        updated_at DESC, target_id DESC
+      LIMIT 1
+    `
+
+	DELETE_USER_REACTION_QUERY = `
+      DELETE FROM
+        user_reactions
+      WHERE
+        user_id = ? AND target_id = ?
+    `
+
+	RESTORE_USER_REACTION_QUERY = `
+      UPDATE
+        user_reactions
+      SET
+        type = previous_type,
+        previous_type = NULL
+      WHERE
+        user_id = ? AND target_id = ?
+    `
+
+	SELECT_CANDIDATE_COLUMNS = `
+      SELECT
+        u.id,
+        u.username,
+        COALESCE(u.bio, '') AS bio,
+        COALESCE(u.photo_url, '') AS photo_url,
+        u.latitude,
+        u.longitude,
+        COALESCE(s.elo_score, 1500) AS elo_score,
+        u.last_active_at
+    `
+
+	SELECT_CANDIDATE_FROM = `
+      FROM
+        users u
+        LEFT JOIN user_scores s ON s.user_id = u.id
+    `
+
+	SELECT_CANDIDATE_BY_ID_QUERY = SELECT_CANDIDATE_COLUMNS + `,
+        u.premium,
+        u.hidden_at IS NOT NULL AS hidden
+    ` + SELECT_CANDIDATE_FROM + `
+      WHERE
+        u.id = ?
+    `
+
+	// the pool is made of the best scored profiles, the ranker then weighs in distance and activity
+	SELECT_CANDIDATES_QUERY = SELECT_CANDIDATE_COLUMNS + `,
+        EXISTS (
+          SELECT 1 FROM user_reactions sl
+          WHERE sl.user_id = u.id AND sl.target_id = ? AND sl.type = ?
+        ) AS super_liked,
+        EXISTS (
+          SELECT 1 FROM user_boosts b
+          WHERE b.user_id = u.id AND b.ends_at > NOW()
+        ) AS boosted,
+        COALESCE((
+          SELECT b.id FROM user_boosts b
+          WHERE b.user_id = u.id AND b.ends_at > NOW()
+          ORDER BY b.ends_at DESC
+          LIMIT 1
+        ), 0) AS boost_id
+    ` + SELECT_CANDIDATE_FROM + `
+      WHERE
+        u.id <> ?
+        AND u.hidden_at IS NULL
+        AND NOT EXISTS (
+          SELECT 1 FROM user_reactions r
+          WHERE r.user_id = ? AND r.target_id = u.id AND r.type <> ?
+        )
+        AND NOT EXISTS (
+          SELECT 1 FROM matches m
+          WHERE m.ended_at IS NOT NULL
+            AND ((m.first_user_id = u.id AND m.second_user_id = ?) OR (m.first_user_id = ? AND m.second_user_id = u.id))
+        )
+        AND NOT EXISTS (
+          SELECT 1 FROM user_blocks b
+          WHERE (b.user_id = ? AND b.blocked_id = u.id) OR (b.user_id = u.id AND b.blocked_id = ?)
+        )
+      ORDER BY
+        super_liked DESC,
+        boosted DESC,
+        elo_score DESC,
+        last_active_at DESC
+      LIMIT ?
+    `
+
+	// profiles passed longer than the cooldown ago, the oldest passes first
+	SELECT_PASSED_CANDIDATES_QUERY = SELECT_CANDIDATE_COLUMNS + `,
+        EXISTS (
+          SELECT 1 FROM user_boosts b
+          WHERE b.user_id = u.id AND b.ends_at > NOW()
+        ) AS boosted,
+        COALESCE((
+          SELECT b.id FROM user_boosts b
+          WHERE b.user_id = u.id AND b.ends_at > NOW()
+          ORDER BY b.ends_at DESC
+          LIMIT 1
+        ), 0) AS boost_id
+    ` + SELECT_CANDIDATE_FROM + `
+        JOIN user_reactions p ON p.target_id = u.id
+      WHERE
+        p.user_id = ?
+        AND p.type = ?
+        AND u.hidden_at IS NULL
+        AND p.updated_at < NOW() - make_interval(secs => ?)
+        AND NOT EXISTS (
+          SELECT 1 FROM matches m
+          WHERE m.ended_at IS NOT NULL
+            AND ((m.first_user_id = u.id AND m.second_user_id = ?) OR (m.first_user_id = ? AND m.second_user_id = u.id))
+        )
+        AND NOT EXISTS (
+          SELECT 1 FROM user_blocks b
+          WHERE (b.user_id = ? AND b.blocked_id = u.id) OR (b.user_id = u.id AND b.blocked_id = ?)
+        )
+      ORDER BY
+        p.updated_at ASC
+      LIMIT ?
+    `
+
+	SELECT_ACTIVE_BOOST_QUERY = `
+      SELECT
+        id, user_id, started_at, ends_at, impressions, likes
+      FROM
+        user_boosts
+      WHERE
+        user_id = ? AND ends_at > NOW()
+      ORDER BY
+        ends_at DESC
+      LIMIT 1
+    `
+
+	// spending a boost and starting it happen in one statement, nothing is spent while a boost is still active
+	ACTIVATE_BOOST_QUERY = `
+      WITH spent AS (
+        UPDATE
+          users
+        SET
+          boost_balance = boost_balance - 1
+        WHERE
+          id = ?
+          AND boost_balance > 0
+          AND NOT EXISTS (
+            SELECT 1 FROM user_boosts b
+            WHERE b.user_id = users.id AND b.ends_at > NOW()
+          )
+        RETURNING
+          id
+      )
+      INSERT INTO user_boosts (
+        user_id, ends_at
+      )
+      SELECT
+        id, NOW() + make_interval(secs => ?)
+      FROM
+        spent
+      RETURNING
+        id, user_id, started_at, ends_at, impressions, likes
+    `
+
+	ADD_BOOST_BALANCE_QUERY = `
+      UPDATE
+        users
+      SET
+        boost_balance = boost_balance + ?
+      WHERE
+        id = ?
+    `
+
+	INCREMENT_BOOST_IMPRESSIONS_QUERY = `
+      UPDATE
+        user_boosts
+      SET
+        impressions = impressions + 1
+      WHERE
+        id IN ? AND ends_at > NOW()
+    `
+
+	INCREMENT_BOOST_LIKES_QUERY = `
+      UPDATE
+        user_boosts
+      SET
+        likes = likes + 1
+      WHERE
+        user_id = ? AND ends_at > NOW()
+      RETURNING
+        id
+    `
+
+	// reactions sharing a timestamp are told apart by their pair, so a page never skips one
+	SELECT_REACTIONS_AFTER_QUERY = `
+      SELECT
+        user_id, target_id, type, created_at, updated_at
+      FROM
+        user_reactions
+      WHERE
+        (updated_at, user_id, target_id) > (?, ?, ?)
+        AND updated_at < NOW() - make_interval(secs => ?)
+      ORDER BY
+        updated_at, user_id, target_id
+      LIMIT ?
+    `
+
+	SELECT_REACTION_HISTORY_QUERY = `
+      SELECT
+        r.type,
+        r.created_at,
+        r.updated_at,
+        u.id AS target_id,
+        u.username AS target_username,
+        COALESCE(u.bio, '') AS target_bio,
+        COALESCE(u.photo_url, '') AS target_photo_url
+      FROM
+        user_reactions r
+        JOIN users u ON u.id = r.target_id
+      WHERE
+        r.user_id = ?
+    `
+
+	RECEIVED_LIKES_CONDITION = `
+      FROM
+        user_reactions r
+        JOIN users u ON u.id = r.user_id
+      WHERE
+        r.target_id = ?
+        AND r.type IN ?
+        AND u.hidden_at IS NULL
+        AND NOT EXISTS (
+          SELECT 1 FROM user_reactions o
+          WHERE o.user_id = r.target_id AND o.target_id = r.user_id AND o.type <> ?
+        )
+        AND NOT EXISTS (
+          SELECT 1 FROM user_blocks b
+          WHERE (b.user_id = r.target_id AND b.blocked_id = r.user_id) OR (b.user_id = r.user_id AND b.blocked_id = r.target_id)
+        )
+    `
+
+	SELECT_RECEIVED_LIKES_QUERY = `
+      SELECT
+        r.created_at,
+        u.id AS user_id,
+        u.username AS user_username,
+        COALESCE(u.bio, '') AS user_bio,
+        COALESCE(u.photo_url, '') AS user_photo_url
+    ` + RECEIVED_LIKES_CONDITION
+
+	COUNT_RECEIVED_LIKES_QUERY = `
+      SELECT
+        COUNT(*)
+    ` + RECEIVED_LIKES_CONDITION
+
+	INSERT_USER_BLOCK_QUERY = `
+      INSERT INTO user_blocks (
+        user_id, blocked_id
+      )
+      VALUES (?, ?)
+      ON CONFLICT(user_id, blocked_id)
+      DO NOTHING
+    `
+
+	// a block ends the active match of the pair, whichever side made it
+	END_BLOCKED_MATCH_QUERY = `
+      UPDATE
+        matches
+      SET
+        ended_at = NOW(),
+        ended_by = ?,
+        end_reason = ?
+      WHERE
+        first_user_id = ? AND second_user_id = ?
+        AND ended_at IS NULL
+      RETURNING
+        id, first_user_id, second_user_id, created_at
+    `
+
+	DELETE_USER_BLOCK_QUERY = `
+      DELETE FROM
+        user_blocks
+      WHERE
+        user_id = ? AND blocked_id = ?
+      RETURNING
+        blocked_id
+    `
+
+	// the users hidden from the given one, blocked by them or blocking them
+	SELECT_BLOCKED_USER_IDS_QUERY = `
+      SELECT blocked_id FROM user_blocks WHERE user_id = ?
+      UNION
+      SELECT user_id FROM user_blocks WHERE blocked_id = ?
+    `
+
+	SELECT_BLOCKS_QUERY = `
+      SELECT
+        b.created_at,
+        u.id AS user_id,
+        u.username AS user_username,
+        COALESCE(u.bio, '') AS user_bio,
+        COALESCE(u.photo_url, '') AS user_photo_url
+      FROM
+        user_blocks b
+        JOIN users u ON u.id = b.blocked_id
+      WHERE
+        b.user_id = ?
+    `
+
+	// taken before a report changes whether the user is hidden, so concurrent reports are counted one after the other
+	LOCK_REPORTED_USER_QUERY = `
+      SELECT
+        id
+      FROM
+        users
+      WHERE
+        id = ?
+      FOR UPDATE
+    `
+
+	// closing a report locks its reported user first like a new report does, so the two never wait on each other
+	LOCK_REPORT_USER_QUERY = `
+      SELECT
+        id
+      FROM
+        users
+      WHERE
+        id = (SELECT reported_id FROM reports WHERE id = ?)
+      FOR UPDATE
+    `
+
+	REPORT_COLUMNS = `
+        r.id, r.reporter_id, r.reported_id, r.reason, r.details, r.status,
+        r.claimed_by, r.claimed_at, r.resolved_by, r.resolved_at, r.note, r.created_at
+    `
+
+	// a reporter with an open report of the same user gets nothing inserted, see SELECT_OPEN_REPORT_QUERY
+	INSERT_REPORT_QUERY = `
+      INSERT INTO reports AS r (
+        reporter_id, reported_id, reason, details
+      )
+      VALUES (?, ?, ?, ?)
+      ON CONFLICT(reporter_id, reported_id) WHERE status IN ('pending', 'claimed')
+      DO NOTHING
+      RETURNING
+    ` + REPORT_COLUMNS
+
+	SELECT_OPEN_REPORT_QUERY = `
+      SELECT
+    ` + REPORT_COLUMNS + `
+      FROM
+        reports r
+      WHERE
+        r.reporter_id = ? AND r.reported_id = ?
+        AND r.status IN ('pending', 'claimed')
+    `
+
+	// distinct users whose reports of the user were not dismissed
+	COUNT_REPORTERS_CONDITION = `
+        SELECT COUNT(DISTINCT o.reporter_id) FROM reports o
+        WHERE o.reported_id = users.id AND o.status <> 'dismissed'
+    `
+
+	HIDE_REPORTED_USER_QUERY = `
+      UPDATE
+        users
+      SET
+        hidden_at = NOW()
+      WHERE
+        id = ?
+        AND hidden_at IS NULL
+        AND (` + COUNT_REPORTERS_CONDITION + `) >= ?
+    `
+
+	// a dismissed report no longer counts, the user is shown again once they are under the threshold
+	UNHIDE_REPORTED_USER_QUERY = `
+      UPDATE
+        users
+      SET
+        hidden_at = NULL
+      WHERE
+        id = ?
+        AND hidden_at IS NOT NULL
+        AND (` + COUNT_REPORTERS_CONDITION + `) < ?
+    `
+
+	SELECT_REPORT_QUEUE_QUERY = `
+      SELECT
+    ` + REPORT_COLUMNS + `,
+        (
+          SELECT COUNT(DISTINCT o.reporter_id) FROM reports o
+          WHERE o.reported_id = r.reported_id AND o.status <> 'dismissed'
+        ) AS reporters,
+        u.hidden_at IS NOT NULL AS reported_hidden
+      FROM
+        reports r
+        JOIN users u ON u.id = r.reported_id
+      WHERE
+        r.status = ?
+    `
+
+	// a pending report can be claimed, and so can a claim older than the claim TTL, which its moderator abandoned
+	CLAIM_REPORT_QUERY = `
+      UPDATE
+        reports r
+      SET
+        status = 'claimed',
+        claimed_by = ?,
+        claimed_at = NOW()
+      WHERE
+        r.id = ?
+        AND (
+          r.status = 'pending'
+          OR (r.status = 'claimed' AND (r.claimed_by = ? OR r.claimed_at < NOW() - make_interval(secs => ?)))
+        )
+      RETURNING
+    ` + REPORT_COLUMNS
+
+	// only the moderator holding the claim closes a report
+	CLOSE_REPORT_QUERY = `
+      UPDATE
+        reports r
+      SET
+        status = ?,
+        resolved_by = ?,
+        resolved_at = NOW(),
+        note = ?
+      WHERE
+        r.id = ?
+        AND r.status = 'claimed'
+        AND r.claimed_by = ?
+      RETURNING
+    ` + REPORT_COLUMNS
+
+	SELECT_SCORE_CHECKPOINT_QUERY = `
+      SELECT
+        updated_at, user_id, target_id
+      FROM
+        user_score_checkpoint
+    `
+
+	// the checkpoint only moves from the one the batch was read after, a run racing another one saves nothing
+	UPSERT_SCORE_CHECKPOINT_QUERY = `
+      INSERT INTO user_score_checkpoint (
+        updated_at, user_id, target_id
+      )
+      VALUES (?, ?, ?)
+      ON CONFLICT (id) DO UPDATE SET
+        updated_at = EXCLUDED.updated_at,
+        user_id = EXCLUDED.user_id,
+        target_id = EXCLUDED.target_id
+      WHERE
+        (user_score_checkpoint.updated_at, user_score_checkpoint.user_id, user_score_checkpoint.target_id) = (?, ?, ?)
+      RETURNING
+        user_id
+    `
+
+	SELECT_USER_SCORES_QUERY = `
+      SELECT
+        user_id, elo_score
+      FROM
+        user_scores
+      WHERE
+        user_id IN ?
+    `
+
+	UPSERT_USER_SCORES_QUERY = `
+      INSERT INTO user_scores (
+        user_id, elo_score
       )
       VALUES ?
-      ON CONFLICT(user_id, target_id)
-      DO UPDATE SET
-        type = ?
+      ON CONFLICT (user_id) DO UPDATE SET
+        elo_score = EXCLUDED.elo_score
     `
 )
 
 var (
+	// reacting again to a user moves them back to the top of the history
+	reactionHistoryKeyset = postgres.Keyset{
+		CreatedAtColumn: "r.updated_at",
+		IDColumn:        "r.target_id",
+	}
+
+	receivedLikesKeyset = postgres.Keyset{
+		CreatedAtColumn: "r.created_at",
+		IDColumn:        "r.user_id",
+	}
+
+	matchesKeyset = postgres.Keyset{
+		CreatedAtColumn: "COALESCE(c.last_message_at, m.created_at)",
+		IDColumn:        "m.id",
+	}
+
+	blocksKeyset = postgres.Keyset{
+		CreatedAtColumn: "b.created_at",
+		IDColumn:        "b.blocked_id",
+	}
+
+	reportsKeyset = postgres.Keyset{
+		CreatedAtColumn: "r.created_at",
+		IDColumn:        "r.id",
+	}
+
 	duplicateKeyErrors = map[string]string{
 		"ERROR: duplicate key value violates unique constraint \"users_email_key\" (SQLSTATE 23505)":    "email",
 		"ERROR: duplicate key value violates unique constraint \"users_username_key\" (SQLSTATE 23505)": "username",
@@ -81,6 +727,7 @@ func (repo *PostgresRepository) InsertUser(user entity.User) error {
 		user.Username,
 		user.Email,
 		user.Premium,
+		user.Timezone,
 		user.HashedPassword,
 	}
 
@@ -101,19 +748,501 @@ func (repo *PostgresRepository) UpdateUserPremium(user entity.User, value interf
 	return nil
 }
 
-func (repo *PostgresRepository) UpsertUserReaction(reaction entity.ReactionParams) error {
+func (repo *PostgresRepository) UpdateUserTimezone(params entity.UserTimezoneParams) error {
+	err := repo.PostgresClient.Exec(UPDATE_USER_TIMEZONE_QUERY, params.Timezone, params.UserID)
+	if err != nil {
+		return errors.Wrap(err, "postgres client error when update timezone to users")
+	}
+
+	return nil
+}
+
+// UpsertUserReaction saves the reaction in a transaction with the match it completes, the match is nil when there is none
+func (repo *PostgresRepository) UpsertUserReaction(reaction entity.ReactionParams) (*entity.Match, error) {
+	var match *entity.Match
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		var err error
+		match, err = upsertUserReaction(tx, reaction)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when upsert to user_reactions")
+	}
+
+	return match, nil
+}
+
+// UpsertUserReactions saves the reactions in order within one transaction, either all of them are saved or none.
+// The matches they complete follow the order of the reactions
+func (repo *PostgresRepository) UpsertUserReactions(reactions []entity.ReactionParams) ([]*entity.Match, error) {
+	matches := make([]*entity.Match, len(reactions))
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		for i, reaction := range reactions {
+			match, err := upsertUserReaction(tx, reaction)
+			if err != nil {
+				return err
+			}
+			matches[i] = match
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when batch upsert to user_reactions")
+	}
+
+	return matches, nil
+}
+
+func upsertUserReaction(client postgres.PostgresInterface, reaction entity.ReactionParams) (*entity.Match, error) {
+	firstUserID, secondUserID := entity.MatchPair(reaction.UserID, reaction.TargetID)
+	err := client.Exec(LOCK_REACTION_PAIR_QUERY, firstUserID, secondUserID)
+	if err != nil {
+		return nil, err
+	}
+
 	param := []interface{}{
 		reaction.UserID,
 		reaction.TargetID,
 		reaction.Type,
 	}
+	err = client.Exec(UPSERT_USER_REACTION, param, reaction.Type)
+	if err != nil {
+		return nil, err
+	}
 
-	err := repo.PostgresClient.Exec(UPSERT_USER_REACTION, param, reaction.Type)
+	if !reaction.Type.CountsTowardMatch() {
+		return nil, nil
+	}
+
+	result := []entity.Match{}
+	err = client.Select(&result, UPSERT_MATCH_QUERY, firstUserID, secondUserID, reaction.TargetID, reaction.UserID, entity.MatchReactionTypes(), firstUserID, secondUserID, firstUserID, secondUserID)
 	if err != nil {
-		return errors.Wrap(err, "postgres client error when upsert to user_reactions")
+		return nil, err
 	}
 
-	return nil
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+func (repo *PostgresRepository) GetUserReaction(userID, targetID uint) (*entity.UserReaction, error) {
+	result := []entity.UserReaction{}
+	err := repo.PostgresClient.Select(&result, SELECT_USER_REACTION_QUERY, userID, targetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when get user reaction")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+func (repo *PostgresRepository) GetLastReaction(userID uint) (*entity.UserReaction, error) {
+	result := []entity.UserReaction{}
+	err := repo.PostgresClient.Select(&result, SELECT_LAST_REACTION_QUERY, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when get last reaction")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// RevertUserReaction deletes a newly inserted reaction, or restores the type it replaced. It is done in a transaction
+// with the end of the match the reaction made, the match is nil when there is none
+func (repo *PostgresRepository) RevertUserReaction(reaction entity.UserReaction) (*entity.Match, error) {
+	query := RESTORE_USER_REACTION_QUERY
+	if reaction.PreviousType == nil {
+		query = DELETE_USER_REACTION_QUERY
+	}
+
+	var match *entity.Match
+	firstUserID, secondUserID := entity.MatchPair(reaction.UserID, reaction.TargetID)
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		// taken like a reaction does, so the other side can not complete a match while the like is being undone
+		err := tx.Exec(LOCK_REACTION_PAIR_QUERY, firstUserID, secondUserID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(query, reaction.UserID, reaction.TargetID)
+		if err != nil {
+			return err
+		}
+
+		if !reaction.UndoEndsMatch() {
+			return nil
+		}
+
+		result := []entity.Match{}
+		err = tx.Select(&result, END_UNDONE_MATCH_QUERY, reaction.UserID, entity.MATCH_END_REASON_UNDONE, firstUserID, secondUserID, reaction.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if len(result) > 0 {
+			match = &result[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when revert user_reactions")
+	}
+
+	return match, nil
+}
+
+func (repo *PostgresRepository) GetCandidateByID(id uint) (*entity.Candidate, error) {
+	result := []entity.Candidate{}
+	err := repo.PostgresClient.Select(&result, SELECT_CANDIDATE_BY_ID_QUERY, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when get candidate by ID")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+func (repo *PostgresRepository) GetCandidates(userID uint, limit int) ([]entity.Candidate, error) {
+	result := []entity.Candidate{}
+	err := repo.PostgresClient.Select(&result, SELECT_CANDIDATES_QUERY, userID, entity.REACTION_TYPE_SUPER_LIKE, userID, userID, entity.REACTION_TYPE_UNDECIDED, userID, userID, userID, userID, limit)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get candidates")
+	}
+
+	return result, nil
+}
+
+// GetPassedCandidates lists the profiles the user passed on at least cooldown ago, so they can be shown again
+func (repo *PostgresRepository) GetPassedCandidates(userID uint, cooldown time.Duration, limit int) ([]entity.Candidate, error) {
+	result := []entity.Candidate{}
+	err := repo.PostgresClient.Select(&result, SELECT_PASSED_CANDIDATES_QUERY, userID, entity.REACTION_TYPE_PASS, cooldown.Seconds(), userID, userID, userID, userID, limit)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get passed candidates")
+	}
+
+	return result, nil
+}
+
+// GetReactionsAfter pages the reactions in the order they were last made, the ones made within settle are left
+// for the next run since a reaction committed late could carry an older timestamp than one already read
+func (repo *PostgresRepository) GetReactionsAfter(after entity.UserReaction, settle time.Duration, limit int) ([]entity.UserReaction, error) {
+	result := []entity.UserReaction{}
+	err := repo.PostgresClient.Select(&result, SELECT_REACTIONS_AFTER_QUERY, after.UpdatedAt, after.UserID, after.TargetID, settle.Seconds(), limit)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get reactions after")
+	}
+
+	return result, nil
+}
+
+// GetScoreCheckpoint returns the last reaction applied to the scores, a zero reaction when none was applied yet
+func (repo *PostgresRepository) GetScoreCheckpoint() (entity.UserReaction, error) {
+	result := []entity.UserReaction{}
+	err := repo.PostgresClient.Select(&result, SELECT_SCORE_CHECKPOINT_QUERY)
+	if err != nil {
+		return entity.UserReaction{}, errors.Wrap(err, "postgres client error when get score checkpoint")
+	}
+	if len(result) == 0 {
+		return entity.UserReaction{}, nil
+	}
+
+	return result[0], nil
+}
+
+func (repo *PostgresRepository) GetUserScores(userIDs []uint) ([]entity.UserScore, error) {
+	result := []entity.UserScore{}
+	err := repo.PostgresClient.Select(&result, SELECT_USER_SCORES_QUERY, userIDs)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get user_scores")
+	}
+
+	return result, nil
+}
+
+// SaveUserScores stores the scores together with the checkpoint moved from after to last, so a batch is either
+// applied once or not at all. It reports false when another run already moved the checkpoint from after
+func (repo *PostgresRepository) SaveUserScores(scores []entity.UserScore, after entity.UserReaction, last entity.UserReaction) (bool, error) {
+	saved := false
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		result := []uint{}
+		err := tx.Select(&result, UPSERT_SCORE_CHECKPOINT_QUERY, last.UpdatedAt, last.UserID, last.TargetID, after.UpdatedAt, after.UserID, after.TargetID)
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			return nil
+		}
+
+		for start := 0; start < len(scores); start += USER_SCORES_INSERT_BATCH_SIZE {
+			end := min(start+USER_SCORES_INSERT_BATCH_SIZE, len(scores))
+			param := [][]interface{}{}
+			for _, score := range scores[start:end] {
+				param = append(param, []interface{}{score.UserID, score.EloScore})
+			}
+
+			err = tx.Exec(UPSERT_USER_SCORES_QUERY, param)
+			if err != nil {
+				return err
+			}
+		}
+		saved = true
+		return nil
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "postgres client error when save user_scores")
+	}
+
+	return saved, nil
+}
+
+// GetReactionHistory lists the reactions made by a user, paged off the user_reaction_user_id_updated_at_target_id index
+func (repo *PostgresRepository) GetReactionHistory(params entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, error) {
+	result := []entity.ReactionHistoryItem{}
+	query := SELECT_REACTION_HISTORY_QUERY
+	args := []interface{}{params.UserID}
+	if params.Type != nil {
+		query += " AND r.type = ?"
+		args = append(args, *params.Type)
+	}
+
+	query, args = reactionHistoryKeyset.Paginate(query, args, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get reaction history")
+	}
+
+	return result, nil
+}
+
+// GetReceivedLikes lists the users who liked the given user and are still waiting for a reaction back,
+// served by the user_reaction_target_id_type index
+func (repo *PostgresRepository) GetReceivedLikes(params entity.ReceivedLikesParams) ([]entity.ReceivedLike, error) {
+	result := []entity.ReceivedLike{}
+	args := []interface{}{params.UserID, entity.MatchReactionTypes(), entity.REACTION_TYPE_UNDECIDED}
+	query, args := receivedLikesKeyset.Paginate(SELECT_RECEIVED_LIKES_QUERY, args, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get received likes")
+	}
+
+	return result, nil
+}
+
+func (repo *PostgresRepository) CountReceivedLikes(userID uint) (int64, error) {
+	var result int64
+	err := repo.PostgresClient.Select(&result, COUNT_RECEIVED_LIKES_QUERY, userID, entity.MatchReactionTypes(), entity.REACTION_TYPE_UNDECIDED)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when count received likes")
+	}
+
+	return result, nil
+}
+
+// GetMatches lists the matches of the user that neither side ended, the most recent first
+func (repo *PostgresRepository) GetMatches(params entity.MatchListParams) ([]entity.MatchListItem, error) {
+	result := []entity.MatchListItem{}
+	args := []interface{}{params.UserID, params.UserID, params.UserID, params.UserID}
+	query, args := matchesKeyset.Paginate(SELECT_MATCHES_QUERY, args, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get matches")
+	}
+
+	return result, nil
+}
+
+// EndMatch ends an active match of the user, it returns nil when the user has no such match
+func (repo *PostgresRepository) EndMatch(params entity.UnmatchParams) (*entity.Match, error) {
+	result := []entity.Match{}
+	err := repo.PostgresClient.Select(&result, END_MATCH_QUERY, params.UserID, params.Reason, params.MatchID, params.UserID, params.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when end match")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// InsertBlock saves the block in a transaction with the end of the match it breaks, the match is nil when there is none
+func (repo *PostgresRepository) InsertBlock(params entity.BlockParams) (*entity.Match, error) {
+	var match *entity.Match
+	firstUserID, secondUserID := entity.MatchPair(params.UserID, params.TargetID)
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		// taken like a reaction does, so a match can not be made while the pair is being blocked
+		err := tx.Exec(LOCK_REACTION_PAIR_QUERY, firstUserID, secondUserID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(INSERT_USER_BLOCK_QUERY, params.UserID, params.TargetID)
+		if err != nil {
+			return err
+		}
+
+		result := []entity.Match{}
+		err = tx.Select(&result, END_BLOCKED_MATCH_QUERY, params.UserID, entity.MATCH_END_REASON_BLOCKED, firstUserID, secondUserID)
+		if err != nil {
+			return err
+		}
+
+		if len(result) > 0 {
+			match = &result[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when insert to user_blocks")
+	}
+
+	return match, nil
+}
+
+// DeleteBlock lifts a block of the user, it reports whether there was one
+func (repo *PostgresRepository) DeleteBlock(params entity.BlockParams) (bool, error) {
+	result := []uint{}
+	err := repo.PostgresClient.Select(&result, DELETE_USER_BLOCK_QUERY, params.UserID, params.TargetID)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres client error when delete from user_blocks")
+	}
+
+	return len(result) > 0, nil
+}
+
+func (repo *PostgresRepository) GetBlockedUserIDs(userID uint) ([]uint, error) {
+	result := []uint{}
+	err := repo.PostgresClient.Select(&result, SELECT_BLOCKED_USER_IDS_QUERY, userID, userID)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get blocked user IDs")
+	}
+
+	return result, nil
+}
+
+// GetBlocks lists the users blocked by the user, the latest block first
+func (repo *PostgresRepository) GetBlocks(params entity.BlockListParams) ([]entity.BlockListItem, error) {
+	result := []entity.BlockListItem{}
+	query, args := blocksKeyset.Paginate(SELECT_BLOCKS_QUERY, []interface{}{params.UserID}, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get blocks")
+	}
+
+	return result, nil
+}
+
+// InsertReport saves the report and hides the reported user once threshold distinct users reported them,
+// an open report the reporter already has is returned instead of a new one
+func (repo *PostgresRepository) InsertReport(params entity.ReportParams, threshold int) (*entity.Report, error) {
+	var report *entity.Report
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		err := tx.Exec(LOCK_REPORTED_USER_QUERY, params.ReportedID)
+		if err != nil {
+			return err
+		}
+
+		result := []entity.Report{}
+		err = tx.Select(&result, INSERT_REPORT_QUERY, params.ReporterID, params.ReportedID, params.Reason, params.Details)
+		if err != nil {
+			return err
+		}
+
+		if len(result) == 0 {
+			err = tx.Select(&result, SELECT_OPEN_REPORT_QUERY, params.ReporterID, params.ReportedID)
+			if err != nil {
+				return err
+			}
+			if len(result) > 0 {
+				report = &result[0]
+			}
+			return nil
+		}
+
+		report = &result[0]
+		report.Created = true
+		if threshold <= 0 {
+			return nil
+		}
+		return tx.Exec(HIDE_REPORTED_USER_QUERY, params.ReportedID, threshold)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when insert to reports")
+	}
+
+	return report, nil
+}
+
+// GetReports lists the reports with the status, the latest report first
+func (repo *PostgresRepository) GetReports(params entity.ReportQueueParams) ([]entity.ReportQueueItem, error) {
+	result := []entity.ReportQueueItem{}
+	query, args := reportsKeyset.Paginate(SELECT_REPORT_QUEUE_QUERY, []interface{}{params.Status}, params.Page)
+	err := repo.PostgresClient.Select(&result, query, args...)
+	if err != nil {
+		return result, errors.Wrap(err, "postgres client error when get reports")
+	}
+
+	return result, nil
+}
+
+// ClaimReport assigns the report to the moderator, it returns nil when the report can not be claimed
+func (repo *PostgresRepository) ClaimReport(params entity.ReportActionParams, claimTTL time.Duration) (*entity.Report, error) {
+	result := []entity.Report{}
+	err := repo.PostgresClient.Select(&result, CLAIM_REPORT_QUERY, params.ModeratorID, params.ReportID, params.ModeratorID, claimTTL.Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when claim report")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// CloseReport resolves or dismisses a report claimed by the moderator, it returns nil when the moderator does not hold it.
+// A dismissed report shows the reported user again when the remaining reporters are under threshold
+func (repo *PostgresRepository) CloseReport(params entity.ReportActionParams, status string, threshold int) (*entity.Report, error) {
+	var report *entity.Report
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		err := tx.Exec(LOCK_REPORT_USER_QUERY, params.ReportID)
+		if err != nil {
+			return err
+		}
+
+		result := []entity.Report{}
+		err = tx.Select(&result, CLOSE_REPORT_QUERY, status, params.ModeratorID, params.Note, params.ReportID, params.ModeratorID)
+		if err != nil {
+			return err
+		}
+
+		if len(result) == 0 {
+			return nil
+		}
+
+		report = &result[0]
+		if status != entity.REPORT_STATUS_DISMISSED || threshold <= 0 {
+			return nil
+		}
+		return tx.Exec(UNHIDE_REPORTED_USER_QUERY, report.ReportedID, threshold)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when close report")
+	}
+
+	return report, nil
 }
 
 func (repo *PostgresRepository) wrapInsertError(err error) error {
@@ -124,3 +1253,70 @@ func (repo *PostgresRepository) wrapInsertError(err error) error {
 
 	return errors.WithStack(errors.Wrap(err, "postgres client error when insert to users"))
 }
+
+func (repo *PostgresRepository) GetActiveBoost(userID uint) (*entity.Boost, error) {
+	result := []entity.Boost{}
+	err := repo.PostgresClient.Select(&result, SELECT_ACTIVE_BOOST_QUERY, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when get active boost")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// ActivateBoost spends one boost of the user's balance, it returns nil when there is no balance left or a boost is still active
+func (repo *PostgresRepository) ActivateBoost(userID uint, duration time.Duration) (*entity.Boost, error) {
+	result := []entity.Boost{}
+	err := repo.PostgresClient.Select(&result, ACTIVATE_BOOST_QUERY, userID, duration.Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres client error when activate boost")
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return &result[0], nil
+}
+
+// GrantPremium makes the user premium and adds the boosts granted with it in one transaction, so neither is kept
+// without the other
+func (repo *PostgresRepository) GrantPremium(userID uint, boosts int) error {
+	err := repo.PostgresClient.Transaction(func(tx postgres.PostgresInterface) error {
+		err := tx.Exec(UPDATE_USER_PREMIUM_QUERY, true, userID)
+		if err != nil || boosts <= 0 {
+			return err
+		}
+		return tx.Exec(ADD_BOOST_BALANCE_QUERY, boosts, userID)
+	})
+	if err != nil {
+		return errors.Wrap(err, "postgres client error when grant premium")
+	}
+
+	return nil
+}
+
+// IncrementBoostImpressions counts one more impression on each of the given boosts that is still active
+func (repo *PostgresRepository) IncrementBoostImpressions(boostIDs []uint) error {
+	err := repo.PostgresClient.Exec(INCREMENT_BOOST_IMPRESSIONS_QUERY, boostIDs)
+	if err != nil {
+		return errors.Wrap(err, "postgres client error when increment boost impressions")
+	}
+
+	return nil
+}
+
+// IncrementBoostLikes credits a like to the user's active boost, it reports whether the user was boosted
+func (repo *PostgresRepository) IncrementBoostLikes(userID uint) (bool, error) {
+	result := []uint{}
+	err := repo.PostgresClient.Select(&result, INCREMENT_BOOST_LIKES_QUERY, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres client error when increment boost likes")
+	}
+
+	return len(result) > 0, nil
+}
@@ -3,12 +3,14 @@ package repository_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"timble/internal/connection/postgres"
+	"timble/internal/utils"
 	mockspostgres "timble/mocks/internal_/connection/postgres"
 	"timble/module/users/entity"
 	"timble/module/users/internal/repository"
@@ -20,6 +22,7 @@ var (
 		Email:          "test@email.com",
 		Username:       "testuser",
 		Premium:        true,
+		Timezone:       "Asia/Jakarta",
 		HashedPassword: "testhashespassword",
 	}
 )
@@ -52,6 +55,7 @@ func TestPostgresRepository_GetUserByID(t *testing.T) {
 					arg.Email = testUser.Email
 					arg.Username = testUser.Username
 					arg.Premium = testUser.Premium
+					arg.Timezone = testUser.Timezone
 					arg.HashedPassword = testUser.HashedPassword
 				}).Return(nil)
 			},
@@ -106,6 +110,7 @@ func TestPostgresRepository_GetUserByUsername(t *testing.T) {
 					arg.Email = testUser.Email
 					arg.Username = testUser.Username
 					arg.Premium = testUser.Premium
+					arg.Timezone = testUser.Timezone
 					arg.HashedPassword = testUser.HashedPassword
 				}).Return(nil)
 			},
@@ -145,6 +150,7 @@ func TestPostgresRepository_InsertUser(t *testing.T) {
 		testUser.Username,
 		testUser.Email,
 		testUser.Premium,
+		testUser.Timezone,
 		testUser.HashedPassword,
 	}
 	tests := []struct {
@@ -238,38 +244,451 @@ func TestPostgresRepository_UpdateUserPremium(t *testing.T) {
 	}
 }
 
+func TestPostgresRepository_UpdateUserTimezone(t *testing.T) {
+	params := entity.UserTimezoneParams{UserID: testUser.ID, Timezone: "Europe/Berlin"}
+	tests := []struct {
+		name             string
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - successfully update timezone",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Exec", repository.UPDATE_USER_TIMEZONE_QUERY, "Europe/Berlin", testUser.ID).Return(nil)
+			},
+		},
+		{
+			name: "error case - unexpected error during update",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Exec", repository.UPDATE_USER_TIMEZONE_QUERY, "Europe/Berlin", testUser.ID).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when update timezone to users: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			err := repo.UpdateUserTimezone(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
 func TestPostgresRepository_UpsertUserReaction(t *testing.T) {
 	reaction := entity.ReactionParams{
-		UserID:   testUser.ID,
+		UserID:   uint(3),
 		TargetID: uint(2),
-		Type:     1,
+		Type:     entity.REACTION_TYPE_PASS,
 	}
-	postgreParams := []interface{}{
-		reaction.UserID,
-		reaction.TargetID,
-		reaction.Type,
+	like := entity.ReactionParams{
+		UserID:   uint(3),
+		TargetID: uint(2),
+		Type:     entity.REACTION_TYPE_LIKE,
+	}
+	match := entity.Match{
+		ID:           7,
+		FirstUserID:  2,
+		SecondUserID: 3,
+		CreatedAt:    time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+		Created:      true,
+	}
+	existingMatch := match
+	existingMatch.Created = false
+	postgreParams := func(reaction entity.ReactionParams) []interface{} {
+		return []interface{}{
+			reaction.UserID,
+			reaction.TargetID,
+			reaction.Type,
+		}
 	}
 	tests := []struct {
 		name             string
 		args             entity.ReactionParams
+		expectedResult   *entity.Match
 		expectedError    error
-		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
 	}{
 		{
-			name: "normal case - successfully upsert user reaction",
+			name: "normal case - successfully upsert a pass without looking for a match",
 			args: reaction,
-			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
-				postgresClient.On("Exec", repository.UPSERT_USER_REACTION, postgreParams, reaction.Type).Return(nil)
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_REACTION, postgreParams(reaction), reaction.Type).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "normal case - like without the reverse like makes no match",
+			args: like,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_REACTION, postgreParams(like), like.Type).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.UPSERT_MATCH_QUERY, uint(2), uint(3), like.TargetID, like.UserID, entity.MatchReactionTypes(), uint(2), uint(3), uint(2), uint(3)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - like completing a mutual like creates the match",
+			args:           like,
+			expectedResult: &match,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_REACTION, postgreParams(like), like.Type).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.UPSERT_MATCH_QUERY, uint(2), uint(3), like.TargetID, like.UserID, entity.MatchReactionTypes(), uint(2), uint(3), uint(2), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Match)
+					*arg = append(*arg, match)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - like on an existing match returns it without creating another",
+			args:           like,
+			expectedResult: &existingMatch,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_REACTION, postgreParams(like), like.Type).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.UPSERT_MATCH_QUERY, uint(2), uint(3), like.TargetID, like.UserID, entity.MatchReactionTypes(), uint(2), uint(3), uint(2), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Match)
+					*arg = append(*arg, existingMatch)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "error case - unexpected error when locking the pair",
+			args: reaction,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
 			},
+			expectedError: errors.New("postgres client error when upsert to user_reactions: timeout"),
 		},
 		{
 			name: "error case - unexpected error during upsert",
 			args: reaction,
-			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
-				postgresClient.On("Exec", repository.UPSERT_USER_REACTION, postgreParams, reaction.Type).Return(errors.New("timeout"))
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_REACTION, postgreParams(reaction), reaction.Type).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
 			},
 			expectedError: errors.New("postgres client error when upsert to user_reactions: timeout"),
 		},
+		{
+			name: "error case - unexpected error when creating the match rolls back the reaction",
+			args: like,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_REACTION, postgreParams(like), like.Type).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.UPSERT_MATCH_QUERY, uint(2), uint(3), like.TargetID, like.UserID, entity.MatchReactionTypes(), uint(2), uint(3), uint(2), uint(3)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when upsert to user_reactions: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.UpsertUserReaction(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_UpsertUserReactions(t *testing.T) {
+	reactions := []entity.ReactionParams{
+		{UserID: testUser.ID, TargetID: uint(2), Type: entity.REACTION_TYPE_LIKE},
+		{UserID: testUser.ID, TargetID: uint(3), Type: entity.REACTION_TYPE_PASS},
+	}
+	match := entity.Match{ID: 7, FirstUserID: testUser.ID, SecondUserID: 2, Created: true}
+	tests := []struct {
+		name             string
+		args             []entity.ReactionParams
+		expectedResult   []*entity.Match
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully upsert user reactions in a transaction",
+			args:           reactions,
+			expectedResult: []*entity.Match{&match, nil},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				for _, reaction := range reactions {
+					firstUserID, secondUserID := entity.MatchPair(reaction.UserID, reaction.TargetID)
+					tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, firstUserID, secondUserID).Return(nil).Once()
+					tx.On("Exec", repository.UPSERT_USER_REACTION, []interface{}{reaction.UserID, reaction.TargetID, reaction.Type}, reaction.Type).Return(nil).Once()
+				}
+				tx.On("Select", &[]entity.Match{}, repository.UPSERT_MATCH_QUERY, testUser.ID, uint(2), uint(2), testUser.ID, entity.MatchReactionTypes(), testUser.ID, uint(2), testUser.ID, uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Match)
+					*arg = append(*arg, match)
+				}).Return(nil).Once()
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "error case - unexpected error rolls back the transaction",
+			args: reactions,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				reaction := reactions[0]
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, testUser.ID, uint(2)).Return(nil).Once()
+				tx.On("Exec", repository.UPSERT_USER_REACTION, []interface{}{reaction.UserID, reaction.TargetID, reaction.Type}, reaction.Type).Return(errors.New("timeout")).Once()
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when batch upsert to user_reactions: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.UpsertUserReactions(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetCandidateByID(t *testing.T) {
+	candidate := entity.Candidate{
+		ID:       testUser.ID,
+		Username: testUser.Username,
+		EloScore: 1500,
+	}
+	tests := []struct {
+		name             string
+		args             uint
+		expectedError    error
+		expectedResult   *entity.Candidate
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get candidate",
+			args:           testUser.ID,
+			expectedResult: &candidate,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_CANDIDATE_BY_ID_QUERY, testUser.ID).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Candidate)
+					*arg = append(*arg, candidate)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - candidate not found",
+			args: testUser.ID,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_CANDIDATE_BY_ID_QUERY, testUser.ID).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			args: testUser.ID,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_CANDIDATE_BY_ID_QUERY, testUser.ID).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get candidate by ID: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetCandidateByID(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetCandidates(t *testing.T) {
+	candidates := []entity.Candidate{
+		{ID: 2, Username: "second", EloScore: 1500},
+		{ID: 3, Username: "third", EloScore: 1600},
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   []entity.Candidate
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get candidates",
+			expectedResult: candidates,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_CANDIDATES_QUERY, testUser.ID, entity.REACTION_TYPE_SUPER_LIKE, testUser.ID, testUser.ID, entity.REACTION_TYPE_UNDECIDED, testUser.ID, testUser.ID, testUser.ID, testUser.ID, 10).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Candidate)
+					*arg = append(*arg, candidates...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: []entity.Candidate{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_CANDIDATES_QUERY, testUser.ID, entity.REACTION_TYPE_SUPER_LIKE, testUser.ID, testUser.ID, entity.REACTION_TYPE_UNDECIDED, testUser.ID, testUser.ID, testUser.ID, testUser.ID, 10).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get candidates: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetCandidates(testUser.ID, 10)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetPassedCandidates(t *testing.T) {
+	cooldown := 7 * 24 * time.Hour
+	candidates := []entity.Candidate{
+		{ID: 2, Username: "second", EloScore: 1500},
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   []entity.Candidate
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get passed candidates",
+			expectedResult: candidates,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_PASSED_CANDIDATES_QUERY, testUser.ID, entity.REACTION_TYPE_PASS, cooldown.Seconds(), testUser.ID, testUser.ID, testUser.ID, testUser.ID, 5).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Candidate)
+					*arg = append(*arg, candidates...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: []entity.Candidate{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Candidate{}, repository.SELECT_PASSED_CANDIDATES_QUERY, testUser.ID, entity.REACTION_TYPE_PASS, cooldown.Seconds(), testUser.ID, testUser.ID, testUser.ID, testUser.ID, 5).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get passed candidates: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetPassedCandidates(testUser.ID, cooldown, 5)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetReactionsAfter(t *testing.T) {
+	since, _ := time.Parse("1/2/2006", "2/2/2025")
+	after := entity.UserReaction{UserID: 1, TargetID: 2, UpdatedAt: since}
+	reactions := []entity.UserReaction{
+		{UserID: 1, TargetID: 3, Type: 2, UpdatedAt: since},
+		{UserID: 1, TargetID: 2, Type: 2, UpdatedAt: since.Add(time.Minute)},
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   []entity.UserReaction
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get reactions",
+			expectedResult: reactions,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_REACTIONS_AFTER_QUERY, since, uint(1), uint(2), float64(60), 100).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.UserReaction)
+					*arg = append(*arg, reactions...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: []entity.UserReaction{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_REACTIONS_AFTER_QUERY, since, uint(1), uint(2), float64(60), 100).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get reactions after: timeout"),
+		},
 	}
 
 	for _, tc := range tests {
@@ -278,7 +697,7 @@ func TestPostgresRepository_UpsertUserReaction(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockPostgresCall(postgresClient)
 			repo := repository.NewPostgresRepository(postgresClient)
-			err := repo.UpsertUserReaction(tc.args)
+			result, err := repo.GetReactionsAfter(after, time.Minute, 100)
 
 			if tc.expectedError != nil {
 				assert.NotNil(t, err)
@@ -286,6 +705,1622 @@ func TestPostgresRepository_UpsertUserReaction(t *testing.T) {
 			} else {
 				assert.Nil(t, err)
 			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetScoreCheckpoint(t *testing.T) {
+	since, _ := time.Parse("1/2/2006", "2/2/2025")
+	checkpoint := entity.UserReaction{UserID: 1, TargetID: 2, UpdatedAt: since}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   entity.UserReaction
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get checkpoint",
+			expectedResult: checkpoint,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_SCORE_CHECKPOINT_QUERY).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.UserReaction)
+					*arg = append(*arg, checkpoint)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "normal case - no checkpoint yet",
+			expectedResult: entity.UserReaction{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_SCORE_CHECKPOINT_QUERY).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: entity.UserReaction{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_SCORE_CHECKPOINT_QUERY).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get score checkpoint: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetScoreCheckpoint()
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetUserScores(t *testing.T) {
+	userIDs := []uint{1, 2}
+	scores := []entity.UserScore{{UserID: 1, EloScore: 1516}}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   []entity.UserScore
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get scores",
+			expectedResult: scores,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserScore{}, repository.SELECT_USER_SCORES_QUERY, userIDs).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.UserScore)
+					*arg = append(*arg, scores...)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: []entity.UserScore{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserScore{}, repository.SELECT_USER_SCORES_QUERY, userIDs).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get user_scores: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetUserScores(userIDs)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_SaveUserScores(t *testing.T) {
+	since, _ := time.Parse("1/2/2006", "2/2/2025")
+	after := entity.UserReaction{UserID: 1, TargetID: 2, UpdatedAt: since}
+	last := entity.UserReaction{UserID: 3, TargetID: 4, UpdatedAt: since.Add(time.Minute)}
+	checkpointArgs := []interface{}{
+		&[]uint{}, repository.UPSERT_SCORE_CHECKPOINT_QUERY,
+		last.UpdatedAt, uint(3), uint(4), after.UpdatedAt, uint(1), uint(2),
+	}
+	moveCheckpoint := func(args mock.Arguments) {
+		arg := args.Get(0).(*[]uint)
+		*arg = append(*arg, 3)
+	}
+	scores := []entity.UserScore{}
+	firstBatch := [][]interface{}{}
+	for id := uint(1); id <= repository.USER_SCORES_INSERT_BATCH_SIZE+1; id++ {
+		scores = append(scores, entity.UserScore{UserID: id, EloScore: 1516})
+		if id <= repository.USER_SCORES_INSERT_BATCH_SIZE {
+			firstBatch = append(firstBatch, []interface{}{id, float64(1516)})
+		}
+	}
+	secondBatch := [][]interface{}{
+		{uint(repository.USER_SCORES_INSERT_BATCH_SIZE + 1), float64(1516)},
+	}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   bool
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully save scores in batches",
+			expectedResult: true,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", checkpointArgs...).Run(moveCheckpoint).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_SCORES_QUERY, firstBatch).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_SCORES_QUERY, secondBatch).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - checkpoint moved by another run",
+			expectedResult: false,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", checkpointArgs...).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "error case - unexpected error during checkpoint",
+			expectedResult: false,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", checkpointArgs...).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when save user_scores: timeout"),
+		},
+		{
+			name:           "error case - unexpected error during upsert",
+			expectedResult: false,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Select", checkpointArgs...).Run(moveCheckpoint).Return(nil)
+				tx.On("Exec", repository.UPSERT_USER_SCORES_QUERY, firstBatch).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when save user_scores: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.SaveUserScores(scores, after, last)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetReactionHistory(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	likeType := entity.REACTION_TYPE_LIKE
+	history := []entity.ReactionHistoryItem{
+		{
+			Type:      likeType,
+			Target:    entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt: timestamp,
+			UpdatedAt: timestamp,
+		},
+	}
+	baseQuery := repository.SELECT_REACTION_HISTORY_QUERY
+	tests := []struct {
+		name             string
+		args             entity.ReactionHistoryParams
+		expectedError    error
+		expectedResult   []entity.ReactionHistoryItem
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - first page of every type",
+			args: entity.ReactionHistoryParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10},
+			},
+			expectedResult: history,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := baseQuery + " AND TRUE ORDER BY r.updated_at DESC, r.target_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReactionHistoryItem{}, query, testUser.ID, 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.ReactionHistoryItem)
+					*arg = append(*arg, history...)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - next page of likes",
+			args: entity.ReactionHistoryParams{
+				UserID: testUser.ID,
+				Type:   &likeType,
+				Page:   utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 3}},
+			},
+			expectedResult: history,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := baseQuery + " AND r.type = ? AND (r.updated_at, r.target_id) < (?, ?) ORDER BY r.updated_at DESC, r.target_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReactionHistoryItem{}, query, testUser.ID, likeType, timestamp, uint(3), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.ReactionHistoryItem)
+					*arg = append(*arg, history...)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			args: entity.ReactionHistoryParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10},
+			},
+			expectedResult: []entity.ReactionHistoryItem{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := baseQuery + " AND TRUE ORDER BY r.updated_at DESC, r.target_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReactionHistoryItem{}, query, testUser.ID, 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get reaction history: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetReactionHistory(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetReceivedLikes(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	likes := []entity.ReceivedLike{
+		{
+			User:      &entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt: &timestamp,
+		},
+	}
+	tests := []struct {
+		name             string
+		args             entity.ReceivedLikesParams
+		expectedError    error
+		expectedResult   []entity.ReceivedLike
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - next page of received likes",
+			args: entity.ReceivedLikesParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 3}},
+			},
+			expectedResult: likes,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_RECEIVED_LIKES_QUERY + " AND (r.created_at, r.user_id) < (?, ?) ORDER BY r.created_at DESC, r.user_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReceivedLike{}, query, testUser.ID, entity.MatchReactionTypes(), entity.REACTION_TYPE_UNDECIDED, timestamp, uint(3), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.ReceivedLike)
+					*arg = append(*arg, likes...)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			args: entity.ReceivedLikesParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10},
+			},
+			expectedResult: []entity.ReceivedLike{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_RECEIVED_LIKES_QUERY + " AND TRUE ORDER BY r.created_at DESC, r.user_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReceivedLike{}, query, testUser.ID, entity.MatchReactionTypes(), entity.REACTION_TYPE_UNDECIDED, 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get received likes: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetReceivedLikes(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetMatches(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	matches := []entity.MatchListItem{
+		{
+			ID:             7,
+			User:           entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt:      timestamp,
+			LastActiveAt:   timestamp,
+			LastActivityAt: timestamp.Add(time.Hour),
+			UnreadCount:    3,
+		},
+	}
+	tests := []struct {
+		name             string
+		args             entity.MatchListParams
+		expectedError    error
+		expectedResult   []entity.MatchListItem
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - next page of matches",
+			args: entity.MatchListParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 8}},
+			},
+			expectedResult: matches,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_MATCHES_QUERY + " AND (COALESCE(c.last_message_at, m.created_at), m.id) < (?, ?) ORDER BY COALESCE(c.last_message_at, m.created_at) DESC, m.id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.MatchListItem{}, query, testUser.ID, testUser.ID, testUser.ID, testUser.ID, timestamp, uint(8), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.MatchListItem)
+					*arg = append(*arg, matches...)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			args: entity.MatchListParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10},
+			},
+			expectedResult: []entity.MatchListItem{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_MATCHES_QUERY + " AND TRUE ORDER BY COALESCE(c.last_message_at, m.created_at) DESC, m.id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.MatchListItem{}, query, testUser.ID, testUser.ID, testUser.ID, testUser.ID, 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get matches: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetMatches(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_EndMatch(t *testing.T) {
+	params := entity.UnmatchParams{
+		MatchID: 7,
+		UserID:  testUser.ID,
+		Reason:  entity.UNMATCH_REASON_NOT_INTERESTED,
+	}
+	match := entity.Match{ID: 7, FirstUserID: testUser.ID, SecondUserID: 2}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.Match
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully end match",
+			expectedResult: &match,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Match{}, repository.END_MATCH_QUERY, testUser.ID, params.Reason, params.MatchID, testUser.ID, testUser.ID).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Match)
+					*arg = append(*arg, match)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no active match of the user",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Match{}, repository.END_MATCH_QUERY, testUser.ID, params.Reason, params.MatchID, testUser.ID, testUser.ID).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when updating",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Match{}, repository.END_MATCH_QUERY, testUser.ID, params.Reason, params.MatchID, testUser.ID, testUser.ID).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when end match: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.EndMatch(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_CountReceivedLikes(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   int64
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case",
+			expectedResult: 7,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				var count int64
+				postgresClient.On("Select", &count, repository.COUNT_RECEIVED_LIKES_QUERY, testUser.ID, entity.MatchReactionTypes(), entity.REACTION_TYPE_UNDECIDED).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*int64)
+					*arg = 7
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				var count int64
+				postgresClient.On("Select", &count, repository.COUNT_RECEIVED_LIKES_QUERY, testUser.ID, entity.MatchReactionTypes(), entity.REACTION_TYPE_UNDECIDED).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when count received likes: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.CountReceivedLikes(testUser.ID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetLastReaction(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	reaction := entity.UserReaction{UserID: 1, TargetID: 2, Type: 2, CreatedAt: timestamp, UpdatedAt: timestamp}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.UserReaction
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - reaction found",
+			expectedResult: &reaction,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_LAST_REACTION_QUERY, testUser.ID).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.UserReaction)
+					*arg = append(*arg, reaction)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no reaction found",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_LAST_REACTION_QUERY, testUser.ID).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_LAST_REACTION_QUERY, testUser.ID).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get last reaction: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetLastReaction(testUser.ID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_RevertUserReaction(t *testing.T) {
+	previousType := entity.REACTION_TYPE_LIKE
+	passType := entity.REACTION_TYPE_PASS
+	savedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	match := entity.Match{ID: 7, FirstUserID: 1, SecondUserID: 2, CreatedAt: savedAt}
+	tests := []struct {
+		name             string
+		args             entity.UserReaction
+		expectedResult   *entity.Match
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - delete newly inserted reaction",
+			args: entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.DELETE_USER_REACTION_QUERY, uint(1), uint(2)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "normal case - restore replaced reaction",
+			args: entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS, PreviousType: &previousType},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.RESTORE_USER_REACTION_QUERY, uint(1), uint(2)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - delete a like ending the match it made",
+			args:           entity.UserReaction{UserID: 2, TargetID: 1, Type: entity.REACTION_TYPE_LIKE, CreatedAt: savedAt, UpdatedAt: savedAt},
+			expectedResult: &match,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.DELETE_USER_REACTION_QUERY, uint(2), uint(1)).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.END_UNDONE_MATCH_QUERY, uint(2), entity.MATCH_END_REASON_UNDONE, uint(1), uint(2), savedAt).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Match)
+					*arg = append(*arg, match)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "normal case - restore a pass replaced by a super like without a match",
+			args: entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_SUPER_LIKE, PreviousType: &passType, UpdatedAt: savedAt},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.RESTORE_USER_REACTION_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.END_UNDONE_MATCH_QUERY, uint(1), entity.MATCH_END_REASON_UNDONE, uint(1), uint(2), savedAt).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "normal case - restore a like replaced by a super like keeps the match",
+			args: entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_SUPER_LIKE, PreviousType: &previousType, UpdatedAt: savedAt},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.RESTORE_USER_REACTION_QUERY, uint(1), uint(2)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "error case - error when locking the pair",
+			args: entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when revert user_reactions: timeout"),
+		},
+		{
+			name: "error case - error when executing",
+			args: entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.DELETE_USER_REACTION_QUERY, uint(1), uint(2)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when revert user_reactions: timeout"),
+		},
+		{
+			name: "error case - error when ending the match",
+			args: entity.UserReaction{UserID: 2, TargetID: 1, Type: entity.REACTION_TYPE_LIKE, CreatedAt: savedAt, UpdatedAt: savedAt},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(1), uint(2)).Return(nil)
+				tx.On("Exec", repository.DELETE_USER_REACTION_QUERY, uint(2), uint(1)).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.END_UNDONE_MATCH_QUERY, uint(2), entity.MATCH_END_REASON_UNDONE, uint(1), uint(2), savedAt).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when revert user_reactions: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.RevertUserReaction(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestPostgresRepository_GetUserReaction(t *testing.T) {
+	reaction := entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.UserReaction
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - reaction found",
+			expectedResult: &reaction,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_USER_REACTION_QUERY, uint(1), uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.UserReaction)
+					*arg = append(*arg, reaction)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no reaction found",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_USER_REACTION_QUERY, uint(1), uint(2)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.UserReaction{}, repository.SELECT_USER_REACTION_QUERY, uint(1), uint(2)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get user reaction: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetUserReaction(1, 2)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetActiveBoost(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	boost := entity.Boost{ID: 7, UserID: 1, StartedAt: timestamp, EndsAt: timestamp.Add(30 * time.Minute), Impressions: 12, Likes: 3}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.Boost
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - active boost found",
+			expectedResult: &boost,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Boost{}, repository.SELECT_ACTIVE_BOOST_QUERY, uint(1)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Boost)
+					*arg = append(*arg, boost)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no active boost",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Boost{}, repository.SELECT_ACTIVE_BOOST_QUERY, uint(1)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Boost{}, repository.SELECT_ACTIVE_BOOST_QUERY, uint(1)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get active boost: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetActiveBoost(1)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_ActivateBoost(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	boost := entity.Boost{ID: 7, UserID: 1, StartedAt: timestamp, EndsAt: timestamp.Add(30 * time.Minute)}
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   *entity.Boost
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - boost spent and started",
+			expectedResult: &boost,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Boost{}, repository.ACTIVATE_BOOST_QUERY, uint(1), float64(1800)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Boost)
+					*arg = append(*arg, boost)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no balance left",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Boost{}, repository.ACTIVATE_BOOST_QUERY, uint(1), float64(1800)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Boost{}, repository.ACTIVATE_BOOST_QUERY, uint(1), float64(1800)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when activate boost: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.ActivateBoost(1, 30*time.Minute)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GrantPremium(t *testing.T) {
+	tests := []struct {
+		name             string
+		boosts           int
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:   "normal case - successfully grant premium with boosts",
+			boosts: 2,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.UPDATE_USER_PREMIUM_QUERY, true, uint(1)).Return(nil)
+				tx.On("Exec", repository.ADD_BOOST_BALANCE_QUERY, 2, uint(1)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "normal case - successfully grant premium without boosts",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.UPDATE_USER_PREMIUM_QUERY, true, uint(1)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:   "error case - unexpected error when updating premium",
+			boosts: 2,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.UPDATE_USER_PREMIUM_QUERY, true, uint(1)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when grant premium: timeout"),
+		},
+		{
+			name:   "error case - unexpected error when adding the boosts",
+			boosts: 2,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.UPDATE_USER_PREMIUM_QUERY, true, uint(1)).Return(nil)
+				tx.On("Exec", repository.ADD_BOOST_BALANCE_QUERY, 2, uint(1)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when grant premium: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			err := repo.GrantPremium(1, tc.boosts)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPostgresRepository_IncrementBoostImpressions(t *testing.T) {
+	boostIDs := []uint{7, 8}
+	tests := []struct {
+		name             string
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - successfully increment impressions",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Exec", repository.INCREMENT_BOOST_IMPRESSIONS_QUERY, boostIDs).Return(nil)
+			},
+		},
+		{
+			name: "error case - unexpected error during update",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Exec", repository.INCREMENT_BOOST_IMPRESSIONS_QUERY, boostIDs).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when increment boost impressions: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			err := repo.IncrementBoostImpressions(boostIDs)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPostgresRepository_IncrementBoostLikes(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedError    error
+		expectedResult   bool
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - like credited to the active boost",
+			expectedResult: true,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.INCREMENT_BOOST_LIKES_QUERY, uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]uint)
+					*arg = append(*arg, 7)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - target is not boosted",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.INCREMENT_BOOST_LIKES_QUERY, uint(2)).Return(nil)
+			},
+		},
+		{
+			name: "error case - unexpected error during update",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.INCREMENT_BOOST_LIKES_QUERY, uint(2)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when increment boost likes: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.IncrementBoostLikes(2)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_InsertBlock(t *testing.T) {
+	params := entity.BlockParams{UserID: uint(3), TargetID: uint(2)}
+	match := entity.Match{ID: 7, FirstUserID: 2, SecondUserID: 3}
+	tests := []struct {
+		name             string
+		expectedResult   *entity.Match
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - block without a match",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.INSERT_USER_BLOCK_QUERY, uint(3), uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.END_BLOCKED_MATCH_QUERY, uint(3), entity.MATCH_END_REASON_BLOCKED, uint(2), uint(3)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - block ending the match of the pair",
+			expectedResult: &match,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.INSERT_USER_BLOCK_QUERY, uint(3), uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.END_BLOCKED_MATCH_QUERY, uint(3), entity.MATCH_END_REASON_BLOCKED, uint(2), uint(3)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Match)
+					*arg = append(*arg, match)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name: "error case - unexpected error when locking the pair",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when insert to user_blocks: timeout"),
+		},
+		{
+			name: "error case - unexpected error when inserting",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.INSERT_USER_BLOCK_QUERY, uint(3), uint(2)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when insert to user_blocks: timeout"),
+		},
+		{
+			name: "error case - unexpected error when ending the match",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REACTION_PAIR_QUERY, uint(2), uint(3)).Return(nil)
+				tx.On("Exec", repository.INSERT_USER_BLOCK_QUERY, uint(3), uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Match{}, repository.END_BLOCKED_MATCH_QUERY, uint(3), entity.MATCH_END_REASON_BLOCKED, uint(2), uint(3)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when insert to user_blocks: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.InsertBlock(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_DeleteBlock(t *testing.T) {
+	params := entity.BlockParams{UserID: testUser.ID, TargetID: 2}
+	tests := []struct {
+		name             string
+		expectedResult   bool
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully delete block",
+			expectedResult: true,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.DELETE_USER_BLOCK_QUERY, testUser.ID, uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]uint)
+					*arg = append(*arg, 2)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - no block to delete",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.DELETE_USER_BLOCK_QUERY, testUser.ID, uint(2)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when deleting",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.DELETE_USER_BLOCK_QUERY, testUser.ID, uint(2)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when delete from user_blocks: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.DeleteBlock(params)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetBlockedUserIDs(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedResult   []uint
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - successfully get blocked user IDs",
+			expectedResult: []uint{2, 5},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.SELECT_BLOCKED_USER_IDS_QUERY, testUser.ID, testUser.ID).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]uint)
+					*arg = append(*arg, 2, 5)
+				}).Return(nil)
+			},
+		},
+		{
+			name:           "error case - error when querying",
+			expectedResult: []uint{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]uint{}, repository.SELECT_BLOCKED_USER_IDS_QUERY, testUser.ID, testUser.ID).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get blocked user IDs: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetBlockedUserIDs(testUser.ID)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetBlocks(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	blocks := []entity.BlockListItem{
+		{
+			User:      entity.UserSummary{ID: 2, Username: "second"},
+			CreatedAt: timestamp,
+		},
+	}
+	tests := []struct {
+		name             string
+		args             entity.BlockListParams
+		expectedError    error
+		expectedResult   []entity.BlockListItem
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - next page of blocks",
+			args: entity.BlockListParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 8}},
+			},
+			expectedResult: blocks,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_BLOCKS_QUERY + " AND (b.created_at, b.blocked_id) < (?, ?) ORDER BY b.created_at DESC, b.blocked_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.BlockListItem{}, query, testUser.ID, timestamp, uint(8), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.BlockListItem)
+					*arg = append(*arg, blocks...)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			args: entity.BlockListParams{
+				UserID: testUser.ID,
+				Page:   utils.PageParams{Limit: 10},
+			},
+			expectedResult: []entity.BlockListItem{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_BLOCKS_QUERY + " AND TRUE ORDER BY b.created_at DESC, b.blocked_id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.BlockListItem{}, query, testUser.ID, 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get blocks: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetBlocks(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_InsertReport(t *testing.T) {
+	params := entity.ReportParams{ReporterID: testUser.ID, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM, Details: "spam links"}
+	report := entity.Report{ID: 5, ReporterID: testUser.ID, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM, Details: "spam links", Status: entity.REPORT_STATUS_PENDING}
+	createdReport := report
+	createdReport.Created = true
+	tests := []struct {
+		name             string
+		threshold        int
+		expectedResult   *entity.Report
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - new report checked against the threshold",
+			threshold:      3,
+			expectedResult: &createdReport,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORTED_USER_QUERY, uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.INSERT_REPORT_QUERY, testUser.ID, uint(2), entity.REPORT_REASON_SPAM, "spam links").Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, report)
+				}).Return(nil)
+				tx.On("Exec", repository.HIDE_REPORTED_USER_QUERY, uint(2), 3).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - new report with hiding disabled",
+			expectedResult: &createdReport,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORTED_USER_QUERY, uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.INSERT_REPORT_QUERY, testUser.ID, uint(2), entity.REPORT_REASON_SPAM, "spam links").Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, report)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - report already open",
+			threshold:      3,
+			expectedResult: &report,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORTED_USER_QUERY, uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.INSERT_REPORT_QUERY, testUser.ID, uint(2), entity.REPORT_REASON_SPAM, "spam links").Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.SELECT_OPEN_REPORT_QUERY, testUser.ID, uint(2)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, report)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:      "error case - unexpected error when locking the reported user",
+			threshold: 3,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORTED_USER_QUERY, uint(2)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when insert to reports: timeout"),
+		},
+		{
+			name:      "error case - unexpected error when inserting",
+			threshold: 3,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORTED_USER_QUERY, uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.INSERT_REPORT_QUERY, testUser.ID, uint(2), entity.REPORT_REASON_SPAM, "spam links").Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when insert to reports: timeout"),
+		},
+		{
+			name:      "error case - unexpected error when hiding the reported user",
+			threshold: 3,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORTED_USER_QUERY, uint(2)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.INSERT_REPORT_QUERY, testUser.ID, uint(2), entity.REPORT_REASON_SPAM, "spam links").Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, report)
+				}).Return(nil)
+				tx.On("Exec", repository.HIDE_REPORTED_USER_QUERY, uint(2), 3).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when insert to reports: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.InsertReport(params, tc.threshold)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_GetReports(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	reports := []entity.ReportQueueItem{
+		{
+			Report:    entity.Report{ID: 5, ReporterID: testUser.ID, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM, Status: entity.REPORT_STATUS_PENDING, CreatedAt: timestamp},
+			Reporters: 2,
+		},
+	}
+	tests := []struct {
+		name             string
+		args             entity.ReportQueueParams
+		expectedError    error
+		expectedResult   []entity.ReportQueueItem
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name: "normal case - next page of reports",
+			args: entity.ReportQueueParams{
+				Status: entity.REPORT_STATUS_PENDING,
+				Page:   utils.PageParams{Limit: 10, Cursor: &utils.Cursor{CreatedAt: timestamp, ID: 8}},
+			},
+			expectedResult: reports,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_REPORT_QUEUE_QUERY + " AND (r.created_at, r.id) < (?, ?) ORDER BY r.created_at DESC, r.id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReportQueueItem{}, query, entity.REPORT_STATUS_PENDING, timestamp, uint(8), 11).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.ReportQueueItem)
+					*arg = append(*arg, reports...)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when querying",
+			args: entity.ReportQueueParams{
+				Status: entity.REPORT_STATUS_CLAIMED,
+				Page:   utils.PageParams{Limit: 10},
+			},
+			expectedResult: []entity.ReportQueueItem{},
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				query := repository.SELECT_REPORT_QUEUE_QUERY + " AND TRUE ORDER BY r.created_at DESC, r.id DESC LIMIT ?"
+				postgresClient.On("Select", &[]entity.ReportQueueItem{}, query, entity.REPORT_STATUS_CLAIMED, 11).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when get reports: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.GetReports(tc.args)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_ClaimReport(t *testing.T) {
+	params := entity.ReportActionParams{ReportID: 5, ModeratorID: 9}
+	moderatorID := uint(9)
+	report := entity.Report{ID: 5, ReporterID: testUser.ID, ReportedID: 2, Status: entity.REPORT_STATUS_CLAIMED, ClaimedBy: &moderatorID}
+	tests := []struct {
+		name             string
+		expectedResult   *entity.Report
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - report claimed",
+			expectedResult: &report,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Report{}, repository.CLAIM_REPORT_QUERY, uint(9), uint(5), uint(9), float64(1800)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, report)
+				}).Return(nil)
+			},
+		},
+		{
+			name: "normal case - report can not be claimed",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Report{}, repository.CLAIM_REPORT_QUERY, uint(9), uint(5), uint(9), float64(1800)).Return(nil)
+			},
+		},
+		{
+			name: "error case - error when updating",
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface) {
+				postgresClient.On("Select", &[]entity.Report{}, repository.CLAIM_REPORT_QUERY, uint(9), uint(5), uint(9), float64(1800)).Return(errors.New("timeout"))
+			},
+			expectedError: errors.New("postgres client error when claim report: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.ClaimReport(params, 30*time.Minute)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresRepository_CloseReport(t *testing.T) {
+	params := entity.ReportActionParams{ReportID: 5, ModeratorID: 9, Note: "warned"}
+	moderatorID := uint(9)
+	resolved := entity.Report{ID: 5, ReporterID: testUser.ID, ReportedID: 2, Status: entity.REPORT_STATUS_RESOLVED, ResolvedBy: &moderatorID, Note: "warned"}
+	dismissed := entity.Report{ID: 5, ReporterID: testUser.ID, ReportedID: 2, Status: entity.REPORT_STATUS_DISMISSED, ResolvedBy: &moderatorID, Note: "warned"}
+	tests := []struct {
+		name             string
+		status           string
+		expectedResult   *entity.Report
+		expectedError    error
+		mockPostgresCall func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface)
+	}{
+		{
+			name:           "normal case - report resolved",
+			status:         entity.REPORT_STATUS_RESOLVED,
+			expectedResult: &resolved,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORT_USER_QUERY, uint(5)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.CLOSE_REPORT_QUERY, entity.REPORT_STATUS_RESOLVED, uint(9), "warned", uint(5), uint(9)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, resolved)
+				}).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:           "normal case - report dismissed showing the reported user again",
+			status:         entity.REPORT_STATUS_DISMISSED,
+			expectedResult: &dismissed,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORT_USER_QUERY, uint(5)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.CLOSE_REPORT_QUERY, entity.REPORT_STATUS_DISMISSED, uint(9), "warned", uint(5), uint(9)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, dismissed)
+				}).Return(nil)
+				tx.On("Exec", repository.UNHIDE_REPORTED_USER_QUERY, uint(2), 3).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:   "normal case - report not claimed by the moderator",
+			status: entity.REPORT_STATUS_DISMISSED,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORT_USER_QUERY, uint(5)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.CLOSE_REPORT_QUERY, entity.REPORT_STATUS_DISMISSED, uint(9), "warned", uint(5), uint(9)).Return(nil)
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+		},
+		{
+			name:   "error case - unexpected error when locking the reported user",
+			status: entity.REPORT_STATUS_RESOLVED,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORT_USER_QUERY, uint(5)).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when close report: timeout"),
+		},
+		{
+			name:   "error case - unexpected error when showing the reported user again",
+			status: entity.REPORT_STATUS_DISMISSED,
+			mockPostgresCall: func(postgresClient *mockspostgres.PostgresInterface, tx *mockspostgres.PostgresInterface) {
+				tx.On("Exec", repository.LOCK_REPORT_USER_QUERY, uint(5)).Return(nil)
+				tx.On("Select", &[]entity.Report{}, repository.CLOSE_REPORT_QUERY, entity.REPORT_STATUS_DISMISSED, uint(9), "warned", uint(5), uint(9)).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*[]entity.Report)
+					*arg = append(*arg, dismissed)
+				}).Return(nil)
+				tx.On("Exec", repository.UNHIDE_REPORTED_USER_QUERY, uint(2), 3).Return(errors.New("timeout"))
+				postgresClient.On("Transaction", mock.Anything).Return(func(fn func(tx postgres.PostgresInterface) error) error {
+					return fn(tx)
+				})
+			},
+			expectedError: errors.New("postgres client error when close report: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		postgresClient := mockspostgres.NewPostgresInterface(t)
+		tx := mockspostgres.NewPostgresInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockPostgresCall(postgresClient, tx)
+			repo := repository.NewPostgresRepository(postgresClient)
+			result, err := repo.CloseReport(params, tc.status, 3)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
 		})
 	}
 }
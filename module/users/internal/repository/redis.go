@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"timble/internal/connection/redis"
+	"timble/internal/utils"
 )
 
 type RedisRepository struct {
@@ -48,6 +49,16 @@ func (repo *RedisRepository) Get(ctx context.Context, key string) (string, error
 	return res, nil
 }
 
+// GetDel returns the value of the key and removes it, so only one caller ever claims it
+func (repo *RedisRepository) GetDel(ctx context.Context, key string) (string, error) {
+	res, err := repo.redisClient.GetDel(ctx, key)
+	if err != nil {
+		return "", errors.Wrap(err, "redis client error when getdel")
+	}
+
+	return res, nil
+}
+
 func (repo *RedisRepository) Del(ctx context.Context, key string) (int64, error) {
 	res, err := repo.redisClient.Del(ctx, key)
 	if err != nil {
@@ -56,3 +67,82 @@ func (repo *RedisRepository) Del(ctx context.Context, key string) (int64, error)
 
 	return res, nil
 }
+
+// AddToSet adds the member to the set at key, it reports whether the member was not in the set yet.
+// The expiry is set whenever a member is added
+func (repo *RedisRepository) AddToSet(ctx context.Context, key, member string, expire time.Duration) (bool, error) {
+	res, err := repo.redisClient.SAdd(ctx, key, member)
+	if err != nil {
+		return false, errors.Wrap(err, "redis client error when add to set")
+	}
+
+	if res == 1 && expire != 0 {
+		repo.redisClient.Expire(ctx, key, expire)
+	}
+
+	return res == 1, nil
+}
+
+// PublishEvent publishes the event to the user and keeps it in their event log, it returns the ID of the logged event
+func (repo *RedisRepository) PublishEvent(ctx context.Context, userID uint, payload string) (string, error) {
+	res, err := repo.redisClient.PublishEvent(ctx, utils.BuildEventStreamKey(userID), utils.BuildNotificationChannel(userID), payload, utils.EVENT_LOG_MAX_LENGTH, utils.EVENT_LOG_TTL)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when publish event")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) ReserveQuota(ctx context.Context, key string, limit int, expire time.Duration) (bool, error) {
+	res, err := repo.redisClient.ReserveQuota(ctx, key, limit, expire)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when reserve quota")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) RefundQuota(ctx context.Context, key string) (int64, error) {
+	res, err := repo.redisClient.RefundQuota(ctx, key)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when refund quota")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) ReserveRollingQuota(ctx context.Context, key, member string, limit int, period time.Duration) (bool, error) {
+	res, err := repo.redisClient.ReserveRollingQuota(ctx, key, member, limit, period)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when reserve rolling quota")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) RefundRollingQuota(ctx context.Context, key, member string) (int64, error) {
+	res, err := repo.redisClient.RefundRollingQuota(ctx, key, member)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when refund rolling quota")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) RefundRollingQuotaAt(ctx context.Context, key string, at time.Time) (int64, error) {
+	res, err := repo.redisClient.RefundRollingQuotaAt(ctx, key, at)
+	if err != nil {
+		return res, errors.Wrap(err, "redis client error when refund rolling quota at")
+	}
+
+	return res, nil
+}
+
+func (repo *RedisRepository) RollingQuotaUsage(ctx context.Context, key string, period time.Duration) (int64, time.Time, error) {
+	count, oldest, err := repo.redisClient.RollingQuotaUsage(ctx, key, period)
+	if err != nil {
+		return count, oldest, errors.Wrap(err, "redis client error when get rolling quota usage")
+	}
+
+	return count, oldest, nil
+}
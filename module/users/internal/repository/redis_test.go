@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	redis "timble/internal/connection/redis"
+	"timble/internal/utils"
 	mocksredis "timble/mocks/internal_/connection/redis"
 	"timble/module/users/internal/repository"
 )
@@ -176,6 +177,52 @@ func TestRedisRepository_Get(t *testing.T) {
 	}
 }
 
+func TestRedisRepository_GetDel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		key            string
+		expectedError  error
+		expectedResult string
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully get and delete key",
+			key:            testKey,
+			expectedResult: testMember,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("GetDel", ctx, testKey).Return(testMember, nil)
+			},
+		},
+		{
+			name: "error case - error when adding new value",
+			key:  testKey,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("GetDel", ctx, testKey).Return("", errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when getdel: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.GetDel(ctx, tc.key)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
 func TestRedisRepository_Del(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
@@ -219,3 +266,344 @@ func TestRedisRepository_Del(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisRepository_AddToSet(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult bool
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - new member is added",
+			expectedResult: true,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("SAdd", ctx, testKey, testMember).Return(int64(1), nil)
+				redisClient.On("Expire", ctx, testKey, testExpire).Return(true, nil)
+			},
+		},
+		{
+			name: "normal case - member is already in the set",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("SAdd", ctx, testKey, testMember).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "error case - error when adding",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("SAdd", ctx, testKey, testMember).Return(int64(0), errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when add to set: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			res, err := repo.AddToSet(ctx, testKey, testMember, testExpire)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
+
+func TestRedisRepository_ReserveQuota(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult bool
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully reserve quota",
+			expectedResult: true,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("ReserveQuota", ctx, testKey, 10, testExpire).Return(true, nil)
+			},
+		},
+		{
+			name: "error case - error when reserving quota",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("ReserveQuota", ctx, testKey, 10, testExpire).Return(false, errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when reserve quota: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.ReserveQuota(ctx, testKey, 10, testExpire)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_RefundQuota(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult int64
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully refund quota",
+			expectedResult: int64(1),
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RefundQuota", ctx, testKey).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "error case - error when refunding quota",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RefundQuota", ctx, testKey).Return(int64(0), errors.New("timeout"))
+			},
+			expectedResult: int64(0),
+			expectedError:  errors.New("redis client error when refund quota: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.RefundQuota(ctx, testKey)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_ReserveRollingQuota(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult bool
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully reserve rolling quota",
+			expectedResult: true,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("ReserveRollingQuota", ctx, testKey, "member", 10, testExpire).Return(true, nil)
+			},
+		},
+		{
+			name: "error case - error when reserving rolling quota",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("ReserveRollingQuota", ctx, testKey, "member", 10, testExpire).Return(false, errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when reserve rolling quota: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.ReserveRollingQuota(ctx, testKey, "member", 10, testExpire)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_RefundRollingQuota(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult int64
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully refund rolling quota",
+			expectedResult: int64(1),
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RefundRollingQuota", ctx, testKey, "member").Return(int64(1), nil)
+			},
+		},
+		{
+			name: "error case - error when refunding rolling quota",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RefundRollingQuota", ctx, testKey, "member").Return(int64(0), errors.New("timeout"))
+			},
+			expectedResult: int64(0),
+			expectedError:  errors.New("redis client error when refund rolling quota: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.RefundRollingQuota(ctx, testKey, "member")
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_RefundRollingQuotaAt(t *testing.T) {
+	ctx := context.Background()
+	at := time.Now()
+	tests := []struct {
+		name           string
+		expectedResult int64
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully refund rolling quota",
+			expectedResult: int64(1),
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RefundRollingQuotaAt", ctx, testKey, at).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "error case - error when refunding rolling quota",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RefundRollingQuotaAt", ctx, testKey, at).Return(int64(0), errors.New("timeout"))
+			},
+			expectedResult: int64(0),
+			expectedError:  errors.New("redis client error when refund rolling quota at: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.RefundRollingQuotaAt(ctx, testKey, at)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestRedisRepository_RollingQuotaUsage(t *testing.T) {
+	ctx := context.Background()
+	oldest := time.Now()
+	tests := []struct {
+		name           string
+		expectedCount  int64
+		expectedOldest time.Time
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully get rolling quota usage",
+			expectedCount:  int64(2),
+			expectedOldest: oldest,
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RollingQuotaUsage", ctx, testKey, testExpire).Return(int64(2), oldest, nil)
+			},
+		},
+		{
+			name: "error case - error when getting rolling quota usage",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("RollingQuotaUsage", ctx, testKey, testExpire).Return(int64(0), time.Time{}, errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when get rolling quota usage: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			count, result, err := repo.RollingQuotaUsage(ctx, testKey, testExpire)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedOldest, result)
+		})
+	}
+}
+
+func TestRedisRepository_PublishEvent(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		expectedResult string
+		expectedError  error
+		mockRedisCall  func(redisClient *mocksredis.RedisInterface)
+	}{
+		{
+			name:           "normal case - successfully publish event",
+			expectedResult: "1-0",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("PublishEvent", ctx, "events:7", "notifications:7", testMember, int64(utils.EVENT_LOG_MAX_LENGTH), utils.EVENT_LOG_TTL).Return("1-0", nil)
+			},
+		},
+		{
+			name: "error case - error when publishing event",
+			mockRedisCall: func(redisClient *mocksredis.RedisInterface) {
+				redisClient.On("PublishEvent", ctx, "events:7", "notifications:7", testMember, int64(utils.EVENT_LOG_MAX_LENGTH), utils.EVENT_LOG_TTL).Return("", errors.New("timeout"))
+			},
+			expectedError: errors.New("redis client error when publish event: timeout"),
+		},
+	}
+
+	for _, tc := range tests {
+		redisClient := mocksredis.NewRedisInterface(t)
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockRedisCall(redisClient)
+			repo := repository.NewRedisRepository(redisClient)
+			result, err := repo.PublishEvent(ctx, 7, testMember)
+
+			if tc.expectedError != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
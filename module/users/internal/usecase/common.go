@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -11,24 +12,49 @@ import (
 const (
 	PREMIUM_TRUE_STRING  = "true"
 	PREMIUM_FALSE_STRING = "false"
-	REACTION_LIMIT       = 10
+
+	DISCOVERY_POOL_SIZE        = 100
+	DISCOVERY_LIMIT            = 20
+	SCORE_RECOMPUTE_BATCH_SIZE = 1000
+	ELO_DEFAULT_SCORE          = 1500
+	ELO_K_FACTOR               = 32
+
+	BOOST_EVENT_ACTIVATION = "activation"
+	BOOST_EVENT_IMPRESSION = "impression"
+	BOOST_EVENT_LIKE       = "like"
 )
 
 var (
-	premiumExpCache       = 24 * time.Hour
-	reactionLimitExpCache = 24 * time.Hour
+	timezoneExpCache = 24 * time.Hour
+	blocksExpCache   = 24 * time.Hour
+
+	// scoreSettleTime leaves the latest reactions to the next recompute, a reaction still being committed is not skipped
+	scoreSettleTime = time.Minute
+
+	// dailyQuotaPolicy places the window the daily quotas reset on
+	dailyQuotaPolicy = entity.QuotaPolicy{Window: entity.QUOTA_WINDOW_DAILY}
 )
 
 type RedisRepository interface {
 	Set(ctx context.Context, key string, value interface{}, expire time.Duration) (string, error)
 	Get(ctx context.Context, key string) (string, error)
+	GetDel(ctx context.Context, key string) (string, error)
 	Incr(ctx context.Context, key string, expire time.Duration) (int64, error)
 	Del(ctx context.Context, key string) (int64, error)
+	AddToSet(ctx context.Context, key, member string, expire time.Duration) (bool, error)
+	PublishEvent(ctx context.Context, userID uint, payload string) (string, error)
+	ReserveQuota(ctx context.Context, key string, limit int, expire time.Duration) (bool, error)
+	RefundQuota(ctx context.Context, key string) (int64, error)
+	ReserveRollingQuota(ctx context.Context, key, member string, limit int, period time.Duration) (bool, error)
+	RefundRollingQuota(ctx context.Context, key, member string) (int64, error)
+	RefundRollingQuotaAt(ctx context.Context, key string, at time.Time) (int64, error)
+	RollingQuotaUsage(ctx context.Context, key string, period time.Duration) (int64, time.Time, error)
 }
 
 type CacheRepository interface {
 	Get(ctx context.Context, key string) (res []byte, err error)
 	Set(ctx context.Context, key string, data []byte, exp time.Duration) error
+	Delete(ctx context.Context, key string) error
 }
 
 type PostgresRepository interface {
@@ -36,22 +62,100 @@ type PostgresRepository interface {
 	GetUserByUsername(username string) (*entity.User, error)
 	InsertUser(user entity.User) error
 	UpdateUserPremium(user entity.User, value interface{}) error
-	UpsertUserReaction(reaction entity.ReactionParams) error
+	UpdateUserTimezone(params entity.UserTimezoneParams) error
+	UpsertUserReaction(reaction entity.ReactionParams) (*entity.Match, error)
+	UpsertUserReactions(reactions []entity.ReactionParams) ([]*entity.Match, error)
+	GetUserReaction(userID, targetID uint) (*entity.UserReaction, error)
+	GetLastReaction(userID uint) (*entity.UserReaction, error)
+	RevertUserReaction(reaction entity.UserReaction) (*entity.Match, error)
+	GetCandidateByID(id uint) (*entity.Candidate, error)
+	GetCandidates(userID uint, limit int) ([]entity.Candidate, error)
+	GetPassedCandidates(userID uint, cooldown time.Duration, limit int) ([]entity.Candidate, error)
+	GetReactionsAfter(after entity.UserReaction, settle time.Duration, limit int) ([]entity.UserReaction, error)
+	GetScoreCheckpoint() (entity.UserReaction, error)
+	GetUserScores(userIDs []uint) ([]entity.UserScore, error)
+	SaveUserScores(scores []entity.UserScore, after entity.UserReaction, last entity.UserReaction) (bool, error)
+	GetReactionHistory(params entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, error)
+	GetReceivedLikes(params entity.ReceivedLikesParams) ([]entity.ReceivedLike, error)
+	CountReceivedLikes(userID uint) (int64, error)
+	GetMatches(params entity.MatchListParams) ([]entity.MatchListItem, error)
+	EndMatch(params entity.UnmatchParams) (*entity.Match, error)
+	GetActiveBoost(userID uint) (*entity.Boost, error)
+	ActivateBoost(userID uint, duration time.Duration) (*entity.Boost, error)
+	GrantPremium(userID uint, boosts int) error
+	IncrementBoostImpressions(boostIDs []uint) error
+	IncrementBoostLikes(userID uint) (bool, error)
+	InsertBlock(params entity.BlockParams) (*entity.Match, error)
+	DeleteBlock(params entity.BlockParams) (bool, error)
+	GetBlockedUserIDs(userID uint) ([]uint, error)
+	GetBlocks(params entity.BlockListParams) ([]entity.BlockListItem, error)
+	InsertReport(params entity.ReportParams, threshold int) (*entity.Report, error)
+	GetReports(params entity.ReportQueueParams) ([]entity.ReportQueueItem, error)
+	ClaimReport(params entity.ReportActionParams, claimTTL time.Duration) (*entity.Report, error)
+	CloseReport(params entity.ReportActionParams, status string, threshold int) (*entity.Report, error)
 }
 
 func BuildPremiumCacheKey(userID uint) string {
 	return fmt.Sprintf("premium:%d", userID)
 }
 
-func BuildReactionLimitRedisKey(userID uint) string {
-	currentTime := time.Now()
-	loc, err := time.LoadLocation("Asia/Jakarta")
-	if err == nil {
-		currentTime = currentTime.In(loc)
-	}
-	return fmt.Sprintf("reaction:%s:%d", currentTime.Format("2006-01-02"), userID)
+func BuildTimezoneCacheKey(userID uint) string {
+	return fmt.Sprintf("timezone:%d", userID)
+}
+
+// BuildBlocksRedisKey caches the users hidden from the user, whichever side made the block
+func BuildBlocksRedisKey(userID uint) string {
+	return fmt.Sprintf("blocks:%d", userID)
+}
+
+func BuildQuotaRedisKey(name string, window QuotaWindow, userID uint) string {
+	return fmt.Sprintf("%s:%s:%d", name, window.Period, userID)
+}
+
+// BuildBoostImpressionsRedisKey keeps the viewers the boost was already shown to
+func BuildBoostImpressionsRedisKey(boostID uint) string {
+	return fmt.Sprintf("boost_impressions:%d", boostID)
 }
 
 func BuildPremiumEligibilityRedisKey(userID uint) string {
 	return fmt.Sprintf("eligible_for_premium:%d", userID)
 }
+
+// QuotaWindow is the span a quota is counted on, Period tells apart the fixed windows of a quota
+type QuotaWindow struct {
+	Period  string
+	ResetAt time.Time
+}
+
+// NewQuotaWindow returns the window of the quota containing now. Fixed windows follow the user's local clock,
+// a rolling one with nothing taken is already whole, it resets one period after its oldest unit once one is taken
+func NewQuotaWindow(quota entity.QuotaPolicy, now time.Time, loc *time.Location) QuotaWindow {
+	localTime := now.In(loc)
+	year, month, day := localTime.Date()
+	switch quota.Window {
+	case entity.QUOTA_WINDOW_HOURLY:
+		return QuotaWindow{
+			Period:  localTime.Format("2006-01-02T15"),
+			ResetAt: time.Date(year, month, day, localTime.Hour()+1, 0, 0, 0, loc),
+		}
+	case entity.QUOTA_WINDOW_ROLLING:
+		return QuotaWindow{
+			Period:  entity.QUOTA_WINDOW_ROLLING,
+			ResetAt: now,
+		}
+	default:
+		return QuotaWindow{
+			Period:  localTime.Format("2006-01-02"),
+			ResetAt: time.Date(year, month, day+1, 0, 0, 0, 0, loc),
+		}
+	}
+}
+
+// publishNotification publishes the notification to the user and their event log, delivery is best effort
+func publishNotification(ctx context.Context, redis RedisRepository, userID uint, notification entity.Notification) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return
+	}
+	redis.PublishEvent(ctx, userID, string(payload))
+}
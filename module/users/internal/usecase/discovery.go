@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/users/entity"
+)
+
+type DiscoveryUsecase interface {
+	Discover(ctx context.Context, userID uint) ([]entity.Candidate, error)
+	RecomputeScores(ctx context.Context) error
+}
+
+type DiscoveryUc struct {
+	ranker   Ranker
+	settings entity.Settings
+	redis    RedisRepository
+	db       PostgresRepository
+	logger   *log.Logger
+}
+
+func NewDiscoveryUsecase(ranker Ranker, settings entity.Settings, redis RedisRepository, db PostgresRepository, logger *log.Logger) *DiscoveryUc {
+	return &DiscoveryUc{
+		ranker:   ranker,
+		settings: settings,
+		redis:    redis,
+		db:       db,
+		logger:   logger,
+	}
+}
+
+func (usecase DiscoveryUc) Discover(ctx context.Context, userID uint) ([]entity.Candidate, error) {
+	viewer, err := usecase.db.GetCandidateByID(userID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if viewer == nil || viewer.ID == 0 {
+		return nil, utils.UserNotFoundError(userID)
+	}
+
+	candidates, err := usecase.db.GetCandidates(userID, DISCOVERY_POOL_SIZE)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// passed profiles come back once their cooldown is over, but only to fill a pool that runs thin
+	cooldown := usecase.settings.Policy.PassCooldown(entity.UserTier(viewer.Premium))
+	if len(candidates) < DISCOVERY_LIMIT && cooldown > 0 {
+		passed, err := usecase.db.GetPassedCandidates(userID, cooldown, DISCOVERY_LIMIT-len(candidates))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		candidates = append(candidates, passed...)
+	}
+
+	ranked := usecase.ranker.Rank(*viewer, candidates)
+	// whoever super liked the viewer is always shown first, regardless of the ranker
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].SuperLiked && !ranked[j].SuperLiked
+	})
+	if len(ranked) > DISCOVERY_LIMIT {
+		ranked = ranked[:DISCOVERY_LIMIT]
+	}
+
+	usecase.trackBoostImpressions(ctx, userID, ranked)
+
+	return ranked, nil
+}
+
+// trackBoostImpressions counts the boosted candidates shown to the viewer, a boost gets one impression per viewer
+// however many times it is shown to them. Tracking is best effort
+func (usecase DiscoveryUc) trackBoostImpressions(ctx context.Context, viewerID uint, candidates []entity.Candidate) {
+	boostIDs := []uint{}
+	for _, candidate := range candidates {
+		if !candidate.Boosted || candidate.BoostID == 0 {
+			continue
+		}
+
+		seen := BuildBoostImpressionsRedisKey(candidate.BoostID)
+		added, err := usecase.redis.AddToSet(ctx, seen, fmt.Sprint(viewerID), usecase.settings.Boost.Duration)
+		if err != nil || !added {
+			continue
+		}
+		boostIDs = append(boostIDs, candidate.BoostID)
+	}
+
+	if len(boostIDs) == 0 {
+		return
+	}
+
+	err := usecase.db.IncrementBoostImpressions(boostIDs)
+	if err != nil {
+		return
+	}
+	utils.TimbleBoostEvents.WithLabelValues(BOOST_EVENT_IMPRESSION).Add(float64(len(boostIDs)))
+}
+
+// RecomputeScores applies the reactions made since the last run to the Elo scores. Each batch is saved with its
+// checkpoint, so a failed run resumes where it stopped and a reaction is never counted twice. A reaction changed
+// later is applied again with its new type
+func (usecase DiscoveryUc) RecomputeScores(ctx context.Context) error {
+	after, err := usecase.db.GetScoreCheckpoint()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for {
+		reactions, err := usecase.db.GetReactionsAfter(after, scoreSettleTime, SCORE_RECOMPUTE_BATCH_SIZE)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if len(reactions) == 0 {
+			return nil
+		}
+
+		scores, err := usecase.getReactionScores(reactions)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		scored := map[uint]bool{}
+		applyReactions(scores, scored, reactions)
+
+		result := []entity.UserScore{}
+		for id := range scored {
+			result = append(result, entity.UserScore{UserID: id, EloScore: scores[id]})
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+
+		last := reactions[len(reactions)-1]
+		saved, err := usecase.db.SaveUserScores(result, after, last)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !saved || len(reactions) < SCORE_RECOMPUTE_BATCH_SIZE {
+			return nil
+		}
+		after = last
+	}
+}
+
+// getReactionScores loads the stored scores of the users taking part in the reactions
+func (usecase DiscoveryUc) getReactionScores(reactions []entity.UserReaction) (map[uint]float64, error) {
+	scores := map[uint]float64{}
+	seen := map[uint]bool{}
+	userIDs := []uint{}
+	for _, reaction := range reactions {
+		if reaction.Type == entity.REACTION_TYPE_UNDECIDED {
+			continue
+		}
+		for _, id := range []uint{reaction.UserID, reaction.TargetID} {
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	if len(userIDs) == 0 {
+		return scores, nil
+	}
+
+	result, err := usecase.db.GetUserScores(userIDs)
+	if err != nil {
+		return scores, err
+	}
+	for _, score := range result {
+		scores[score.UserID] = score.EloScore
+	}
+
+	return scores, nil
+}
+
+// applyReactions moves the scores of the reacted users, a user nobody reacted to keeps the default score
+func applyReactions(scores map[uint]float64, scored map[uint]bool, reactions []entity.UserReaction) {
+	score := func(id uint) float64 {
+		if value, ok := scores[id]; ok {
+			return value
+		}
+		return ELO_DEFAULT_SCORE
+	}
+
+	for _, reaction := range reactions {
+		if reaction.Type == entity.REACTION_TYPE_UNDECIDED {
+			continue
+		}
+		liked := reaction.Type.CountsTowardMatch()
+		scores[reaction.TargetID] = UpdateEloScore(score(reaction.UserID), score(reaction.TargetID), liked)
+		scored[reaction.TargetID] = true
+	}
+}
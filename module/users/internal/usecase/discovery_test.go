@@ -0,0 +1,392 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	log "go.uber.org/zap"
+
+	mocksrepo "timble/mocks/module/users/internal_/usecase"
+	"timble/module/users/entity"
+	"timble/module/users/internal/repository"
+	uc "timble/module/users/internal/usecase"
+)
+
+func TestNewDiscoveryUsecase(t *testing.T) {
+	t.Run("new discovery usecase", func(t *testing.T) {
+
+		usecase := uc.NewDiscoveryUsecase(
+			uc.NewDefaultRanker(entity.RankingWeights{}),
+			entity.Settings{},
+			&repository.RedisRepository{},
+			&repository.PostgresRepository{},
+			&log.Logger{},
+		)
+
+		assert.IsType(t, &uc.DiscoveryUc{}, usecase)
+	})
+}
+
+func TestDiscoveryUc_Discover(t *testing.T) {
+	viewer := &entity.Candidate{ID: 1, Username: "testuser"}
+	candidates := []entity.Candidate{
+		{ID: 2, Username: "second"},
+		{ID: 3, Username: "third"},
+	}
+	superLiker := entity.Candidate{ID: 4, Username: "fourth", SuperLiked: true}
+	boosted := entity.Candidate{ID: 5, Username: "fifth", Boosted: true, BoostID: 7}
+	premiumViewer := &entity.Candidate{ID: 1, Username: "testuser", Premium: true}
+	passed := entity.Candidate{ID: 6, Username: "sixth"}
+	manyCandidates := make([]entity.Candidate, uc.DISCOVERY_LIMIT+5)
+
+	type shouldMock struct {
+		dbGetCandidates             bool
+		dbGetPassedCandidates       bool
+		rank                        bool
+		redisAddToSet               bool
+		dbIncrementBoostImpressions bool
+	}
+
+	type mocked struct {
+		dbGetViewerResult           *entity.Candidate
+		dbGetViewerError            error
+		dbGetCandidatesResult       []entity.Candidate
+		dbGetCandidatesError        error
+		passCooldown                time.Duration
+		dbGetPassedCandidatesResult []entity.Candidate
+		dbGetPassedCandidatesError  error
+		rankResult                  []entity.Candidate
+		redisAddToSetResult         bool
+		redisAddToSetError          error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult []entity.Candidate
+		expectedErr    error
+	}{
+		{
+			name: "normal case - successfully rank candidates",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+				rank:                  true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:     viewer,
+				passCooldown:          30 * 24 * time.Hour,
+				dbGetCandidatesResult: candidates,
+				rankResult:            []entity.Candidate{candidates[1], candidates[0]},
+			},
+			expectedResult: []entity.Candidate{candidates[1], candidates[0]},
+		},
+		{
+			name: "normal case - super likers are shown first",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+				rank:                  true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:     viewer,
+				passCooldown:          30 * 24 * time.Hour,
+				dbGetCandidatesResult: append([]entity.Candidate{superLiker}, candidates...),
+				rankResult:            []entity.Candidate{candidates[1], superLiker, candidates[0]},
+			},
+			expectedResult: []entity.Candidate{superLiker, candidates[1], candidates[0]},
+		},
+		{
+			name: "normal case - boosted candidates shown get an impression",
+			shouldMock: shouldMock{
+				dbGetCandidates:             true,
+				dbGetPassedCandidates:       true,
+				rank:                        true,
+				redisAddToSet:               true,
+				dbIncrementBoostImpressions: true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:     viewer,
+				passCooldown:          30 * 24 * time.Hour,
+				dbGetCandidatesResult: append([]entity.Candidate{boosted}, candidates...),
+				rankResult:            []entity.Candidate{boosted, candidates[0], candidates[1]},
+				redisAddToSetResult:   true,
+			},
+			expectedResult: []entity.Candidate{boosted, candidates[0], candidates[1]},
+		},
+		{
+			name: "normal case - boosted candidates already shown to the viewer get no new impression",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+				rank:                  true,
+				redisAddToSet:         true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:     viewer,
+				passCooldown:          30 * 24 * time.Hour,
+				dbGetCandidatesResult: append([]entity.Candidate{boosted}, candidates...),
+				rankResult:            []entity.Candidate{boosted, candidates[0], candidates[1]},
+			},
+			expectedResult: []entity.Candidate{boosted, candidates[0], candidates[1]},
+		},
+		{
+			name: "normal case - impressions are skipped when the viewers of the boost can not be checked",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+				rank:                  true,
+				redisAddToSet:         true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:     viewer,
+				passCooldown:          30 * 24 * time.Hour,
+				dbGetCandidatesResult: append([]entity.Candidate{boosted}, candidates...),
+				rankResult:            []entity.Candidate{boosted, candidates[0], candidates[1]},
+				redisAddToSetError:    errors.New("Error AddToSet"),
+			},
+			expectedResult: []entity.Candidate{boosted, candidates[0], candidates[1]},
+		},
+		{
+			name: "normal case - ranked candidates are capped to the discovery limit",
+			shouldMock: shouldMock{
+				dbGetCandidates: true,
+				rank:            true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:     viewer,
+				dbGetCandidatesResult: manyCandidates,
+				rankResult:            manyCandidates,
+			},
+			expectedResult: manyCandidates[:uc.DISCOVERY_LIMIT],
+		},
+		{
+			name: "normal case - passed profiles resurface when the pool runs thin",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+				rank:                  true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:           viewer,
+				passCooldown:                30 * 24 * time.Hour,
+				dbGetCandidatesResult:       candidates,
+				dbGetPassedCandidatesResult: []entity.Candidate{passed},
+				rankResult:                  []entity.Candidate{candidates[0], passed, candidates[1]},
+			},
+			expectedResult: []entity.Candidate{candidates[0], passed, candidates[1]},
+		},
+		{
+			name: "normal case - passed profiles resurface sooner for premium viewers",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+				rank:                  true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:           premiumViewer,
+				passCooldown:                7 * 24 * time.Hour,
+				dbGetCandidatesResult:       candidates,
+				dbGetPassedCandidatesResult: []entity.Candidate{passed},
+				rankResult:                  []entity.Candidate{passed, candidates[0], candidates[1]},
+			},
+			expectedResult: []entity.Candidate{passed, candidates[0], candidates[1]},
+		},
+		{
+			name: "error case - error when retrieving passed candidates",
+			shouldMock: shouldMock{
+				dbGetCandidates:       true,
+				dbGetPassedCandidates: true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:          viewer,
+				passCooldown:               30 * 24 * time.Hour,
+				dbGetCandidatesResult:      candidates,
+				dbGetPassedCandidatesError: errors.New("Error GetPassedCandidates"),
+			},
+			expectedErr: errors.New("Error GetPassedCandidates"),
+		},
+		{
+			name:        "error case - viewer not found",
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:1; field:"),
+		},
+		{
+			name: "error case - error when retrieving viewer",
+			mocked: mocked{
+				dbGetViewerError: errors.New("Error GetCandidateByID"),
+			},
+			expectedErr: errors.New("Error GetCandidateByID"),
+		},
+		{
+			name: "error case - error when retrieving candidates",
+			shouldMock: shouldMock{
+				dbGetCandidates: true,
+			},
+			mocked: mocked{
+				dbGetViewerResult:    viewer,
+				dbGetCandidatesError: errors.New("Error GetCandidates"),
+			},
+			expectedErr: errors.New("Error GetCandidates"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		ranker := mocksrepo.NewRanker(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetCandidateByID", uint(1)).Return(tc.mocked.dbGetViewerResult, tc.mocked.dbGetViewerError)
+
+			if tc.shouldMock.dbGetCandidates {
+				db.On("GetCandidates", uint(1), uc.DISCOVERY_POOL_SIZE).Return(tc.mocked.dbGetCandidatesResult, tc.mocked.dbGetCandidatesError)
+			}
+
+			if tc.shouldMock.dbGetPassedCandidates {
+				limit := uc.DISCOVERY_LIMIT - len(tc.mocked.dbGetCandidatesResult)
+				db.On("GetPassedCandidates", uint(1), tc.mocked.passCooldown, limit).Return(tc.mocked.dbGetPassedCandidatesResult, tc.mocked.dbGetPassedCandidatesError)
+			}
+
+			if tc.shouldMock.rank {
+				pool := append(append([]entity.Candidate{}, tc.mocked.dbGetCandidatesResult...), tc.mocked.dbGetPassedCandidatesResult...)
+				ranker.On("Rank", *tc.mocked.dbGetViewerResult, pool).Return(tc.mocked.rankResult)
+			}
+
+			if tc.shouldMock.redisAddToSet {
+				redis.On("AddToSet", ctx, uc.BuildBoostImpressionsRedisKey(boosted.BoostID), "1", defaultSettings.Boost.Duration).Return(tc.mocked.redisAddToSetResult, tc.mocked.redisAddToSetError)
+			}
+
+			if tc.shouldMock.dbIncrementBoostImpressions {
+				db.On("IncrementBoostImpressions", []uint{boosted.BoostID}).Return(nil)
+			}
+
+			usecase := uc.NewDiscoveryUsecase(ranker, defaultSettings, redis, db, &log.Logger{})
+
+			result, err := usecase.Discover(ctx, 1)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestDiscoveryUc_RecomputeScores(t *testing.T) {
+	since, _ := time.Parse("1/2/2006", "2/2/2025")
+	checkpoint := entity.UserReaction{UserID: 6, TargetID: 7, UpdatedAt: since}
+	reactions := []entity.UserReaction{
+		{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE, UpdatedAt: since.Add(time.Minute)},
+		{UserID: 3, TargetID: 1, Type: entity.REACTION_TYPE_PASS, UpdatedAt: since.Add(2 * time.Minute)},
+		{UserID: 2, TargetID: 3, Type: entity.REACTION_TYPE_UNDECIDED, UpdatedAt: since.Add(3 * time.Minute)},
+	}
+	storedScores := []entity.UserScore{{UserID: 1, EloScore: 1600}}
+	savedScores := []entity.UserScore{
+		{UserID: 1, EloScore: uc.UpdateEloScore(1500, 1600, false)},
+		{UserID: 2, EloScore: uc.UpdateEloScore(1600, 1500, true)},
+	}
+	fullBatch := []entity.UserReaction{}
+	for i := 0; i < uc.SCORE_RECOMPUTE_BATCH_SIZE; i++ {
+		fullBatch = append(fullBatch, entity.UserReaction{UserID: 4, TargetID: 5, Type: entity.REACTION_TYPE_UNDECIDED, UpdatedAt: since})
+	}
+	lastOfFullBatch := fullBatch[len(fullBatch)-1]
+
+	tests := []struct {
+		name        string
+		mockDbCall  func(db *mocksrepo.PostgresRepository)
+		expectedErr error
+	}{
+		{
+			name: "normal case - successfully apply the reactions since the checkpoint to the stored scores",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(reactions, nil).Once()
+				db.On("GetUserScores", []uint{1, 2, 3}).Return(storedScores, nil)
+				db.On("SaveUserScores", savedScores, checkpoint, reactions[2]).Return(true, nil)
+			},
+		},
+		{
+			name: "normal case - reactions are paged after the last reaction of a full batch",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(fullBatch, nil).Once()
+				db.On("SaveUserScores", []entity.UserScore{}, checkpoint, lastOfFullBatch).Return(true, nil)
+				db.On("GetReactionsAfter", lastOfFullBatch, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(reactions, nil).Once()
+				db.On("GetUserScores", []uint{1, 2, 3}).Return(storedScores, nil)
+				db.On("SaveUserScores", savedScores, lastOfFullBatch, reactions[2]).Return(true, nil)
+			},
+		},
+		{
+			name: "normal case - no new reactions leaves the scores",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return([]entity.UserReaction{}, nil).Once()
+			},
+		},
+		{
+			name: "normal case - another run moved the checkpoint",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(fullBatch, nil).Once()
+				db.On("SaveUserScores", []entity.UserScore{}, checkpoint, lastOfFullBatch).Return(false, nil)
+			},
+		},
+		{
+			name: "error case - error when retrieving the checkpoint",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(entity.UserReaction{}, errors.New("Error GetScoreCheckpoint"))
+			},
+			expectedErr: errors.New("Error GetScoreCheckpoint"),
+		},
+		{
+			name: "error case - error when retrieving reactions",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(nil, errors.New("Error GetReactionsAfter")).Once()
+			},
+			expectedErr: errors.New("Error GetReactionsAfter"),
+		},
+		{
+			name: "error case - error when retrieving stored scores",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(reactions, nil).Once()
+				db.On("GetUserScores", []uint{1, 2, 3}).Return(nil, errors.New("Error GetUserScores"))
+			},
+			expectedErr: errors.New("Error GetUserScores"),
+		},
+		{
+			name: "error case - error when saving scores",
+			mockDbCall: func(db *mocksrepo.PostgresRepository) {
+				db.On("GetScoreCheckpoint").Return(checkpoint, nil)
+				db.On("GetReactionsAfter", checkpoint, time.Minute, uc.SCORE_RECOMPUTE_BATCH_SIZE).Return(reactions, nil).Once()
+				db.On("GetUserScores", []uint{1, 2, 3}).Return(storedScores, nil)
+				db.On("SaveUserScores", savedScores, checkpoint, reactions[2]).Return(false, errors.New("Error SaveUserScores"))
+			},
+			expectedErr: errors.New("Error SaveUserScores"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tc.mockDbCall(db)
+
+			usecase := uc.NewDiscoveryUsecase(uc.NewDefaultRanker(defaultRankingWeights), defaultSettings, redis, db, &log.Logger{})
+
+			err := usecase.RecomputeScores(ctx)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
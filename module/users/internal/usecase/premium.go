@@ -13,43 +13,46 @@ import (
 type PremiumUsecase interface {
 	Grant(ctx context.Context, userID uint) error
 	Unsubscribe(ctx context.Context, userID uint) error
+	ActivateBoost(ctx context.Context, userID uint) (entity.Boost, error)
+	BoostStatus(ctx context.Context, userID uint) (entity.BoostStatus, error)
 }
 
 type PremiumUc struct {
-	db     PostgresRepository
-	redis  RedisRepository
-	cache  CacheRepository
-	logger *log.Logger
+	settings entity.Settings
+	db       PostgresRepository
+	redis    RedisRepository
+	cache    CacheRepository
+	logger   *log.Logger
 }
 
-func NewPremiumUsecase(redis RedisRepository, db PostgresRepository, cache CacheRepository, logger *log.Logger) *PremiumUc {
+func NewPremiumUsecase(settings entity.Settings, redis RedisRepository, db PostgresRepository, cache CacheRepository, logger *log.Logger) *PremiumUc {
 	return &PremiumUc{
-		db:     db,
-		redis:  redis,
-		cache:  cache,
-		logger: logger,
+		settings: settings,
+		db:       db,
+		redis:    redis,
+		cache:    cache,
+		logger:   logger,
 	}
 }
 
+// Grant claims the eligibility of the user before granting premium, so two grants at the same time can not both add
+// the boosts. The eligibility is given back when the grant fails
 func (usecase PremiumUc) Grant(ctx context.Context, userID uint) error {
-	// check if user is eligible for premium
-	eligibleForPremium, err := usecase.redis.Get(ctx, BuildPremiumEligibilityRedisKey(userID))
+	eligibleForPremium, err := usecase.redis.GetDel(ctx, BuildPremiumEligibilityRedisKey(userID))
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	if eligibleForPremium != PREMIUM_TRUE_STRING {
 		return utils.NewStandardError("You are not eligible for premium for now", "NOT ELIGIBLE FOR PREMIUM", "")
 	}
-	userData := entity.User{
-		ID:      userID,
-		Premium: true,
-	}
-	err = usecase.db.UpdateUserPremium(userData, interface{}(userData.Premium))
+
+	err = usecase.db.GrantPremium(userID, usecase.settings.Boost.PremiumGrant)
 	if err != nil {
+		usecase.redis.Set(ctx, BuildPremiumEligibilityRedisKey(userID), PREMIUM_TRUE_STRING, 0)
 		return errors.WithStack(err)
 	}
-	usecase.cache.Set(ctx, BuildPremiumCacheKey(userID), []byte(PREMIUM_TRUE_STRING), premiumExpCache)
-	usecase.redis.Del(ctx, BuildPremiumEligibilityRedisKey(userID))
+	usecase.cache.Set(ctx, BuildPremiumCacheKey(userID), []byte(PREMIUM_TRUE_STRING), usecase.settings.PremiumCacheTTL)
+	usecase.notifyPremium(ctx, userID, true)
 	return nil
 }
 
@@ -62,6 +65,59 @@ func (usecase PremiumUc) Unsubscribe(ctx context.Context, userID uint) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	usecase.cache.Set(ctx, BuildPremiumCacheKey(userID), []byte(PREMIUM_FALSE_STRING), premiumExpCache)
+	usecase.cache.Set(ctx, BuildPremiumCacheKey(userID), []byte(PREMIUM_FALSE_STRING), usecase.settings.PremiumCacheTTL)
+	usecase.notifyPremium(ctx, userID, false)
 	return nil
 }
+
+// notifyPremium tells the other sessions of the user that their premium status changed
+func (usecase PremiumUc) notifyPremium(ctx context.Context, userID uint, premium bool) {
+	publishNotification(ctx, usecase.redis, userID, entity.Notification{
+		Type: entity.NOTIFICATION_TYPE_PREMIUM,
+		Data: entity.PremiumStatus{Premium: premium},
+	})
+}
+
+// ActivateBoost spends one boost from the user's balance and starts it for the configured duration
+func (usecase PremiumUc) ActivateBoost(ctx context.Context, userID uint) (entity.Boost, error) {
+	active, err := usecase.db.GetActiveBoost(userID)
+	if err != nil {
+		return entity.Boost{}, errors.WithStack(err)
+	}
+	if active != nil {
+		return entity.Boost{}, utils.NewStandardError("A boost is already active", "BOOST_ACTIVE", "")
+	}
+
+	boost, err := usecase.db.ActivateBoost(userID, usecase.settings.Boost.Duration)
+	if err != nil {
+		return entity.Boost{}, errors.WithStack(err)
+	}
+	// the balance ran out, or another boost was started in the meantime
+	if boost == nil {
+		return entity.Boost{}, utils.NewStandardError("No boost left", "NO_BOOST_BALANCE", "")
+	}
+
+	utils.TimbleBoostEvents.WithLabelValues(BOOST_EVENT_ACTIVATION).Inc()
+	return *boost, nil
+}
+
+// BoostStatus returns the boosts left, and the active boost with what it gained so far
+func (usecase PremiumUc) BoostStatus(ctx context.Context, userID uint) (entity.BoostStatus, error) {
+	status := entity.BoostStatus{}
+	userData, err := usecase.db.GetUserByID(userID)
+	if err != nil {
+		return status, errors.WithStack(err)
+	}
+
+	if userData == nil || userData.ID == 0 {
+		return status, utils.UserNotFoundError(userID)
+	}
+	status.Balance = userData.BoostBalance
+
+	status.Active, err = usecase.db.GetActiveBoost(userID)
+	if err != nil {
+		return status, errors.WithStack(err)
+	}
+
+	return status, nil
+}
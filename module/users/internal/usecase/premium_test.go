@@ -19,6 +19,7 @@ func TestNewPremiumUsecase(t *testing.T) {
 	t.Run("new premiumh usecase", func(t *testing.T) {
 
 		usecase := uc.NewPremiumUsecase(
+			defaultSettings,
 			&repository.RedisRepository{},
 			&repository.PostgresRepository{},
 			&repository.CacheRepository{},
@@ -30,65 +31,44 @@ func TestNewPremiumUsecase(t *testing.T) {
 }
 
 func TestPremiumUc_Grant(t *testing.T) {
-	type args struct {
-		params   uint
-		dbParams entity.User
-	}
-
 	type mocked struct {
 		redisResult string
 		redisError  error
 		dbError     error
 	}
 	tests := []struct {
-		name        string
-		args        args
-		mocked      mocked
-		expectedErr error
+		name         string
+		premiumGrant int
+		mocked       mocked
+		expectedErr  error
 	}{
 		{
 			name: "normal case - successfully grant premium",
-			args: args{
-				params: 1,
-				dbParams: entity.User{
-					ID:      1,
-					Premium: true,
-				},
-			},
 			mocked: mocked{
 				redisResult: uc.PREMIUM_TRUE_STRING,
 			},
 		},
 		{
-			name: "error case - user not eligible",
-			args: args{
-				params: 1,
+			name:         "normal case - successfully grant premium with boosts",
+			premiumGrant: 2,
+			mocked: mocked{
+				redisResult: uc.PREMIUM_TRUE_STRING,
 			},
+		},
+		{
+			name:        "error case - user not eligible or eligibility already claimed",
 			expectedErr: errors.New("Error on\ncode: NOT ELIGIBLE FOR PREMIUM; error: You are not eligible for premium for now; field:"),
 		},
 		{
 			name: "error case - error from redis",
-			args: args{
-				params: 1,
-				dbParams: entity.User{
-					ID:      1,
-					Premium: true,
-				},
-			},
 			mocked: mocked{
 				redisError: errors.New("Redis error"),
 			},
 			expectedErr: errors.New("Redis error"),
 		},
 		{
-			name: "error case - error from db",
-			args: args{
-				params: 1,
-				dbParams: entity.User{
-					ID:      1,
-					Premium: true,
-				},
-			},
+			name:         "error case - error from db gives the eligibility back",
+			premiumGrant: 2,
 			mocked: mocked{
 				dbError:     errors.New("DB error"),
 				redisResult: uc.PREMIUM_TRUE_STRING,
@@ -103,19 +83,24 @@ func TestPremiumUc_Grant(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 
-			redis.On("Get", ctx, "eligible_for_premium:1").Return(tc.mocked.redisResult, tc.mocked.redisError)
-			if tc.mocked.redisResult == "true" {
-				db.On("UpdateUserPremium", tc.args.dbParams, interface{}(tc.args.dbParams.Premium)).Return(tc.mocked.dbError)
+			redis.On("GetDel", ctx, "eligible_for_premium:1").Return(tc.mocked.redisResult, tc.mocked.redisError)
+			if tc.mocked.redisResult == uc.PREMIUM_TRUE_STRING {
+				db.On("GrantPremium", uint(1), tc.premiumGrant).Return(tc.mocked.dbError)
+			}
+			if tc.mocked.dbError != nil {
+				redis.On("Set", ctx, "eligible_for_premium:1", uc.PREMIUM_TRUE_STRING, time.Duration(0)).Return("OK", nil)
 			}
 
 			if tc.expectedErr == nil {
 				cache.On("Set", ctx, "premium:1", []byte("true"), 24*time.Hour).Return(nil)
-				redis.On("Del", ctx, "eligible_for_premium:1").Return(int64(1), nil)
+				redis.On("PublishEvent", ctx, uint(1), `{"type":"premium","data":{"premium":true}}`).Return("1-0", nil)
 			}
 
-			usecase := uc.NewPremiumUsecase(redis, db, cache, &log.Logger{})
+			settings := defaultSettings
+			settings.Boost.PremiumGrant = tc.premiumGrant
+			usecase := uc.NewPremiumUsecase(settings, redis, db, cache, &log.Logger{})
 
-			err := usecase.Grant(ctx, tc.args.params)
+			err := usecase.Grant(ctx, 1)
 			if tc.expectedErr != nil {
 				assert.NotNil(t, err)
 				assert.Equal(t, tc.expectedErr.Error(), err.Error())
@@ -176,9 +161,10 @@ func TestPremiumUc_Unsubscribe(t *testing.T) {
 			db.On("UpdateUserPremium", tc.args.dbParams, interface{}(tc.args.dbParams.Premium)).Return(tc.mocked.dbError)
 			if tc.expectedErr == nil {
 				cache.On("Set", ctx, "premium:1", []byte("false"), 24*time.Hour).Return(nil)
+				redis.On("PublishEvent", ctx, uint(1), `{"type":"premium","data":{"premium":false}}`).Return("1-0", nil)
 			}
 
-			usecase := uc.NewPremiumUsecase(redis, db, cache, &log.Logger{})
+			usecase := uc.NewPremiumUsecase(defaultSettings, redis, db, cache, &log.Logger{})
 
 			err := usecase.Unsubscribe(ctx, tc.args.params)
 			if tc.expectedErr != nil {
@@ -190,3 +176,183 @@ func TestPremiumUc_Unsubscribe(t *testing.T) {
 		})
 	}
 }
+
+func TestPremiumUc_ActivateBoost(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	boost := &entity.Boost{ID: 7, UserID: 1, StartedAt: timestamp, EndsAt: timestamp.Add(30 * time.Minute)}
+
+	type shouldMock struct {
+		dbActivateBoost bool
+	}
+
+	type mocked struct {
+		dbGetActiveBoostResult *entity.Boost
+		dbGetActiveBoostError  error
+		dbActivateBoostResult  *entity.Boost
+		dbActivateBoostError   error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult entity.Boost
+		expectedErr    error
+	}{
+		{
+			name: "normal case - successfully activate boost",
+			shouldMock: shouldMock{
+				dbActivateBoost: true,
+			},
+			mocked: mocked{
+				dbActivateBoostResult: boost,
+			},
+			expectedResult: *boost,
+		},
+		{
+			name: "error case - boost already active",
+			mocked: mocked{
+				dbGetActiveBoostResult: boost,
+			},
+			expectedErr: errors.New("Error on\ncode: BOOST_ACTIVE; error: A boost is already active; field:"),
+		},
+		{
+			name: "error case - no boost left",
+			shouldMock: shouldMock{
+				dbActivateBoost: true,
+			},
+			expectedErr: errors.New("Error on\ncode: NO_BOOST_BALANCE; error: No boost left; field:"),
+		},
+		{
+			name: "error case - error when get active boost",
+			mocked: mocked{
+				dbGetActiveBoostError: errors.New("DB error"),
+			},
+			expectedErr: errors.New("DB error"),
+		},
+		{
+			name: "error case - error when activate boost",
+			shouldMock: shouldMock{
+				dbActivateBoost: true,
+			},
+			mocked: mocked{
+				dbActivateBoostError: errors.New("DB error"),
+			},
+			expectedErr: errors.New("DB error"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetActiveBoost", uint(1)).Return(tc.mocked.dbGetActiveBoostResult, tc.mocked.dbGetActiveBoostError)
+			if tc.shouldMock.dbActivateBoost {
+				db.On("ActivateBoost", uint(1), 30*time.Minute).Return(tc.mocked.dbActivateBoostResult, tc.mocked.dbActivateBoostError)
+			}
+
+			usecase := uc.NewPremiumUsecase(defaultSettings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.ActivateBoost(ctx, 1)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestPremiumUc_BoostStatus(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	boost := &entity.Boost{ID: 7, UserID: 1, StartedAt: timestamp, EndsAt: timestamp.Add(30 * time.Minute), Impressions: 40, Likes: 6}
+
+	type shouldMock struct {
+		dbGetActiveBoost bool
+	}
+
+	type mocked struct {
+		dbGetUserByIDResult    *entity.User
+		dbGetUserByIDError     error
+		dbGetActiveBoostResult *entity.Boost
+		dbGetActiveBoostError  error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult entity.BoostStatus
+		expectedErr    error
+	}{
+		{
+			name: "normal case - balance with an active boost",
+			shouldMock: shouldMock{
+				dbGetActiveBoost: true,
+			},
+			mocked: mocked{
+				dbGetUserByIDResult:    &entity.User{ID: 1, BoostBalance: 2},
+				dbGetActiveBoostResult: boost,
+			},
+			expectedResult: entity.BoostStatus{Balance: 2, Active: boost},
+		},
+		{
+			name: "normal case - balance without an active boost",
+			shouldMock: shouldMock{
+				dbGetActiveBoost: true,
+			},
+			mocked: mocked{
+				dbGetUserByIDResult: &entity.User{ID: 1, BoostBalance: 1},
+			},
+			expectedResult: entity.BoostStatus{Balance: 1},
+		},
+		{
+			name:        "error case - user not found",
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:1; field:"),
+		},
+		{
+			name: "error case - error when get user",
+			mocked: mocked{
+				dbGetUserByIDError: errors.New("DB error"),
+			},
+			expectedErr: errors.New("DB error"),
+		},
+		{
+			name: "error case - error when get active boost",
+			shouldMock: shouldMock{
+				dbGetActiveBoost: true,
+			},
+			mocked: mocked{
+				dbGetUserByIDResult:   &entity.User{ID: 1, BoostBalance: 1},
+				dbGetActiveBoostError: errors.New("DB error"),
+			},
+			expectedErr: errors.New("DB error"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetUserByID", uint(1)).Return(tc.mocked.dbGetUserByIDResult, tc.mocked.dbGetUserByIDError)
+			if tc.shouldMock.dbGetActiveBoost {
+				db.On("GetActiveBoost", uint(1)).Return(tc.mocked.dbGetActiveBoostResult, tc.mocked.dbGetActiveBoostError)
+			}
+
+			usecase := uc.NewPremiumUsecase(defaultSettings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.BoostStatus(ctx, 1)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
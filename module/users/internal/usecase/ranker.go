@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"timble/module/users/entity"
+)
+
+// Ranker orders discovery candidates for a viewer, the most relevant first
+type Ranker interface {
+	Rank(viewer entity.Candidate, candidates []entity.Candidate) []entity.Candidate
+}
+
+// DefaultRanker combines desirability (Elo), activity recency, profile completeness and distance,
+// boosted candidates get a fixed bonus on top
+type DefaultRanker struct {
+	weights entity.RankingWeights
+}
+
+func NewDefaultRanker(weights entity.RankingWeights) *DefaultRanker {
+	return &DefaultRanker{
+		weights: weights,
+	}
+}
+
+func (ranker DefaultRanker) Rank(viewer entity.Candidate, candidates []entity.Candidate) []entity.Candidate {
+	ranked := make([]entity.Candidate, len(candidates))
+	copy(ranked, candidates)
+	for i := range ranked {
+		ranked[i].Score = ranker.Score(viewer, ranked[i])
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// Score returns the weighted sum of the candidate signals, each normalized to the 0..1 range
+func (ranker DefaultRanker) Score(viewer entity.Candidate, candidate entity.Candidate) float64 {
+	// a default Elo score maps to 0.5, every 400 points doubles the odds
+	desirability := 1 / (1 + math.Pow(10, (ELO_DEFAULT_SCORE-candidate.EloScore)/400))
+	score := ranker.weights.Elo * desirability
+
+	if ranker.weights.RecencyHalfLife > 0 && !candidate.LastActiveAt.IsZero() {
+		idle := time.Since(candidate.LastActiveAt)
+		if idle < 0 {
+			idle = 0
+		}
+		score += ranker.weights.Recency * math.Pow(0.5, float64(idle)/float64(ranker.weights.RecencyHalfLife))
+	}
+
+	score += ranker.weights.Completeness * candidate.Completeness()
+
+	if distance, ok := viewer.DistanceKm(candidate); ok && ranker.weights.DistanceScaleKm > 0 {
+		score += ranker.weights.Distance / (1 + distance/ranker.weights.DistanceScaleKm)
+	}
+
+	if candidate.Boosted {
+		score += ranker.weights.Boost
+	}
+
+	return score
+}
+
+// UpdateEloScore returns the new target score after the reactor liked or passed them
+func UpdateEloScore(reactorScore, targetScore float64, liked bool) float64 {
+	expected := 1 / (1 + math.Pow(10, (reactorScore-targetScore)/400))
+	actual := 0.0
+	if liked {
+		actual = 1
+	}
+	return targetScore + ELO_K_FACTOR*(actual-expected)
+}
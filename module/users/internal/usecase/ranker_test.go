@@ -0,0 +1,149 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"timble/module/users/entity"
+	uc "timble/module/users/internal/usecase"
+)
+
+var (
+	defaultRankingWeights = entity.RankingWeights{
+		Elo:             0.4,
+		Recency:         0.3,
+		Completeness:    0.1,
+		Distance:        0.2,
+		RecencyHalfLife: 72 * time.Hour,
+		DistanceScaleKm: 25,
+	}
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestNewDefaultRanker(t *testing.T) {
+	t.Run("new default ranker", func(t *testing.T) {
+		ranker := uc.NewDefaultRanker(defaultRankingWeights)
+
+		assert.IsType(t, &uc.DefaultRanker{}, ranker)
+	})
+}
+
+func TestDefaultRanker_Score(t *testing.T) {
+	now := time.Now()
+	viewer := entity.Candidate{ID: 1, Latitude: floatPtr(-6.2088), Longitude: floatPtr(106.8456)}
+
+	tests := []struct {
+		name           string
+		weights        entity.RankingWeights
+		candidate      entity.Candidate
+		expectedResult float64
+	}{
+		{
+			name:           "default elo score only",
+			weights:        entity.RankingWeights{Elo: 1},
+			candidate:      entity.Candidate{EloScore: uc.ELO_DEFAULT_SCORE},
+			expectedResult: 0.5,
+		},
+		{
+			name:           "just active candidate",
+			weights:        entity.RankingWeights{Recency: 1, RecencyHalfLife: time.Hour},
+			candidate:      entity.Candidate{LastActiveAt: now.Add(time.Minute)},
+			expectedResult: 1,
+		},
+		{
+			name:           "candidate idle for one half life",
+			weights:        entity.RankingWeights{Recency: 1, RecencyHalfLife: time.Hour},
+			candidate:      entity.Candidate{LastActiveAt: now.Add(-time.Hour)},
+			expectedResult: 0.5,
+		},
+		{
+			name:           "complete profile at the same location",
+			weights:        entity.RankingWeights{Completeness: 1, Distance: 1, DistanceScaleKm: 25},
+			candidate:      entity.Candidate{Bio: "hi", PhotoURL: "photo", Latitude: viewer.Latitude, Longitude: viewer.Longitude},
+			expectedResult: 2,
+		},
+		{
+			name:           "boosted candidate gets the boost weight",
+			weights:        entity.RankingWeights{Elo: 1, Boost: 0.5},
+			candidate:      entity.Candidate{EloScore: uc.ELO_DEFAULT_SCORE, Boosted: true},
+			expectedResult: 1,
+		},
+		{
+			name:           "unknown distance and activity are ignored",
+			weights:        defaultRankingWeights,
+			candidate:      entity.Candidate{EloScore: uc.ELO_DEFAULT_SCORE},
+			expectedResult: 0.2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ranker := uc.NewDefaultRanker(tc.weights)
+
+			assert.InDelta(t, tc.expectedResult, ranker.Score(viewer, tc.candidate), 0.01)
+		})
+	}
+}
+
+func TestDefaultRanker_Rank(t *testing.T) {
+	now := time.Now()
+	viewer := entity.Candidate{ID: 1}
+	candidates := []entity.Candidate{
+		{ID: 2, EloScore: 1400, LastActiveAt: now.Add(-24 * time.Hour)},
+		{ID: 3, EloScore: 1700, LastActiveAt: now},
+		{ID: 4, EloScore: 1500, LastActiveAt: now.Add(-time.Hour)},
+	}
+
+	t.Run("candidates are ordered by score", func(t *testing.T) {
+		ranker := uc.NewDefaultRanker(defaultRankingWeights)
+
+		result := ranker.Rank(viewer, candidates)
+
+		assert.Equal(t, []uint{3, 4, 2}, []uint{result[0].ID, result[1].ID, result[2].ID})
+		assert.Greater(t, result[0].Score, result[1].Score)
+		assert.Greater(t, result[1].Score, result[2].Score)
+		// the input is left untouched
+		assert.Equal(t, uint(2), candidates[0].ID)
+	})
+}
+
+func TestUpdateEloScore(t *testing.T) {
+	tests := []struct {
+		name           string
+		reactorScore   float64
+		targetScore    float64
+		liked          bool
+		expectedResult float64
+	}{
+		{
+			name:           "like between equal scores",
+			reactorScore:   1500,
+			targetScore:    1500,
+			liked:          true,
+			expectedResult: 1516,
+		},
+		{
+			name:           "pass between equal scores",
+			reactorScore:   1500,
+			targetScore:    1500,
+			liked:          false,
+			expectedResult: 1484,
+		},
+		{
+			name:           "like from a much more desirable reactor",
+			reactorScore:   1900,
+			targetScore:    1500,
+			liked:          true,
+			expectedResult: 1529.09,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expectedResult, uc.UpdateEloScore(tc.reactorScore, tc.targetScore, tc.liked), 0.01)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	"timble/module/users/entity"
+)
+
+type ReportUsecase interface {
+	Report(ctx context.Context, params entity.ReportParams) (*entity.Report, error)
+	Queue(ctx context.Context, params entity.ReportQueueParams) ([]entity.ReportQueueItem, utils.Page, error)
+	Claim(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error)
+	Resolve(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error)
+	Dismiss(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error)
+}
+
+type ReportUc struct {
+	settings entity.Settings
+	db       PostgresRepository
+	logger   *log.Logger
+}
+
+func NewReportUsecase(settings entity.Settings, db PostgresRepository, logger *log.Logger) *ReportUc {
+	return &ReportUc{
+		settings: settings,
+		db:       db,
+		logger:   logger,
+	}
+}
+
+// Report saves the report of a user, reporting the same user again while the report is open changes nothing
+func (usecase ReportUc) Report(ctx context.Context, params entity.ReportParams) (*entity.Report, error) {
+	reportedUserData, err := usecase.db.GetUserByID(params.ReportedID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if reportedUserData == nil || reportedUserData.ID == 0 {
+		return nil, utils.UserNotFoundError(params.ReportedID)
+	}
+
+	report, err := usecase.db.InsertReport(params, usecase.settings.Reports.HideThreshold)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return report, nil
+}
+
+// Queue lists the reports with the status to a moderator, the latest report first
+func (usecase ReportUc) Queue(ctx context.Context, params entity.ReportQueueParams) ([]entity.ReportQueueItem, utils.Page, error) {
+	err := usecase.checkModerator(params.ModeratorID)
+	if err != nil {
+		return nil, utils.Page{}, err
+	}
+
+	reports, err := usecase.db.GetReports(params)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
+	}
+
+	reports, page := utils.NewPage(reports, params.Page.Limit, func(report entity.ReportQueueItem) utils.Cursor {
+		return utils.Cursor{CreatedAt: report.CreatedAt, ID: report.ID}
+	})
+
+	return reports, page, nil
+}
+
+// Claim assigns a pending report to the moderator, claiming it again renews the claim
+func (usecase ReportUc) Claim(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error) {
+	err := usecase.checkModerator(params.ModeratorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := usecase.db.ClaimReport(params, usecase.settings.Reports.ClaimTTL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if report == nil {
+		return nil, utils.NewStandardError("Report not found or claimed by another moderator", "NOT FOUND", "")
+	}
+
+	return report, nil
+}
+
+// Resolve closes a report the moderator acted on, the reported user stays hidden if they were
+func (usecase ReportUc) Resolve(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error) {
+	return usecase.close(params, entity.REPORT_STATUS_RESOLVED)
+}
+
+// Dismiss closes a report found groundless, it stops counting towards hiding the reported user
+func (usecase ReportUc) Dismiss(ctx context.Context, params entity.ReportActionParams) (*entity.Report, error) {
+	return usecase.close(params, entity.REPORT_STATUS_DISMISSED)
+}
+
+func (usecase ReportUc) close(params entity.ReportActionParams, status string) (*entity.Report, error) {
+	err := usecase.checkModerator(params.ModeratorID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := usecase.db.CloseReport(params, status, usecase.settings.Reports.HideThreshold)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if report == nil {
+		return nil, utils.NewStandardError("Report not found or not claimed by you", "NOT FOUND", "")
+	}
+
+	return report, nil
+}
+
+// checkModerator refuses users who are not moderators, moderators are flagged in the users table
+func (usecase ReportUc) checkModerator(userID uint) error {
+	userData, err := usecase.db.GetUserByID(userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if userData == nil || !userData.Moderator {
+		return &utils.StandardError{
+			Message:    "Only moderators can review reports",
+			Code:       "FORBIDDEN",
+			HttpStatus: http.StatusForbidden,
+		}
+	}
+
+	return nil
+}
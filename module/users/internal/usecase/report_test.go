@@ -0,0 +1,387 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	log "go.uber.org/zap"
+
+	"timble/internal/utils"
+	mocksrepo "timble/mocks/module/users/internal_/usecase"
+	"timble/module/users/entity"
+	"timble/module/users/internal/repository"
+	uc "timble/module/users/internal/usecase"
+)
+
+var (
+	testModerator = &entity.User{ID: 9, Username: "moderator", Moderator: true}
+	errForbidden  = errors.New("Error on\ncode: FORBIDDEN; error: Only moderators can review reports; field:")
+)
+
+func TestNewReportUsecase(t *testing.T) {
+	t.Run("new report usecase", func(t *testing.T) {
+		usecase := uc.NewReportUsecase(
+			defaultSettings,
+			&repository.PostgresRepository{},
+			&log.Logger{},
+		)
+
+		assert.IsType(t, &uc.ReportUc{}, usecase)
+	})
+}
+
+func TestReportUc_Report(t *testing.T) {
+	params := entity.ReportParams{ReporterID: 1, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM}
+	report := &entity.Report{ID: 5, ReporterID: 1, ReportedID: 2, Reason: entity.REPORT_REASON_SPAM, Status: entity.REPORT_STATUS_PENDING, Created: true}
+
+	type shouldMock struct {
+		dbInsertReport bool
+	}
+	type mocked struct {
+		dbGetUserResult      *entity.User
+		dbGetUserError       error
+		dbInsertReportResult *entity.Report
+		dbInsertReportError  error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult *entity.Report
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - report saved",
+			shouldMock: shouldMock{dbInsertReport: true},
+			mocked: mocked{
+				dbGetUserResult:      &entity.User{ID: 2},
+				dbInsertReportResult: report,
+			},
+			expectedResult: report,
+		},
+		{
+			name: "error case - reported user not found",
+			mocked: mocked{
+				dbGetUserResult: &entity.User{},
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - error when getting the reported user",
+			mocked: mocked{
+				dbGetUserError: errors.New("Error GetUserByID"),
+			},
+			expectedErr: errors.New("Error GetUserByID"),
+		},
+		{
+			name:       "error case - error when inserting the report",
+			shouldMock: shouldMock{dbInsertReport: true},
+			mocked: mocked{
+				dbGetUserResult:     &entity.User{ID: 2},
+				dbInsertReportError: errors.New("Error InsertReport"),
+			},
+			expectedErr: errors.New("Error InsertReport"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetUserByID", uint(2)).Return(tc.mocked.dbGetUserResult, tc.mocked.dbGetUserError)
+			if tc.shouldMock.dbInsertReport {
+				db.On("InsertReport", params, 3).Return(tc.mocked.dbInsertReportResult, tc.mocked.dbInsertReportError)
+			}
+
+			usecase := uc.NewReportUsecase(defaultSettings, db, &log.Logger{})
+
+			result, err := usecase.Report(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestReportUc_Queue(t *testing.T) {
+	timestamp := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	params := entity.ReportQueueParams{
+		ModeratorID: 9,
+		Status:      entity.REPORT_STATUS_PENDING,
+		Page:        utils.PageParams{Limit: 2},
+	}
+	reports := []entity.ReportQueueItem{
+		{Report: entity.Report{ID: 7, CreatedAt: timestamp.Add(2 * time.Minute)}, Reporters: 1},
+		{Report: entity.Report{ID: 6, CreatedAt: timestamp.Add(time.Minute)}, Reporters: 3, ReportedHidden: true},
+		{Report: entity.Report{ID: 5, CreatedAt: timestamp}, Reporters: 3, ReportedHidden: true},
+	}
+
+	type shouldMock struct {
+		dbGetReports bool
+	}
+	type mocked struct {
+		dbGetUserResult    *entity.User
+		dbGetUserError     error
+		dbGetReportsResult []entity.ReportQueueItem
+		dbGetReportsError  error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult []entity.ReportQueueItem
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - page with more results",
+			shouldMock: shouldMock{dbGetReports: true},
+			mocked: mocked{
+				dbGetUserResult:    testModerator,
+				dbGetReportsResult: reports,
+			},
+			expectedResult: reports[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: reports[1].CreatedAt, ID: 6},
+			},
+		},
+		{
+			name:       "normal case - last page",
+			shouldMock: shouldMock{dbGetReports: true},
+			mocked: mocked{
+				dbGetUserResult:    testModerator,
+				dbGetReportsResult: reports[2:],
+			},
+			expectedResult: reports[2:],
+		},
+		{
+			name: "error case - not a moderator",
+			mocked: mocked{
+				dbGetUserResult: testUser,
+			},
+			expectedErr: errForbidden,
+		},
+		{
+			name: "error case - error when getting the moderator",
+			mocked: mocked{
+				dbGetUserError: errors.New("Error GetUserByID"),
+			},
+			expectedErr: errors.New("Error GetUserByID"),
+		},
+		{
+			name:       "error case - error when getting the reports",
+			shouldMock: shouldMock{dbGetReports: true},
+			mocked: mocked{
+				dbGetUserResult:   testModerator,
+				dbGetReportsError: errors.New("Error GetReports"),
+			},
+			expectedErr: errors.New("Error GetReports"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetUserByID", uint(9)).Return(tc.mocked.dbGetUserResult, tc.mocked.dbGetUserError)
+			if tc.shouldMock.dbGetReports {
+				db.On("GetReports", params).Return(tc.mocked.dbGetReportsResult, tc.mocked.dbGetReportsError)
+			}
+
+			usecase := uc.NewReportUsecase(defaultSettings, db, &log.Logger{})
+
+			result, page, err := usecase.Queue(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestReportUc_Claim(t *testing.T) {
+	params := entity.ReportActionParams{ReportID: 5, ModeratorID: 9}
+	report := &entity.Report{ID: 5, Status: entity.REPORT_STATUS_CLAIMED}
+
+	type shouldMock struct {
+		dbClaimReport bool
+	}
+	type mocked struct {
+		dbGetUserResult     *entity.User
+		dbGetUserError      error
+		dbClaimReportResult *entity.Report
+		dbClaimReportError  error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult *entity.Report
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - report claimed",
+			shouldMock: shouldMock{dbClaimReport: true},
+			mocked: mocked{
+				dbGetUserResult:     testModerator,
+				dbClaimReportResult: report,
+			},
+			expectedResult: report,
+		},
+		{
+			name:       "error case - report claimed by another moderator",
+			shouldMock: shouldMock{dbClaimReport: true},
+			mocked: mocked{
+				dbGetUserResult: testModerator,
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: Report not found or claimed by another moderator; field:"),
+		},
+		{
+			name: "error case - not a moderator",
+			mocked: mocked{
+				dbGetUserResult: testUser,
+			},
+			expectedErr: errForbidden,
+		},
+		{
+			name:       "error case - error when claiming",
+			shouldMock: shouldMock{dbClaimReport: true},
+			mocked: mocked{
+				dbGetUserResult:    testModerator,
+				dbClaimReportError: errors.New("Error ClaimReport"),
+			},
+			expectedErr: errors.New("Error ClaimReport"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetUserByID", uint(9)).Return(tc.mocked.dbGetUserResult, tc.mocked.dbGetUserError)
+			if tc.shouldMock.dbClaimReport {
+				db.On("ClaimReport", params, 30*time.Minute).Return(tc.mocked.dbClaimReportResult, tc.mocked.dbClaimReportError)
+			}
+
+			usecase := uc.NewReportUsecase(defaultSettings, db, &log.Logger{})
+
+			result, err := usecase.Claim(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestReportUc_Close(t *testing.T) {
+	params := entity.ReportActionParams{ReportID: 5, ModeratorID: 9, Note: "warned"}
+
+	type shouldMock struct {
+		dbCloseReport bool
+	}
+	type mocked struct {
+		dbGetUserResult     *entity.User
+		dbCloseReportResult *entity.Report
+		dbCloseReportError  error
+	}
+	tests := []struct {
+		name           string
+		status         string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult *entity.Report
+		expectedErr    error
+	}{
+		{
+			name:       "normal case - report resolved",
+			status:     entity.REPORT_STATUS_RESOLVED,
+			shouldMock: shouldMock{dbCloseReport: true},
+			mocked: mocked{
+				dbGetUserResult:     testModerator,
+				dbCloseReportResult: &entity.Report{ID: 5, Status: entity.REPORT_STATUS_RESOLVED},
+			},
+			expectedResult: &entity.Report{ID: 5, Status: entity.REPORT_STATUS_RESOLVED},
+		},
+		{
+			name:       "normal case - report dismissed",
+			status:     entity.REPORT_STATUS_DISMISSED,
+			shouldMock: shouldMock{dbCloseReport: true},
+			mocked: mocked{
+				dbGetUserResult:     testModerator,
+				dbCloseReportResult: &entity.Report{ID: 5, Status: entity.REPORT_STATUS_DISMISSED},
+			},
+			expectedResult: &entity.Report{ID: 5, Status: entity.REPORT_STATUS_DISMISSED},
+		},
+		{
+			name:       "error case - report not claimed by the moderator",
+			status:     entity.REPORT_STATUS_RESOLVED,
+			shouldMock: shouldMock{dbCloseReport: true},
+			mocked: mocked{
+				dbGetUserResult: testModerator,
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: Report not found or not claimed by you; field:"),
+		},
+		{
+			name:   "error case - not a moderator",
+			status: entity.REPORT_STATUS_DISMISSED,
+			mocked: mocked{
+				dbGetUserResult: testUser,
+			},
+			expectedErr: errForbidden,
+		},
+		{
+			name:       "error case - error when closing",
+			status:     entity.REPORT_STATUS_DISMISSED,
+			shouldMock: shouldMock{dbCloseReport: true},
+			mocked: mocked{
+				dbGetUserResult:    testModerator,
+				dbCloseReportError: errors.New("Error CloseReport"),
+			},
+			expectedErr: errors.New("Error CloseReport"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetUserByID", uint(9)).Return(tc.mocked.dbGetUserResult, nil)
+			if tc.shouldMock.dbCloseReport {
+				db.On("CloseReport", params, tc.status, 3).Return(tc.mocked.dbCloseReportResult, tc.mocked.dbCloseReportError)
+			}
+
+			usecase := uc.NewReportUsecase(defaultSettings, db, &log.Logger{})
+
+			var result *entity.Report
+			var err error
+			if tc.status == entity.REPORT_STATUS_RESOLVED {
+				result, err = usecase.Resolve(ctx, params)
+			} else {
+				result, err = usecase.Dismiss(ctx, params)
+			}
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand/v2"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	log "go.uber.org/zap"
@@ -16,24 +20,38 @@ import (
 type UserUsecase interface {
 	Create(ctx context.Context, params entity.UserRegistrationParams) (entity.UserToken, error)
 	Show(ctx context.Context, userID uint) (*entity.UserPublic, error)
-	React(ctx context.Context, params entity.ReactionParams) error
+	UpdateTimezone(ctx context.Context, params entity.UserTimezoneParams) (*entity.UserPublic, error)
+	React(ctx context.Context, params entity.ReactionParams) (entity.ReactionResult, error)
+	ReactBatch(ctx context.Context, params entity.BatchReactionParams) ([]entity.BatchReactionResult, error)
+	UndoReaction(ctx context.Context, userID uint) (*entity.UserReaction, error)
+	ReactionHistory(ctx context.Context, params entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, utils.Page, error)
+	ReceivedLikes(ctx context.Context, params entity.ReceivedLikesParams) (entity.ReceivedLikes, utils.Page, error)
+	Quota(ctx context.Context, userID uint) (entity.QuotaSummary, error)
+	Matches(ctx context.Context, params entity.MatchListParams) ([]entity.MatchListItem, utils.Page, error)
+	Unmatch(ctx context.Context, params entity.UnmatchParams) error
+	Profile(ctx context.Context, userID, targetID uint) (*entity.UserProfile, error)
+	Block(ctx context.Context, params entity.BlockParams) error
+	Unblock(ctx context.Context, params entity.BlockParams) error
+	Blocks(ctx context.Context, params entity.BlockListParams) ([]entity.BlockListItem, utils.Page, error)
 }
 
 type UserUc struct {
-	auth   *utils.AuthConfig
-	cache  CacheRepository
-	redis  RedisRepository
-	db     PostgresRepository
-	logger *log.Logger
+	auth     *utils.AuthConfig
+	settings entity.Settings
+	cache    CacheRepository
+	redis    RedisRepository
+	db       PostgresRepository
+	logger   *log.Logger
 }
 
-func NewUserUsecase(auth *utils.AuthConfig, redis RedisRepository, db PostgresRepository, cache CacheRepository, logger *log.Logger) *UserUc {
+func NewUserUsecase(auth *utils.AuthConfig, settings entity.Settings, redis RedisRepository, db PostgresRepository, cache CacheRepository, logger *log.Logger) *UserUc {
 	return &UserUc{
-		auth:   auth,
-		redis:  redis,
-		db:     db,
-		cache:  cache,
-		logger: logger,
+		auth:     auth,
+		settings: settings,
+		redis:    redis,
+		db:       db,
+		cache:    cache,
+		logger:   logger,
 	}
 }
 
@@ -43,6 +61,7 @@ func (usecase UserUc) Create(ctx context.Context, params entity.UserRegistration
 	userData := entity.User{
 		Username:       params.Username,
 		Email:          params.Email,
+		Timezone:       params.Timezone,
 		HashedPassword: string(bytes),
 	}
 
@@ -70,40 +89,372 @@ func (usecase UserUc) Show(ctx context.Context, userID uint) (*entity.UserPublic
 	}
 
 	userPublicData := &entity.UserPublic{
-		ID:        userData.ID,
-		Username:  userData.Username,
-		Email:     userData.Email,
-		Premium:   userData.Premium,
-		CreatedAt: userData.CreatedAt,
-		UpdatedAt: userData.UpdatedAt,
+		ID:           userData.ID,
+		Username:     userData.Username,
+		Email:        userData.Email,
+		Premium:      userData.Premium,
+		Timezone:     userData.Timezone,
+		QuotaResetAt: NewQuotaWindow(dailyQuotaPolicy, time.Now(), entity.LoadTimezone(userData.Timezone)).ResetAt,
+		CreatedAt:    userData.CreatedAt,
+		UpdatedAt:    userData.UpdatedAt,
 	}
 
 	return userPublicData, nil
 }
 
-func (usecase UserUc) React(ctx context.Context, params entity.ReactionParams) error {
-	// check premium status
-	isPremiumBytes, err := usecase.cache.Get(ctx, BuildPremiumCacheKey(params.UserID))
-	isPremiumStr := string(isPremiumBytes)
-	isPremium := isPremiumStr == PREMIUM_TRUE_STRING
-	if err != nil || isPremiumStr == "" {
-		// check in db
-		userData, err := usecase.db.GetUserByID(params.UserID)
+// UpdateTimezone moves the user's daily quota resets to the new local midnight, the cached timezone is dropped
+// so the next quota check reads it again
+func (usecase UserUc) UpdateTimezone(ctx context.Context, params entity.UserTimezoneParams) (*entity.UserPublic, error) {
+	err := usecase.db.UpdateUserTimezone(params)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	usecase.cache.Delete(ctx, BuildTimezoneCacheKey(params.UserID))
+
+	return usecase.Show(ctx, params.UserID)
+}
+
+// React saves the reaction and tells whether it made a match,
+// it also returns the most used quota the reaction type is charged to
+func (usecase UserUc) React(ctx context.Context, params entity.ReactionParams) (entity.ReactionResult, error) {
+	owner, err := usecase.quotaOwner(ctx, params.UserID)
+	if err != nil {
+		return entity.ReactionResult{}, err
+	}
+
+	targetUserData, err := usecase.db.GetUserByID(params.TargetID)
+	if err != nil {
+		return entity.ReactionResult{}, errors.WithStack(err)
+	}
+
+	if targetUserData == nil || targetUserData.ID == 0 || targetUserData.HiddenAt != nil {
+		return entity.ReactionResult{}, utils.UserNotFoundError(params.TargetID)
+	}
+
+	// a blocked pair can not see each other, so the target is not found either way
+	blocked, err := usecase.isBlocked(ctx, params.UserID, params.TargetID)
+	if err != nil {
+		return entity.ReactionResult{}, err
+	}
+
+	if blocked {
+		return entity.ReactionResult{}, utils.UserNotFoundError(params.TargetID)
+	}
+
+	// re-reacting to the same target is not charged again for what the previous reaction already paid
+	previous, err := usecase.db.GetUserReaction(params.UserID, params.TargetID)
+	if err != nil {
+		return entity.ReactionResult{}, errors.WithStack(err)
+	}
+
+	var previousType *entity.ReactionType
+	if previous != nil {
+		previousType = &previous.Type
+	}
+
+	quotas := usecase.chargeableQuotas(owner, params.Type, previousType)
+	err = usecase.reserveQuotas(ctx, quotas)
+	if err != nil {
+		return entity.NewReactionResult(nil, usecase.rateLimit(ctx, owner, params.Type)), err
+	}
+
+	match, err := usecase.db.UpsertUserReaction(params)
+	if err != nil {
+		usecase.refundQuotas(ctx, quotas)
+		return entity.NewReactionResult(nil, usecase.rateLimit(ctx, owner, params.Type)), errors.WithStack(err)
+	}
+	usecase.trackBoostLike(params, previousType)
+
+	usecase.notifyReaction(ctx, params, previousType, match)
+
+	return entity.NewReactionResult(match, usecase.rateLimit(ctx, owner, params.Type)), nil
+}
+
+// ReactBatch replays reactions queued offline in the order they were made. Items are charged one by one
+// and the ones over quota or invalid are rejected, the accepted ones are then saved in a single transaction.
+// Results follow the order of the request
+func (usecase UserUc) ReactBatch(ctx context.Context, params entity.BatchReactionParams) ([]entity.BatchReactionResult, error) {
+	owner, err := usecase.quotaOwner(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := usecase.blockedUsers(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, len(params.Reactions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return params.Reactions[order[a]].ReactedAt.Before(params.Reactions[order[b]].ReactedAt)
+	})
+
+	results := make([]entity.BatchReactionResult, len(params.Reactions))
+	reactions := []entity.ReactionParams{}
+	resultIndexes := []int{}
+	previousTypes := []*entity.ReactionType{}
+	reserved := []quota{}
+	// targets reacted to earlier in the batch, with the type the next reaction replaces
+	replaced := map[uint]*entity.ReactionType{}
+	for _, i := range order {
+		item := params.Reactions[i]
+		results[i] = entity.BatchReactionResult{
+			TargetID:  item.TargetID,
+			Type:      item.Type,
+			ReactedAt: item.ReactedAt,
+			Status:    entity.BATCH_REACTION_STATUS_REJECTED,
+		}
+
+		reaction := item.Reaction(params.UserID)
+		var previousType *entity.ReactionType
+		err := item.Validate(params.UserID)
+		if err == nil {
+			previousType, err = usecase.reserveBatchReaction(ctx, owner, reaction, blocked, replaced, &reserved)
+		}
+
 		if err != nil {
-			return errors.WithStack(err)
+			standardErr, ok := err.(*utils.StandardError)
+			if !ok {
+				usecase.refundQuotas(ctx, reserved)
+				return nil, err
+			}
+			results[i].Error = standardErr
+			continue
 		}
-		usecase.cache.Set(ctx, BuildPremiumCacheKey(params.UserID), []byte(fmt.Sprintf("%t", userData.Premium)), premiumExpCache)
-		isPremium = userData.Premium
+
+		results[i].Status = entity.BATCH_REACTION_STATUS_APPLIED
+		reactions = append(reactions, reaction)
+		resultIndexes = append(resultIndexes, i)
+		previousTypes = append(previousTypes, previousType)
+	}
+
+	matches := []*entity.Match{}
+	if len(reactions) > 0 {
+		matches, err = usecase.db.UpsertUserReactions(reactions)
+		if err != nil {
+			usecase.refundQuotas(ctx, reserved)
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	for i, reaction := range reactions {
+		usecase.trackBoostLike(reaction, previousTypes[i])
+		usecase.notifyReaction(ctx, reaction, previousTypes[i], matches[i])
+
+		if match := matches[i]; match != nil {
+			results[resultIndexes[i]].Matched = true
+			results[resultIndexes[i]].MatchID = match.ID
+		}
+	}
+
+	return results, nil
+}
+
+// reserveBatchReaction charges a batch item and returns the type it replaces,
+// a reaction replacing one made earlier in the batch is charged like it would be after the previous one was saved
+func (usecase UserUc) reserveBatchReaction(ctx context.Context, owner quotaOwner, reaction entity.ReactionParams, blocked map[uint]bool, replaced map[uint]*entity.ReactionType, reserved *[]quota) (*entity.ReactionType, error) {
+	previousType, seen := replaced[reaction.TargetID]
+	if !seen {
+		targetUserData, err := usecase.db.GetUserByID(reaction.TargetID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if targetUserData == nil || targetUserData.ID == 0 || targetUserData.HiddenAt != nil || blocked[reaction.TargetID] {
+			return nil, utils.UserNotFoundError(reaction.TargetID)
+		}
+
+		previous, err := usecase.db.GetUserReaction(reaction.UserID, reaction.TargetID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if previous != nil {
+			previousType = &previous.Type
+		}
+	}
+
+	quotas := usecase.chargeableQuotas(owner, reaction.Type, previousType)
+	err := usecase.reserveQuotas(ctx, quotas)
+	if err != nil {
+		return nil, err
+	}
+
+	*reserved = append(*reserved, quotas...)
+	replaced[reaction.TargetID] = &reaction.Type
+	return previousType, nil
+}
+
+// UndoReaction reverts the caller's latest reaction within the rewind window and gives back its quota,
+// a match the reaction made is ended
+func (usecase UserUc) UndoReaction(ctx context.Context, userID uint) (*entity.UserReaction, error) {
+	owner, err := usecase.quotaOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction, err := usecase.db.GetLastReaction(userID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if reaction == nil || time.Since(reaction.UpdatedAt) > usecase.settings.Rewind.Window {
+		return nil, utils.NewStandardError("No recent reaction to undo", "NOT FOUND", "")
+	}
+
+	// checked before the rewind is charged, undoing a reaction that changed nothing would only waste it
+	if !reaction.Undoable() {
+		return nil, utils.NewStandardError("The latest reaction has nothing to undo", "NOT FOUND", "")
+	}
+
+	rewindQuotas := []quota{}
+	if rewindPolicy, ok := usecase.settings.Policy.Quota(owner.tier, entity.QUOTA_REWIND); ok {
+		rewindQuotas = append(rewindQuotas, owner.quota(rewindPolicy))
+	}
+	err = usecase.reserveQuotas(ctx, rewindQuotas)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := usecase.db.RevertUserReaction(*reaction)
+	if err != nil {
+		usecase.refundQuotas(ctx, rewindQuotas)
+		return nil, errors.WithStack(err)
+	}
+
+	// the match the reaction made is gone with it, the other side sees it as an unmatch
+	if match != nil {
+		usecase.notify(ctx, reaction.TargetID, entity.Notification{
+			Type: entity.NOTIFICATION_TYPE_UNMATCH,
+			Data: match,
+		})
+	}
+
+	// the reaction was charged to the window it was made in, which may have reset since,
+	// and to the rolling quotas by an entry taken just before it was saved
+	usecase.refundQuotas(ctx, usecase.chargeableQuotas(owner.at(reaction.UpdatedAt), reaction.Type, reaction.PreviousType))
+
+	return reaction, nil
+}
+
+func (usecase UserUc) ReactionHistory(ctx context.Context, params entity.ReactionHistoryParams) ([]entity.ReactionHistoryItem, utils.Page, error) {
+	reactions, err := usecase.db.GetReactionHistory(params)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
 	}
 
-	if !isPremium {
-		limitStr, _ := usecase.redis.Get(ctx, BuildReactionLimitRedisKey(params.UserID))
-		limit, _ := strconv.Atoi(limitStr)
-		if limit >= REACTION_LIMIT {
-			return utils.NewStandardError("Reaction limit exceeded, try again tommorow", "LIMIT_EXCEEDED", "")
+	reactions, page := utils.NewPage(reactions, params.Page.Limit, func(reaction entity.ReactionHistoryItem) utils.Cursor {
+		return utils.Cursor{CreatedAt: reaction.UpdatedAt, ID: reaction.Target.ID}
+	})
+
+	return reactions, page, nil
+}
+
+// ReceivedLikes lists the pending likes for entitled tiers, the others only get the count and blurred placeholders
+func (usecase UserUc) ReceivedLikes(ctx context.Context, params entity.ReceivedLikesParams) (entity.ReceivedLikes, utils.Page, error) {
+	result := entity.ReceivedLikes{}
+	isPremium, err := usecase.isPremium(ctx, params.UserID)
+	if err != nil {
+		return result, utils.Page{}, err
+	}
+
+	result.Count, err = usecase.db.CountReceivedLikes(params.UserID)
+	if err != nil {
+		return result, utils.Page{}, errors.WithStack(err)
+	}
+
+	if !usecase.settings.Policy.Entitled(entity.UserTier(isPremium), entity.ENTITLEMENT_SEE_RECEIVED_LIKES) {
+		placeholders := min(result.Count, int64(params.Page.Limit))
+		result.Likes = make([]entity.ReceivedLike, placeholders)
+		for i := range result.Likes {
+			result.Likes[i].Blurred = true
 		}
+		return result, utils.Page{}, nil
+	}
+
+	likes, err := usecase.db.GetReceivedLikes(params)
+	if err != nil {
+		return result, utils.Page{}, errors.WithStack(err)
+	}
+
+	likes, page := utils.NewPage(likes, params.Page.Limit, func(like entity.ReceivedLike) utils.Cursor {
+		return utils.Cursor{CreatedAt: *like.CreatedAt, ID: like.User.ID}
+	})
+	result.Likes = likes
+
+	return result, page, nil
+}
+
+func (usecase UserUc) Matches(ctx context.Context, params entity.MatchListParams) ([]entity.MatchListItem, utils.Page, error) {
+	matches, err := usecase.db.GetMatches(params)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
+	}
+
+	matches, page := utils.NewPage(matches, params.Page.Limit, func(match entity.MatchListItem) utils.Cursor {
+		return utils.Cursor{CreatedAt: match.LastActivityAt, ID: match.ID}
+	})
+
+	return matches, page, nil
+}
+
+// Unmatch ends the match for both sides and lets the other user know, without the reason
+func (usecase UserUc) Unmatch(ctx context.Context, params entity.UnmatchParams) error {
+	match, err := usecase.db.EndMatch(params)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if match == nil {
+		return utils.NewStandardError("Match not found", "NOT FOUND", "")
+	}
+
+	otherUserID := match.FirstUserID
+	if otherUserID == params.UserID {
+		otherUserID = match.SecondUserID
+	}
+	usecase.notify(ctx, otherUserID, entity.Notification{
+		Type: entity.NOTIFICATION_TYPE_UNMATCH,
+		Data: match,
+	})
+
+	return nil
+}
+
+// Profile returns the profile of another user, a user hidden by a block or by reports is not found
+func (usecase UserUc) Profile(ctx context.Context, userID, targetID uint) (*entity.UserProfile, error) {
+	blocked, err := usecase.isBlocked(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if blocked {
+		return nil, utils.UserNotFoundError(targetID)
+	}
+
+	candidate, err := usecase.db.GetCandidateByID(targetID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if candidate == nil || candidate.ID == 0 || candidate.Hidden {
+		return nil, utils.UserNotFoundError(targetID)
 	}
 
+	return &entity.UserProfile{
+		ID:           candidate.ID,
+		Username:     candidate.Username,
+		Bio:          candidate.Bio,
+		PhotoURL:     candidate.PhotoURL,
+		LastActiveAt: candidate.LastActiveAt,
+	}, nil
+}
+
+// Block hides the pair from each other and ends their match, the other user is only told that the match ended
+func (usecase UserUc) Block(ctx context.Context, params entity.BlockParams) error {
 	targetUserData, err := usecase.db.GetUserByID(params.TargetID)
 	if err != nil {
 		return errors.WithStack(err)
@@ -113,14 +464,366 @@ func (usecase UserUc) React(ctx context.Context, params entity.ReactionParams) e
 		return utils.UserNotFoundError(params.TargetID)
 	}
 
-	err = usecase.db.UpsertUserReaction(params)
+	match, err := usecase.db.InsertBlock(params)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	usecase.forgetBlocks(ctx, params)
+
+	if match != nil {
+		usecase.notify(ctx, params.TargetID, entity.Notification{
+			Type: entity.NOTIFICATION_TYPE_UNMATCH,
+			Data: match,
+		})
+	}
+
+	return nil
+}
+
+// Unblock lifts a block of the user, a match it ended is not restored
+func (usecase UserUc) Unblock(ctx context.Context, params entity.BlockParams) error {
+	deleted, err := usecase.db.DeleteBlock(params)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	if !isPremium && params.Type != 0 {
-		usecase.redis.Incr(ctx, BuildReactionLimitRedisKey(params.UserID), reactionLimitExpCache)
+	if !deleted {
+		return utils.NewStandardError("Block not found", "NOT FOUND", "")
+	}
+	usecase.forgetBlocks(ctx, params)
+
+	return nil
+}
+
+func (usecase UserUc) Blocks(ctx context.Context, params entity.BlockListParams) ([]entity.BlockListItem, utils.Page, error) {
+	blocks, err := usecase.db.GetBlocks(params)
+	if err != nil {
+		return nil, utils.Page{}, errors.WithStack(err)
+	}
+
+	blocks, page := utils.NewPage(blocks, params.Page.Limit, func(block entity.BlockListItem) utils.Cursor {
+		return utils.Cursor{CreatedAt: block.CreatedAt, ID: block.User.ID}
+	})
+
+	return blocks, page, nil
+}
+
+// Quota returns the usage of every quota of the user's tier
+func (usecase UserUc) Quota(ctx context.Context, userID uint) (entity.QuotaSummary, error) {
+	summary := entity.QuotaSummary{}
+	owner, err := usecase.quotaOwner(ctx, userID)
+	if err != nil {
+		return summary, err
+	}
+
+	summary.Tier = owner.tier
+	summary.Quotas = []entity.QuotaStatus{}
+	for _, policy := range usecase.settings.Policy.Tiers[owner.tier].Quotas {
+		status, err := usecase.quotaStatus(ctx, owner.quota(policy))
+		if err != nil {
+			return summary, err
+		}
+		summary.Quotas = append(summary.Quotas, status)
+	}
+
+	return summary, nil
+}
+
+// isPremium checks the premium status from cache, falling back to db
+func (usecase UserUc) isPremium(ctx context.Context, userID uint) (bool, error) {
+	isPremiumBytes, err := usecase.cache.Get(ctx, BuildPremiumCacheKey(userID))
+	isPremiumStr := string(isPremiumBytes)
+	if err == nil && isPremiumStr != "" {
+		return isPremiumStr == PREMIUM_TRUE_STRING, nil
+	}
+
+	// check in db
+	userData, err := usecase.db.GetUserByID(userID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	usecase.cache.Set(ctx, BuildPremiumCacheKey(userID), []byte(fmt.Sprintf("%t", userData.Premium)), usecase.settings.PremiumCacheTTL)
+
+	return userData.Premium, nil
+}
+
+// location returns the user's timezone from cache, falling back to db
+func (usecase UserUc) location(ctx context.Context, userID uint) (*time.Location, error) {
+	timezoneBytes, err := usecase.cache.Get(ctx, BuildTimezoneCacheKey(userID))
+	timezone := string(timezoneBytes)
+	if err != nil || timezone == "" {
+		userData, err := usecase.db.GetUserByID(userID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		timezone = userData.Timezone
+		usecase.cache.Set(ctx, BuildTimezoneCacheKey(userID), []byte(timezone), timezoneExpCache)
+	}
+
+	return entity.LoadTimezone(timezone), nil
+}
+
+// blockedUsers returns the users hidden from the user from Redis, falling back to db. The list is kept out of the
+// local cache layer, so a block is enforced by every instance as soon as it is made or lifted
+func (usecase UserUc) blockedUsers(ctx context.Context, userID uint) (map[uint]bool, error) {
+	userIDs := []uint{}
+	cached, err := usecase.redis.Get(ctx, BuildBlocksRedisKey(userID))
+	if err != nil || cached == "" || json.Unmarshal([]byte(cached), &userIDs) != nil {
+		userIDs, err = usecase.db.GetBlockedUserIDs(userID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		payload, _ := json.Marshal(userIDs)
+		usecase.redis.Set(ctx, BuildBlocksRedisKey(userID), string(payload), blocksExpCache)
+	}
+
+	blocked := make(map[uint]bool, len(userIDs))
+	for _, id := range userIDs {
+		blocked[id] = true
+	}
+
+	return blocked, nil
+}
+
+// isBlocked tells whether either user of the pair blocked the other
+func (usecase UserUc) isBlocked(ctx context.Context, userID, otherUserID uint) (bool, error) {
+	blocked, err := usecase.blockedUsers(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return blocked[otherUserID], nil
+}
+
+// forgetBlocks drops the cached blocks of both users, so they are read again from db
+func (usecase UserUc) forgetBlocks(ctx context.Context, params entity.BlockParams) {
+	usecase.redis.Del(ctx, BuildBlocksRedisKey(params.UserID))
+	usecase.redis.Del(ctx, BuildBlocksRedisKey(params.TargetID))
+}
+
+// quotaOwner resolves the tier and timezone the user's quotas are placed with
+func (usecase UserUc) quotaOwner(ctx context.Context, userID uint) (quotaOwner, error) {
+	isPremium, err := usecase.isPremium(ctx, userID)
+	if err != nil {
+		return quotaOwner{}, err
+	}
+
+	loc, err := usecase.location(ctx, userID)
+	if err != nil {
+		return quotaOwner{}, err
+	}
+
+	return quotaOwner{
+		userID:   userID,
+		tier:     entity.UserTier(isPremium),
+		location: loc,
+		now:      time.Now(),
+	}, nil
+}
+
+// trackBoostLike credits the target's active boost with a like, unless the reaction it replaces was already one.
+// Tracking is best effort
+func (usecase UserUc) trackBoostLike(reaction entity.ReactionParams, previousType *entity.ReactionType) {
+	if !reaction.Type.CountsTowardMatch() || (previousType != nil && previousType.CountsTowardMatch()) {
+		return
+	}
+
+	boosted, err := usecase.db.IncrementBoostLikes(reaction.TargetID)
+	if err != nil || !boosted {
+		return
+	}
+	utils.TimbleBoostEvents.WithLabelValues(BOOST_EVENT_LIKE).Inc()
+}
+
+// notifyReaction tells the target about the reaction it received. A like that made a match is told by the
+// match notification instead, and a plain like leaves the liker out since seeing who liked is an entitlement
+func (usecase UserUc) notifyReaction(ctx context.Context, reaction entity.ReactionParams, previousType *entity.ReactionType, match *entity.Match) {
+	switch {
+	case reaction.Type == entity.REACTION_TYPE_SUPER_LIKE:
+		usecase.notify(ctx, reaction.TargetID, entity.Notification{
+			Type: entity.NOTIFICATION_TYPE_SUPER_LIKE,
+			Data: reaction,
+		})
+	case reaction.Type == entity.REACTION_TYPE_LIKE && match == nil && (previousType == nil || !previousType.CountsTowardMatch()):
+		usecase.notify(ctx, reaction.TargetID, entity.Notification{
+			Type: entity.NOTIFICATION_TYPE_LIKE,
+		})
+	}
+	usecase.notifyMatch(ctx, match)
+}
+
+// notifyMatch tells both users about a match the moment it is made, not when a later like finds it again
+func (usecase UserUc) notifyMatch(ctx context.Context, match *entity.Match) {
+	if match == nil || !match.Created {
+		return
+	}
+
+	notification := entity.Notification{
+		Type: entity.NOTIFICATION_TYPE_MATCH,
+		Data: match,
+	}
+	usecase.notify(ctx, match.FirstUserID, notification)
+	usecase.notify(ctx, match.SecondUserID, notification)
+}
+
+// notify publishes the notification to the user's channel, delivery is best effort
+func (usecase UserUc) notify(ctx context.Context, userID uint, notification entity.Notification) {
+	publishNotification(ctx, usecase.redis, userID, notification)
+}
+
+// reserveQuotas takes one unit from every quota, or from none of them when one is exhausted.
+// A reservation is kept as is once the action succeeds, and refunded when it fails
+func (usecase UserUc) reserveQuotas(ctx context.Context, quotas []quota) error {
+	for i, quota := range quotas {
+		reserved, err := usecase.reserveQuota(ctx, quota)
+		if err != nil {
+			usecase.refundQuotas(ctx, quotas[:i])
+			return errors.WithStack(err)
+		}
+
+		if !reserved {
+			usecase.refundQuotas(ctx, quotas[:i])
+			return utils.NewStandardError(quota.policy.ExceededMessage(), "LIMIT_EXCEEDED", "")
+		}
 	}
 
 	return nil
 }
+
+func (usecase UserUc) reserveQuota(ctx context.Context, quota quota) (bool, error) {
+	if quota.policy.Window == entity.QUOTA_WINDOW_ROLLING {
+		return usecase.redis.ReserveRollingQuota(ctx, quota.redisKey, quota.member, quota.policy.Limit, time.Duration(quota.policy.Period))
+	}
+
+	// a fixed window counter lives until the window resets
+	return usecase.redis.ReserveQuota(ctx, quota.redisKey, quota.policy.Limit, time.Until(quota.resetAt))
+}
+
+func (usecase UserUc) refundQuotas(ctx context.Context, quotas []quota) {
+	for _, quota := range quotas {
+		if quota.policy.Window == entity.QUOTA_WINDOW_ROLLING && quota.member == "" {
+			usecase.redis.RefundRollingQuotaAt(ctx, quota.redisKey, quota.reservedAt)
+			continue
+		}
+
+		if quota.policy.Window == entity.QUOTA_WINDOW_ROLLING {
+			usecase.redis.RefundRollingQuota(ctx, quota.redisKey, quota.member)
+			continue
+		}
+		usecase.redis.RefundQuota(ctx, quota.redisKey)
+	}
+}
+
+func (usecase UserUc) quotaStatus(ctx context.Context, quota quota) (entity.QuotaStatus, error) {
+	status := entity.QuotaStatus{
+		Name:    quota.policy.Name,
+		Window:  quota.policy.Window,
+		Limit:   quota.policy.Limit,
+		ResetAt: quota.resetAt,
+	}
+
+	used := 0
+	if quota.policy.Window == entity.QUOTA_WINDOW_ROLLING {
+		period := time.Duration(quota.policy.Period)
+		count, oldest, err := usecase.redis.RollingQuotaUsage(ctx, quota.redisKey, period)
+		if err != nil {
+			return status, errors.WithStack(err)
+		}
+
+		// a rolling quota gets its oldest unit back first
+		used = int(count)
+		if count > 0 {
+			status.ResetAt = oldest.Add(period)
+		}
+	} else {
+		count, err := usecase.redis.Get(ctx, quota.redisKey)
+		if err != nil {
+			return status, errors.WithStack(err)
+		}
+		used, _ = strconv.Atoi(count)
+	}
+
+	status.Remaining = max(status.Limit-used, 0)
+	return status, nil
+}
+
+// rateLimit returns the status of the reaction type quota with the least remaining, it is nil when the reaction is unlimited
+func (usecase UserUc) rateLimit(ctx context.Context, owner quotaOwner, reactionType entity.ReactionType) *entity.QuotaStatus {
+	var result *entity.QuotaStatus
+	for _, policy := range usecase.settings.Policy.ReactionQuotas(owner.tier, reactionType) {
+		status, err := usecase.quotaStatus(ctx, owner.quota(policy))
+		if err != nil {
+			// the status is informative only, it must not fail the reaction
+			continue
+		}
+
+		if result == nil || status.Remaining < result.Remaining {
+			result = &status
+		}
+	}
+
+	return result
+}
+
+// chargeableQuotas lists the quotas a reaction is charged to,
+// leaving out the ones already paid by the reaction it replaces
+func (usecase UserUc) chargeableQuotas(owner quotaOwner, reactionType entity.ReactionType, previousType *entity.ReactionType) []quota {
+	paid := map[string]bool{}
+	if previousType != nil {
+		for _, policy := range usecase.settings.Policy.ReactionQuotas(owner.tier, *previousType) {
+			paid[policy.Name] = true
+		}
+	}
+
+	quotas := []quota{}
+	for _, policy := range usecase.settings.Policy.ReactionQuotas(owner.tier, reactionType) {
+		if !paid[policy.Name] {
+			quotas = append(quotas, owner.quota(policy))
+		}
+	}
+
+	return quotas
+}
+
+// quotaOwner is the user quotas are charged to
+type quotaOwner struct {
+	userID   uint
+	tier     string
+	location *time.Location
+	now      time.Time
+	// past tells the owner is placed at a reservation made earlier, see at
+	past bool
+}
+
+type quota struct {
+	policy   entity.QuotaPolicy
+	redisKey string
+	resetAt  time.Time
+	// member is the entry a rolling quota keeps for this reservation, refunding removes this one entry only.
+	// A past reservation has no member, refunding it removes the latest entry taken by reservedAt
+	member     string
+	reservedAt time.Time
+}
+
+// at returns the owner as of a reservation made at the given time, its quotas are then placed in the windows of that time
+func (owner quotaOwner) at(reservedAt time.Time) quotaOwner {
+	owner.now = reservedAt
+	owner.past = true
+	return owner
+}
+
+func (owner quotaOwner) quota(policy entity.QuotaPolicy) quota {
+	window := NewQuotaWindow(policy, owner.now, owner.location)
+	result := quota{
+		policy:     policy,
+		redisKey:   BuildQuotaRedisKey(policy.Name, window, owner.userID),
+		resetAt:    window.ResetAt,
+		reservedAt: owner.now,
+	}
+	if policy.Window == entity.QUOTA_WINDOW_ROLLING && !owner.past {
+		result.member = fmt.Sprintf("%d-%d", owner.now.UnixNano(), rand.Int64())
+	}
+
+	return result
+}
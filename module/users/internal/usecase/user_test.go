@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	log "go.uber.org/zap"
 
+	redisconn "timble/internal/connection/redis"
 	"timble/internal/utils"
 	mocksrepo "timble/mocks/module/users/internal_/usecase"
 	"timble/module/users/entity"
@@ -32,6 +36,32 @@ var (
 		SecretKey: []byte("secretz"),
 		TokenExp:  time.Hour,
 	}
+
+	reactionLimit  = 10
+	superLikeLimit = 3
+
+	testDailyQuota = entity.QuotaPolicy{Window: entity.QUOTA_WINDOW_DAILY}
+	testWindow     = uc.NewQuotaWindow(testDailyQuota, time.Now(), entity.LoadTimezone(entity.DEFAULT_TIMEZONE))
+
+	// quota counters expire at the end of the user's local day
+	untilResetAt = mock.MatchedBy(func(expire time.Duration) bool {
+		return expire > 0 && expire <= 24*time.Hour
+	})
+
+	defaultSettings = entity.Settings{
+		Rewind: entity.RewindSettings{
+			Window: 5 * time.Minute,
+		},
+		Policy:          entity.DefaultPolicy(),
+		PremiumCacheTTL: 24 * time.Hour,
+		Boost: entity.BoostSettings{
+			Duration: 30 * time.Minute,
+		},
+		Reports: entity.ReportSettings{
+			HideThreshold: 3,
+			ClaimTTL:      30 * time.Minute,
+		},
+	}
 )
 
 func TestNewUserUsecase(t *testing.T) {
@@ -39,6 +69,7 @@ func TestNewUserUsecase(t *testing.T) {
 
 		usecase := uc.NewUserUsecase(
 			&utils.AuthConfig{},
+			entity.Settings{},
 			&repository.RedisRepository{},
 			&repository.PostgresRepository{},
 			&repository.CacheRepository{},
@@ -135,7 +166,7 @@ func TestUserUc_Create(t *testing.T) {
 				db.On("GetUserByUsername", tc.args.params.Username).Return(tc.mocked.dbGetResult, tc.mocked.dbGetError)
 			}
 
-			usecase := uc.NewUserUsecase(defaultAuthConfig, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
 
 			result, err := usecase.Create(ctx, tc.args.params)
 			if tc.expectedErr != nil {
@@ -152,19 +183,23 @@ func TestUserUc_Create(t *testing.T) {
 
 func TestUserUc_Show(t *testing.T) {
 	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	berlin, _ := time.LoadLocation("Europe/Berlin")
 	userPublic := &entity.UserPublic{
-		ID:        testUser.ID,
-		Email:     testUser.Email,
-		Username:  testUser.Username,
-		Premium:   testUser.Premium,
-		CreatedAt: timestamp,
-		UpdatedAt: timestamp,
+		ID:           testUser.ID,
+		Email:        testUser.Email,
+		Username:     testUser.Username,
+		Premium:      testUser.Premium,
+		Timezone:     "Europe/Berlin",
+		QuotaResetAt: uc.NewQuotaWindow(testDailyQuota, time.Now(), berlin).ResetAt,
+		CreatedAt:    timestamp,
+		UpdatedAt:    timestamp,
 	}
 	userData := &entity.User{
 		ID:             testUser.ID,
 		Email:          testUser.Email,
 		Username:       testUser.Username,
 		Premium:        testUser.Premium,
+		Timezone:       "Europe/Berlin",
 		HashedPassword: testUser.HashedPassword,
 		CreatedAt:      timestamp,
 		UpdatedAt:      timestamp,
@@ -212,7 +247,7 @@ func TestUserUc_Show(t *testing.T) {
 
 			db.On("GetUserByID", tc.args.params).Return(tc.mocked.dbGetResult, tc.mocked.dbGetError)
 
-			usecase := uc.NewUserUsecase(defaultAuthConfig, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
 
 			result, err := usecase.Show(ctx, tc.args.params)
 			if tc.expectedErr != nil {
@@ -226,12 +261,155 @@ func TestUserUc_Show(t *testing.T) {
 	}
 }
 
+func TestUserUc_UpdateTimezone(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	params := entity.UserTimezoneParams{UserID: testUser.ID, Timezone: "Europe/Berlin"}
+	userData := &entity.User{
+		ID:       testUser.ID,
+		Email:    testUser.Email,
+		Username: testUser.Username,
+		Timezone: "Europe/Berlin",
+	}
+
+	type shouldMock struct {
+		cacheDelete bool
+		dbGet       bool
+	}
+
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		dbUpdateError  error
+		expectedResult *entity.UserPublic
+		expectedErr    error
+	}{
+		{
+			name: "normal case - timezone is saved and the cached one is dropped",
+			shouldMock: shouldMock{
+				cacheDelete: true,
+				dbGet:       true,
+			},
+			expectedResult: &entity.UserPublic{
+				ID:           testUser.ID,
+				Email:        testUser.Email,
+				Username:     testUser.Username,
+				Timezone:     "Europe/Berlin",
+				QuotaResetAt: uc.NewQuotaWindow(testDailyQuota, time.Now(), berlin).ResetAt,
+			},
+		},
+		{
+			name:          "error case - error during update",
+			dbUpdateError: errors.New("Error from db update"),
+			expectedErr:   errors.New("Error from db update"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("UpdateUserTimezone", params).Return(tc.dbUpdateError)
+			if tc.shouldMock.cacheDelete {
+				cache.On("Delete", ctx, "timezone:1").Return(nil)
+			}
+			if tc.shouldMock.dbGet {
+				db.On("GetUserByID", testUser.ID).Return(userData, nil)
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, cache, &log.Logger{})
+
+			result, err := usecase.UpdateTimezone(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestNewQuotaWindow(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	newYork, _ := time.LoadLocation("America/New_York")
+	hourlyQuota := entity.QuotaPolicy{Window: entity.QUOTA_WINDOW_HOURLY}
+	rollingQuota := entity.QuotaPolicy{Window: entity.QUOTA_WINDOW_ROLLING, Period: entity.Duration(6 * time.Hour)}
+	tests := []struct {
+		name           string
+		quota          entity.QuotaPolicy
+		now            time.Time
+		loc            *time.Location
+		expectedResult uc.QuotaWindow
+	}{
+		{
+			name:  "daily window resets at the next local midnight",
+			quota: testDailyQuota,
+			now:   time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC),
+			loc:   jakarta,
+			expectedResult: uc.QuotaWindow{
+				Period:  "2026-03-02",
+				ResetAt: time.Date(2026, 3, 3, 0, 0, 0, 0, jakarta),
+			},
+		},
+		{
+			name:  "daily window follows the local date behind utc",
+			quota: testDailyQuota,
+			now:   time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC),
+			loc:   newYork,
+			expectedResult: uc.QuotaWindow{
+				Period:  "2026-02-28",
+				ResetAt: time.Date(2026, 3, 1, 0, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:  "daily window is shorter on a daylight saving day",
+			quota: testDailyQuota,
+			now:   time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC),
+			loc:   newYork,
+			expectedResult: uc.QuotaWindow{
+				Period:  "2026-03-08",
+				ResetAt: time.Date(2026, 3, 9, 0, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:  "hourly window resets at the next local hour",
+			quota: hourlyQuota,
+			now:   time.Date(2026, 3, 1, 20, 15, 0, 0, time.UTC),
+			loc:   jakarta,
+			expectedResult: uc.QuotaWindow{
+				Period:  "2026-03-02T03",
+				ResetAt: time.Date(2026, 3, 2, 4, 0, 0, 0, jakarta),
+			},
+		},
+		{
+			name:  "rolling window with nothing taken is whole now",
+			quota: rollingQuota,
+			now:   time.Date(2026, 3, 1, 20, 15, 0, 0, time.UTC),
+			loc:   jakarta,
+			expectedResult: uc.QuotaWindow{
+				Period:  "rolling",
+				ResetAt: time.Date(2026, 3, 1, 20, 15, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := uc.NewQuotaWindow(tc.quota, tc.now, tc.loc)
+			assert.Equal(t, tc.expectedResult.Period, result.Period)
+			assert.True(t, tc.expectedResult.ResetAt.Equal(result.ResetAt))
+		})
+	}
+}
+
 func TestUserUc_React(t *testing.T) {
 	reactionParams := entity.ReactionParams{
 		UserID:   1,
 		TargetID: 2,
 		Type:     1,
 	}
+	hiddenAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
 
 	testUserPremium := &entity.User{
 		ID:             uint(2),
@@ -246,31 +424,44 @@ func TestUserUc_React(t *testing.T) {
 	}
 
 	type shouldMock struct {
-		dbGetUserByID        bool
-		cacheSetPremium      bool
-		redisGetLimit        bool
-		dbGetUserByIDTarget  bool
-		dbUpsertUserReaction bool
-		redisIncr            bool
+		dbGetUserByID         bool
+		cacheSetPremium       bool
+		dbGetUserByIDTarget   bool
+		cacheGetBlocks        bool
+		dbGetUserReaction     bool
+		redisReserveLimit     bool
+		dbUpsertUserReaction  bool
+		redisRefundLimit      bool
+		redisReserveSuperLike bool
+		redisRefundSuperLike  bool
+		redisPublish          bool
+		redisGetLimit         bool
+		redisGetSuperLike     bool
 	}
 
 	type mocked struct {
-		cacheGetPremiumResult     []byte
-		cacheGetPremiumError      error
-		dbGetUserByIDResult       *entity.User
-		dbGetUserByIDError        error
-		cacheSetPremiumParam      string
-		redisGetLimitResult       string
-		dbGetUserByIDTargetResult *entity.User
-		dbGetUserByIDTargetError  error
-		dbUpsertUserReactionError error
+		cacheGetPremiumResult       []byte
+		cacheGetPremiumError        error
+		dbGetUserByIDResult         *entity.User
+		dbGetUserByIDError          error
+		cacheSetPremiumParam        string
+		dbGetUserByIDTargetResult   *entity.User
+		dbGetUserByIDTargetError    error
+		redisGetBlocksResult        string
+		dbGetUserReactionResult     *entity.UserReaction
+		dbGetUserReactionError      error
+		redisReserveLimitResult     bool
+		redisReserveLimitError      error
+		redisReserveSuperLikeResult bool
+		dbUpsertUserReactionError   error
+		redisGetLimitResult         string
 	}
 	tests := []struct {
 		name           string
 		args           args
 		shouldMock     shouldMock
 		mocked         mocked
-		expectedResult string
+		expectedResult *entity.QuotaStatus
 		expectedErr    error
 	}{
 		{
@@ -281,13 +472,22 @@ func TestUserUc_React(t *testing.T) {
 			shouldMock: shouldMock{
 				redisGetLimit:        true,
 				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
+				redisReserveLimit:    true,
 				dbUpsertUserReaction: true,
-				redisIncr:            true,
 			},
 			mocked: mocked{
+				redisGetLimitResult:       "3",
 				cacheGetPremiumResult:     []byte("false"),
-				redisGetLimitResult:       "1",
 				dbGetUserByIDTargetResult: testUserPremium,
+				redisReserveLimitResult:   true,
+			},
+			expectedResult: &entity.QuotaStatus{
+				Name:      "reaction",
+				Window:    entity.QUOTA_WINDOW_DAILY,
+				Limit:     reactionLimit,
+				Remaining: 7,
+				ResetAt:   testWindow.ResetAt,
 			},
 		},
 		{
@@ -296,18 +496,19 @@ func TestUserUc_React(t *testing.T) {
 				params: reactionParams,
 			},
 			shouldMock: shouldMock{
+				redisGetLimit:        true,
 				dbGetUserByID:        true,
 				cacheSetPremium:      true,
-				redisGetLimit:        true,
 				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
+				redisReserveLimit:    true,
 				dbUpsertUserReaction: true,
-				redisIncr:            true,
 			},
 			mocked: mocked{
 				dbGetUserByIDResult:       testUser,
 				cacheSetPremiumParam:      "false",
-				redisGetLimitResult:       "1",
 				dbGetUserByIDTargetResult: testUserPremium,
+				redisReserveLimitResult:   true,
 			},
 		},
 		{
@@ -317,6 +518,7 @@ func TestUserUc_React(t *testing.T) {
 			},
 			shouldMock: shouldMock{
 				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
 				dbUpsertUserReaction: true,
 			},
 			mocked: mocked{
@@ -333,6 +535,7 @@ func TestUserUc_React(t *testing.T) {
 				dbGetUserByID:        true,
 				cacheSetPremium:      true,
 				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
 				dbUpsertUserReaction: true,
 			},
 			mocked: mocked{
@@ -341,6 +544,59 @@ func TestUserUc_React(t *testing.T) {
 				dbGetUserByIDTargetResult: testUser,
 			},
 		},
+		{
+			name: "normal case - re-reacting to the same target is not charged again",
+			args: args{
+				params: reactionParams,
+			},
+			shouldMock: shouldMock{
+				redisGetLimit:        true,
+				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
+				dbUpsertUserReaction: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("false"),
+				dbGetUserByIDTargetResult: testUserPremium,
+				dbGetUserReactionResult:   &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE},
+			},
+		},
+		{
+			name: "normal case - upgrading a like to a super like only charges the super like quota",
+			args: args{
+				params: entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_SUPER_LIKE},
+			},
+			shouldMock: shouldMock{
+				redisGetLimit:         true,
+				redisGetSuperLike:     true,
+				dbGetUserByIDTarget:   true,
+				dbGetUserReaction:     true,
+				redisReserveSuperLike: true,
+				dbUpsertUserReaction:  true,
+				redisPublish:          true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:       []byte("false"),
+				dbGetUserByIDTargetResult:   testUserPremium,
+				dbGetUserReactionResult:     &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE},
+				redisReserveSuperLikeResult: true,
+			},
+		},
+		{
+			name: "normal case - undecided reaction of a free user is not charged",
+			args: args{
+				params: entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_UNDECIDED},
+			},
+			shouldMock: shouldMock{
+				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
+				dbUpsertUserReaction: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("false"),
+				dbGetUserByIDTargetResult: testUserPremium,
+			},
+		},
 		{
 			name: "error case - failed to get user data when premium info not in cache",
 			args: args{
@@ -360,14 +616,65 @@ func TestUserUc_React(t *testing.T) {
 				params: reactionParams,
 			},
 			shouldMock: shouldMock{
-				redisGetLimit: true,
+				redisGetLimit:       true,
+				dbGetUserByIDTarget: true,
+				dbGetUserReaction:   true,
+				redisReserveLimit:   true,
 			},
 			mocked: mocked{
-				cacheGetPremiumResult: []byte("false"),
-				redisGetLimitResult:   "10",
+				redisGetLimitResult:       "10",
+				cacheGetPremiumResult:     []byte("false"),
+				dbGetUserByIDTargetResult: testUserPremium,
+				redisReserveLimitResult:   false,
+			},
+			expectedResult: &entity.QuotaStatus{
+				Name:      "reaction",
+				Window:    entity.QUOTA_WINDOW_DAILY,
+				Limit:     reactionLimit,
+				Remaining: 0,
+				ResetAt:   testWindow.ResetAt,
 			},
 			expectedErr: errors.New("Error on\ncode: LIMIT_EXCEEDED; error: Reaction limit exceeded, try again tommorow; field:"),
 		},
+		{
+			name: "error case - error when reserving quota",
+			args: args{
+				params: reactionParams,
+			},
+			shouldMock: shouldMock{
+				redisGetLimit:       true,
+				dbGetUserByIDTarget: true,
+				dbGetUserReaction:   true,
+				redisReserveLimit:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("false"),
+				dbGetUserByIDTargetResult: testUserPremium,
+				redisReserveLimitError:    errors.New("Error ReserveQuota"),
+			},
+			expectedErr: errors.New("Error ReserveQuota"),
+		},
+		{
+			name: "error case - super like limit exceeded refunds the reaction quota",
+			args: args{
+				params: entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_SUPER_LIKE},
+			},
+			shouldMock: shouldMock{
+				redisGetLimit:         true,
+				redisGetSuperLike:     true,
+				dbGetUserByIDTarget:   true,
+				dbGetUserReaction:     true,
+				redisReserveLimit:     true,
+				redisReserveSuperLike: true,
+				redisRefundLimit:      true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("false"),
+				dbGetUserByIDTargetResult: testUserPremium,
+				redisReserveLimitResult:   true,
+			},
+			expectedErr: errors.New("Error on\ncode: LIMIT_EXCEEDED; error: Super like limit exceeded, try again tomorrow; field:"),
+		},
 		{
 			name: "error case - error when retrieving target user",
 			args: args{
@@ -378,7 +685,6 @@ func TestUserUc_React(t *testing.T) {
 			},
 			mocked: mocked{
 				cacheGetPremiumResult:    []byte("true"),
-				redisGetLimitResult:      "1",
 				dbGetUserByIDTargetError: errors.New("Error GetUserByID for target"),
 			},
 			expectedErr: errors.New("Error GetUserByID for target"),
@@ -393,22 +699,72 @@ func TestUserUc_React(t *testing.T) {
 			},
 			mocked: mocked{
 				cacheGetPremiumResult: []byte("true"),
-				redisGetLimitResult:   "1",
 			},
 			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
 		},
 		{
-			name: "error case - failed to save reaction data",
+			name: "error case - target hidden by reports",
+			args: args{
+				params: reactionParams,
+			},
+			shouldMock: shouldMock{
+				dbGetUserByIDTarget: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("true"),
+				dbGetUserByIDTargetResult: &entity.User{ID: 2, HiddenAt: &hiddenAt},
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - blocked target",
+			args: args{
+				params: reactionParams,
+			},
+			shouldMock: shouldMock{
+				dbGetUserByIDTarget: true,
+				cacheGetBlocks:      true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("true"),
+				dbGetUserByIDTargetResult: testUser,
+				redisGetBlocksResult:      "[2]",
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - error when retrieving previous reaction",
+			args: args{
+				params: reactionParams,
+			},
+			shouldMock: shouldMock{
+				dbGetUserByIDTarget: true,
+				dbGetUserReaction:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:     []byte("true"),
+				dbGetUserByIDTargetResult: testUser,
+				dbGetUserReactionError:    errors.New("Error GetUserReaction"),
+			},
+			expectedErr: errors.New("Error GetUserReaction"),
+		},
+		{
+			name: "error case - failed to save reaction data refunds the quota",
 			args: args{
 				params: reactionParams,
 			},
 			shouldMock: shouldMock{
+				redisGetLimit:        true,
 				dbGetUserByIDTarget:  true,
+				dbGetUserReaction:    true,
+				redisReserveLimit:    true,
 				dbUpsertUserReaction: true,
+				redisRefundLimit:     true,
 			},
 			mocked: mocked{
-				cacheGetPremiumResult:     []byte("true"),
+				cacheGetPremiumResult:     []byte("false"),
 				dbGetUserByIDTargetResult: testUser,
+				redisReserveLimitResult:   true,
 				dbUpsertUserReactionError: errors.New("Error UpsertUserReaction"),
 			},
 			expectedErr: errors.New("Error UpsertUserReaction"),
@@ -431,31 +787,1670 @@ func TestUserUc_React(t *testing.T) {
 				cache.On("Set", ctx, "premium:1", []byte(tc.mocked.cacheSetPremiumParam), 24*time.Hour).Return(nil)
 			}
 
-			if tc.shouldMock.redisGetLimit {
-				redis.On("Get", ctx, uc.BuildReactionLimitRedisKey(tc.args.params.UserID)).Return(tc.mocked.redisGetLimitResult, nil)
+			if tc.mocked.dbGetUserByIDError == nil {
+				cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
 			}
 
 			if tc.shouldMock.dbGetUserByIDTarget {
 				db.On("GetUserByID", tc.args.params.TargetID).Return(tc.mocked.dbGetUserByIDTargetResult, tc.mocked.dbGetUserByIDTargetError)
 			}
 
+			if tc.shouldMock.cacheGetBlocks || tc.shouldMock.dbGetUserReaction {
+				blocks := tc.mocked.redisGetBlocksResult
+				if blocks == "" {
+					blocks = "[]"
+				}
+				redis.On("Get", ctx, "blocks:1").Return(blocks, nil)
+			}
+
+			if tc.shouldMock.dbGetUserReaction {
+				db.On("GetUserReaction", tc.args.params.UserID, tc.args.params.TargetID).Return(tc.mocked.dbGetUserReactionResult, tc.mocked.dbGetUserReactionError)
+			}
+
+			if tc.shouldMock.redisReserveLimit {
+				redis.On("ReserveQuota", ctx, uc.BuildQuotaRedisKey("reaction", testWindow, tc.args.params.UserID), reactionLimit, untilResetAt).Return(tc.mocked.redisReserveLimitResult, tc.mocked.redisReserveLimitError)
+			}
+
+			if tc.shouldMock.redisReserveSuperLike {
+				redis.On("ReserveQuota", ctx, uc.BuildQuotaRedisKey("super_like", testWindow, tc.args.params.UserID), superLikeLimit, untilResetAt).Return(tc.mocked.redisReserveSuperLikeResult, nil)
+			}
+
 			if tc.shouldMock.dbUpsertUserReaction {
-				db.On("UpsertUserReaction", tc.args.params).Return(tc.mocked.dbUpsertUserReactionError)
+				db.On("UpsertUserReaction", tc.args.params).Return(nil, tc.mocked.dbUpsertUserReactionError)
+			}
+
+			if tc.shouldMock.redisRefundLimit {
+				redis.On("RefundQuota", ctx, uc.BuildQuotaRedisKey("reaction", testWindow, tc.args.params.UserID)).Return(int64(0), nil)
+			}
+
+			if tc.shouldMock.redisRefundSuperLike {
+				redis.On("RefundQuota", ctx, uc.BuildQuotaRedisKey("super_like", testWindow, tc.args.params.UserID)).Return(int64(0), nil)
+			}
+
+			if tc.shouldMock.redisGetLimit {
+				redis.On("Get", ctx, uc.BuildQuotaRedisKey("reaction", testWindow, tc.args.params.UserID)).Return(tc.mocked.redisGetLimitResult, nil)
+			}
+
+			if tc.shouldMock.redisGetSuperLike {
+				redis.On("Get", ctx, uc.BuildQuotaRedisKey("super_like", testWindow, tc.args.params.UserID)).Return("", nil)
+			}
+
+			if tc.shouldMock.redisPublish {
+				redis.On("PublishEvent", ctx, uint(2), mock.Anything).Return("1-0", nil)
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.React(ctx, tc.args.params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			if tc.expectedResult != nil {
+				assert.Equal(t, tc.expectedResult, result.RateLimit)
+			}
+		})
+	}
+}
+
+func TestUserUc_React_SuperLike(t *testing.T) {
+	reactionParams := entity.ReactionParams{
+		UserID:   1,
+		TargetID: 2,
+		Type:     entity.REACTION_TYPE_SUPER_LIKE,
+	}
+	notification := `{"type":"super_like","data":{"user_id":1,"target_id":2,"type":"super_like"}}`
+
+	type mocked struct {
+		cacheGetPremiumResult []byte
+		redisReserveSuperLike bool
+	}
+	tests := []struct {
+		name        string
+		mocked      mocked
+		expectedErr error
+	}{
+		{
+			name: "normal case - free user super like is charged to both quotas and notifies the target",
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("false"),
+				redisReserveSuperLike: true,
+			},
+		},
+		{
+			name: "normal case - premium user super like is only charged to the super like quota",
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("true"),
+				redisReserveSuperLike: true,
+			},
+		},
+		{
+			name: "error case - premium user exceeds super like limit",
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("true"),
+			},
+			expectedErr: errors.New("Error on\ncode: LIMIT_EXCEEDED; error: Super like limit exceeded, try again tomorrow; field:"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			isPremium := string(tc.mocked.cacheGetPremiumResult) == "true"
+
+			cache.On("Get", ctx, "premium:1").Return(tc.mocked.cacheGetPremiumResult, nil)
+			cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+			redis.On("Get", ctx, "blocks:1").Return("[]", nil)
+			db.On("GetUserByID", uint(2)).Return(testUser, nil)
+			db.On("GetUserReaction", uint(1), uint(2)).Return(nil, nil)
+			if !isPremium {
+				redis.On("ReserveQuota", ctx, uc.BuildQuotaRedisKey("reaction", testWindow, 1), reactionLimit, untilResetAt).Return(true, nil)
+				redis.On("Get", ctx, uc.BuildQuotaRedisKey("reaction", testWindow, 1)).Return("4", nil)
 			}
+			redis.On("ReserveQuota", ctx, uc.BuildQuotaRedisKey("super_like", testWindow, 1), superLikeLimit, untilResetAt).Return(tc.mocked.redisReserveSuperLike, nil)
+			redis.On("Get", ctx, uc.BuildQuotaRedisKey("super_like", testWindow, 1)).Return("2", nil)
 
-			if tc.shouldMock.redisIncr {
-				redis.On("Incr", ctx, uc.BuildReactionLimitRedisKey(tc.args.params.UserID), time.Hour*24).Return(int64(1), nil)
+			if tc.expectedErr == nil {
+				db.On("UpsertUserReaction", reactionParams).Return(nil, nil)
+				db.On("IncrementBoostLikes", uint(2)).Return(false, nil)
+				redis.On("PublishEvent", ctx, uint(2), notification).Return("1-0", nil)
 			}
 
-			usecase := uc.NewUserUsecase(defaultAuthConfig, redis, db, cache, &log.Logger{})
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, cache, &log.Logger{})
 
-			err := usecase.React(ctx, tc.args.params)
+			result, err := usecase.React(ctx, reactionParams)
 			if tc.expectedErr != nil {
 				assert.NotNil(t, err)
 				assert.Equal(t, tc.expectedErr.Error(), err.Error())
 			} else {
 				assert.Nil(t, err)
 			}
+			// the super like quota has the least remaining
+			assert.Equal(t, "super_like", result.RateLimit.Name)
+			assert.Equal(t, 1, result.RateLimit.Remaining)
+		})
+	}
+}
+
+func TestUserUc_React_Match(t *testing.T) {
+	reactionParams := entity.ReactionParams{
+		UserID:   1,
+		TargetID: 2,
+		Type:     entity.REACTION_TYPE_LIKE,
+	}
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	notification := `{"type":"match","data":{"id":9,"first_user_id":1,"second_user_id":2,"created_at":"2026-10-18T09:00:00Z"}}`
+
+	tests := []struct {
+		name               string
+		match              *entity.Match
+		expectedNotify     bool
+		expectedLikeNotify bool
+		expectedResult     entity.ReactionResult
+	}{
+		{
+			name:           "normal case - like completing a mutual like notifies both users",
+			match:          &entity.Match{ID: 9, FirstUserID: 1, SecondUserID: 2, CreatedAt: createdAt, Created: true},
+			expectedNotify: true,
+			expectedResult: entity.ReactionResult{Matched: true, MatchID: 9},
+		},
+		{
+			name:           "normal case - like on an existing match does not notify again",
+			match:          &entity.Match{ID: 9, FirstUserID: 1, SecondUserID: 2, CreatedAt: createdAt},
+			expectedResult: entity.ReactionResult{Matched: true, MatchID: 9},
+		},
+		{
+			name:               "normal case - like without the reverse like notifies the target",
+			expectedLikeNotify: true,
+			expectedResult:     entity.ReactionResult{},
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cache.On("Get", ctx, "premium:1").Return([]byte("true"), nil)
+			cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+			redis.On("Get", ctx, "blocks:1").Return("[]", nil)
+			db.On("GetUserByID", uint(2)).Return(testUser, nil)
+			db.On("GetUserReaction", uint(1), uint(2)).Return(nil, nil)
+			db.On("UpsertUserReaction", reactionParams).Return(tc.match, nil)
+			db.On("IncrementBoostLikes", uint(2)).Return(false, nil)
+			if tc.expectedNotify {
+				redis.On("PublishEvent", ctx, uint(1), notification).Return("1-0", nil).Once()
+				redis.On("PublishEvent", ctx, uint(2), notification).Return("1-0", nil).Once()
+			}
+			if tc.expectedLikeNotify {
+				redis.On("PublishEvent", ctx, uint(2), `{"type":"like","data":null}`).Return("1-0", nil).Once()
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.React(ctx, reactionParams)
+			assert.Nil(t, err)
+			// premium users have no reaction quota to report
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestUserUc_React_Policy(t *testing.T) {
+	reactionParams := entity.ReactionParams{
+		UserID:   1,
+		TargetID: 2,
+		Type:     entity.REACTION_TYPE_LIKE,
+	}
+	hourlyQuota := entity.QuotaPolicy{Name: "reaction", Limit: 30, Window: entity.QUOTA_WINDOW_HOURLY}
+	rollingQuota := entity.QuotaPolicy{Name: "reaction", Limit: 5, Window: entity.QUOTA_WINDOW_ROLLING, Period: entity.Duration(6 * time.Hour), Message: "Slow down"}
+	policyOf := func(quotas ...entity.QuotaPolicy) entity.Policy {
+		return entity.Policy{
+			Tiers: map[string]entity.TierPolicy{
+				entity.TIER_FREE:    {Quotas: quotas},
+				entity.TIER_PREMIUM: {},
+			},
+		}
+	}
+	hourlyWindow := uc.NewQuotaWindow(hourlyQuota, time.Now(), entity.LoadTimezone(entity.DEFAULT_TIMEZONE))
+	untilNextHour := mock.MatchedBy(func(expire time.Duration) bool {
+		return expire > 0 && expire <= time.Hour
+	})
+
+	oldest := time.Now().Add(-time.Hour)
+
+	type mocked struct {
+		redisReserve bool
+		dbUpsertErr  error
+	}
+	tests := []struct {
+		name        string
+		policy      entity.Policy
+		mockQuota   func(ctx context.Context, redis *mocksrepo.RedisRepository, mocked mocked)
+		mocked      mocked
+		expectedErr error
+	}{
+		{
+			name:   "normal case - hourly quota is counted on the local hour",
+			policy: policyOf(hourlyQuota),
+			mockQuota: func(ctx context.Context, redis *mocksrepo.RedisRepository, mocked mocked) {
+				redis.On("ReserveQuota", ctx, uc.BuildQuotaRedisKey("reaction", hourlyWindow, 1), 30, untilNextHour).Return(mocked.redisReserve, nil)
+				redis.On("Get", ctx, uc.BuildQuotaRedisKey("reaction", hourlyWindow, 1)).Return("1", nil)
+			},
+			mocked: mocked{redisReserve: true},
+		},
+		{
+			name:   "normal case - rolling quota is counted over its period",
+			policy: policyOf(rollingQuota),
+			mockQuota: func(ctx context.Context, redis *mocksrepo.RedisRepository, mocked mocked) {
+				redis.On("ReserveRollingQuota", ctx, "reaction:rolling:1", mock.AnythingOfType("string"), 5, 6*time.Hour).Return(mocked.redisReserve, nil)
+				redis.On("RollingQuotaUsage", ctx, "reaction:rolling:1", 6*time.Hour).Return(int64(5), oldest, nil)
+			},
+			mocked: mocked{redisReserve: true},
+		},
+		{
+			name:      "normal case - tier without quotas is unlimited",
+			policy:    policyOf(),
+			mockQuota: func(ctx context.Context, redis *mocksrepo.RedisRepository, mocked mocked) {},
+		},
+		{
+			name:   "error case - rolling quota exceeded",
+			policy: policyOf(rollingQuota),
+			mockQuota: func(ctx context.Context, redis *mocksrepo.RedisRepository, mocked mocked) {
+				redis.On("ReserveRollingQuota", ctx, "reaction:rolling:1", mock.AnythingOfType("string"), 5, 6*time.Hour).Return(mocked.redisReserve, nil)
+				redis.On("RollingQuotaUsage", ctx, "reaction:rolling:1", 6*time.Hour).Return(int64(5), oldest, nil)
+			},
+			expectedErr: errors.New("Error on\ncode: LIMIT_EXCEEDED; error: Slow down; field:"),
+		},
+		{
+			name:   "error case - failed to save reaction refunds the rolling quota",
+			policy: policyOf(rollingQuota),
+			mockQuota: func(ctx context.Context, redis *mocksrepo.RedisRepository, mocked mocked) {
+				// only the entry this reaction reserved is refunded
+				var member string
+				redis.On("ReserveRollingQuota", ctx, "reaction:rolling:1", mock.AnythingOfType("string"), 5, 6*time.Hour).Run(func(args mock.Arguments) {
+					member = args.String(2)
+				}).Return(mocked.redisReserve, nil)
+				redis.On("RollingQuotaUsage", ctx, "reaction:rolling:1", 6*time.Hour).Return(int64(5), oldest, nil)
+				redis.On("RefundRollingQuota", ctx, "reaction:rolling:1", mock.MatchedBy(func(refunded string) bool {
+					return refunded != "" && refunded == member
+				})).Return(int64(1), nil)
+			},
+			mocked: mocked{
+				redisReserve: true,
+				dbUpsertErr:  errors.New("Error UpsertUserReaction"),
+			},
+			expectedErr: errors.New("Error UpsertUserReaction"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cache.On("Get", ctx, "premium:1").Return([]byte("false"), nil)
+			cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+			redis.On("Get", ctx, "blocks:1").Return("[]", nil)
+			db.On("GetUserByID", uint(2)).Return(testUser, nil)
+			db.On("GetUserReaction", uint(1), uint(2)).Return(nil, nil)
+			tc.mockQuota(ctx, redis, tc.mocked)
+			if tc.mocked.redisReserve || len(tc.policy.Tiers[entity.TIER_FREE].Quotas) == 0 {
+				db.On("UpsertUserReaction", reactionParams).Return(nil, tc.mocked.dbUpsertErr)
+			}
+			if tc.mocked.dbUpsertErr == nil && (tc.mocked.redisReserve || len(tc.policy.Tiers[entity.TIER_FREE].Quotas) == 0) {
+				db.On("IncrementBoostLikes", uint(2)).Return(false, nil)
+				redis.On("PublishEvent", ctx, uint(2), `{"type":"like","data":null}`).Return("1-0", nil)
+			}
+
+			settings := defaultSettings
+			settings.Policy = tc.policy
+			usecase := uc.NewUserUsecase(defaultAuthConfig, settings, redis, db, cache, &log.Logger{})
+
+			_, err := usecase.React(ctx, reactionParams)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestUserUc_React_ConcurrentQuota(t *testing.T) {
+	s := miniredis.RunT(t)
+	defer s.Close()
+
+	redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "1s", "0")
+	redis := repository.NewRedisRepository(redisClient)
+	db := mocksrepo.NewPostgresRepository(t)
+	cache := mocksrepo.NewCacheRepository(t)
+	ctx := context.Background()
+
+	cache.On("Get", ctx, "premium:1").Return([]byte("false"), nil)
+	cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+	s.Set("blocks:1", "[]")
+	db.On("GetUserByID", uint(2)).Return(testUser, nil)
+	db.On("GetUserReaction", uint(1), uint(2)).Return(nil, nil)
+	db.On("UpsertUserReaction", mock.Anything).Return(nil, nil)
+	db.On("IncrementBoostLikes", uint(2)).Return(false, nil)
+
+	usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, cache, &log.Logger{})
+
+	attempts := reactionLimit * 5
+	var succeeded, limited atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := usecase.React(ctx, entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE})
+			if err == nil {
+				succeeded.Add(1)
+				return
+			}
+			if stdErr, ok := err.(*utils.StandardError); ok && stdErr.Code == "LIMIT_EXCEEDED" {
+				limited.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(reactionLimit), succeeded.Load())
+	assert.Equal(t, int64(attempts-reactionLimit), limited.Load())
+	count, _ := s.Get(uc.BuildQuotaRedisKey("reaction", testWindow, 1))
+	assert.Equal(t, fmt.Sprintf("%d", reactionLimit), count)
+}
+
+func TestUserUc_Quota(t *testing.T) {
+	oldest := time.Now().Add(-time.Hour)
+	rollingQuota := entity.QuotaPolicy{Name: "super_like", Limit: 2, Window: entity.QUOTA_WINDOW_ROLLING, Period: entity.Duration(6 * time.Hour)}
+	dailyQuota := entity.QuotaPolicy{Name: "reaction", Limit: 10, Window: entity.QUOTA_WINDOW_DAILY}
+	policy := entity.Policy{
+		Tiers: map[string]entity.TierPolicy{
+			entity.TIER_FREE:    {Quotas: []entity.QuotaPolicy{dailyQuota, rollingQuota}},
+			entity.TIER_PREMIUM: {},
+		},
+	}
+
+	type shouldMock struct {
+		redisGetDaily   bool
+		redisGetRolling bool
+	}
+
+	type mocked struct {
+		cacheGetPremiumResult []byte
+		redisGetDailyResult   string
+		redisGetDailyError    error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult entity.QuotaSummary
+		expectedErr    error
+	}{
+		{
+			name: "normal case - free user gets the usage of every quota",
+			shouldMock: shouldMock{
+				redisGetDaily:   true,
+				redisGetRolling: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("false"),
+				redisGetDailyResult:   "4",
+			},
+			expectedResult: entity.QuotaSummary{
+				Tier: entity.TIER_FREE,
+				Quotas: []entity.QuotaStatus{
+					{Name: "reaction", Window: entity.QUOTA_WINDOW_DAILY, Limit: 10, Remaining: 6, ResetAt: testWindow.ResetAt},
+					{Name: "super_like", Window: entity.QUOTA_WINDOW_ROLLING, Limit: 2, Remaining: 1, ResetAt: oldest.Add(6 * time.Hour)},
+				},
+			},
+		},
+		{
+			name: "normal case - premium user without quotas is unlimited",
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("true"),
+			},
+			expectedResult: entity.QuotaSummary{
+				Tier:   entity.TIER_PREMIUM,
+				Quotas: []entity.QuotaStatus{},
+			},
+		},
+		{
+			name: "error case - failed to get quota usage",
+			shouldMock: shouldMock{
+				redisGetDaily: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("false"),
+				redisGetDailyError:    errors.New("Error Get"),
+			},
+			expectedErr: errors.New("Error Get"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cache.On("Get", ctx, "premium:1").Return(tc.mocked.cacheGetPremiumResult, nil)
+			cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+
+			if tc.shouldMock.redisGetDaily {
+				redis.On("Get", ctx, uc.BuildQuotaRedisKey("reaction", testWindow, 1)).Return(tc.mocked.redisGetDailyResult, tc.mocked.redisGetDailyError)
+			}
+
+			if tc.shouldMock.redisGetRolling {
+				redis.On("RollingQuotaUsage", ctx, "super_like:rolling:1", 6*time.Hour).Return(int64(1), oldest, nil)
+			}
+
+			settings := defaultSettings
+			settings.Policy = policy
+			usecase := uc.NewUserUsecase(defaultAuthConfig, settings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.Quota(ctx, 1)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestUserUc_ReactBatch(t *testing.T) {
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return start.Add(time.Duration(minutes) * time.Minute)
+	}
+	reactionKey := uc.BuildQuotaRedisKey("reaction", testWindow, 1)
+	superLikeKey := uc.BuildQuotaRedisKey("super_like", testWindow, 1)
+	pass := entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS}
+	like := entity.ReactionParams{UserID: 1, TargetID: 3, Type: entity.REACTION_TYPE_LIKE}
+	rematch := entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE}
+
+	tests := []struct {
+		name           string
+		reactions      []entity.BatchReactionItem
+		mockCalls      func(ctx context.Context, db *mocksrepo.PostgresRepository, redis *mocksrepo.RedisRepository)
+		expectedResult []entity.BatchReactionResult
+		expectedErr    error
+	}{
+		{
+			name: "normal case - items are applied in client time order up to the remaining quota",
+			reactions: []entity.BatchReactionItem{
+				{TargetID: 3, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(2)},
+				{TargetID: 2, Type: entity.REACTION_TYPE_PASS, ReactedAt: at(1)},
+				{TargetID: 4, Type: entity.REACTION_TYPE_UNKNOWN, ReactedAt: at(0)},
+				{TargetID: 2, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(3)},
+				{TargetID: 5, Type: entity.REACTION_TYPE_SUPER_LIKE, ReactedAt: at(4)},
+				{TargetID: 6, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(5)},
+				{TargetID: 7, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(6)},
+			},
+			mockCalls: func(ctx context.Context, db *mocksrepo.PostgresRepository, redis *mocksrepo.RedisRepository) {
+				for _, targetID := range []uint{2, 3, 5} {
+					db.On("GetUserByID", targetID).Return(&entity.User{ID: targetID}, nil).Once()
+					db.On("GetUserReaction", uint(1), targetID).Return(nil, nil).Once()
+				}
+				db.On("GetUserByID", uint(6)).Return(nil, nil).Once()
+				// blocked by the user, so it is not found either
+				db.On("GetUserByID", uint(7)).Return(&entity.User{ID: 7}, nil).Once()
+				redis.On("ReserveQuota", ctx, reactionKey, reactionLimit, untilResetAt).Return(true, nil).Times(3)
+				redis.On("ReserveQuota", ctx, superLikeKey, superLikeLimit, untilResetAt).Return(false, nil).Once()
+				redis.On("RefundQuota", ctx, reactionKey).Return(int64(2), nil).Once()
+				// the like replacing the pass completes a mutual like
+				match := &entity.Match{ID: 9, FirstUserID: 1, SecondUserID: 2, Created: true}
+				db.On("UpsertUserReactions", []entity.ReactionParams{pass, like, rematch}).Return([]*entity.Match{nil, nil, match}, nil).Once()
+				// the like without a match is told to the target without the liker
+				redis.On("PublishEvent", ctx, uint(3), `{"type":"like","data":null}`).Return("1-0", nil).Once()
+				redis.On("PublishEvent", ctx, uint(1), mock.Anything).Return("1-0", nil).Once()
+				redis.On("PublishEvent", ctx, uint(2), mock.Anything).Return("1-0", nil).Once()
+				// the like on a boosted target is credited to the boost, and so is the like replacing a pass
+				db.On("IncrementBoostLikes", uint(3)).Return(true, nil).Once()
+				db.On("IncrementBoostLikes", uint(2)).Return(false, nil).Once()
+			},
+			expectedResult: []entity.BatchReactionResult{
+				{TargetID: 3, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(2), Status: entity.BATCH_REACTION_STATUS_APPLIED},
+				{TargetID: 2, Type: entity.REACTION_TYPE_PASS, ReactedAt: at(1), Status: entity.BATCH_REACTION_STATUS_APPLIED},
+				{
+					TargetID:  4,
+					Type:      entity.REACTION_TYPE_UNKNOWN,
+					ReactedAt: at(0),
+					Status:    entity.BATCH_REACTION_STATUS_REJECTED,
+					Error:     utils.BadRequestParamError("Invalid reaction type", "type"),
+				},
+				{TargetID: 2, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(3), Status: entity.BATCH_REACTION_STATUS_APPLIED, Matched: true, MatchID: 9},
+				{
+					TargetID:  5,
+					Type:      entity.REACTION_TYPE_SUPER_LIKE,
+					ReactedAt: at(4),
+					Status:    entity.BATCH_REACTION_STATUS_REJECTED,
+					Error:     utils.NewStandardError("Super like limit exceeded, try again tomorrow", "LIMIT_EXCEEDED", ""),
+				},
+				{
+					TargetID:  6,
+					Type:      entity.REACTION_TYPE_LIKE,
+					ReactedAt: at(5),
+					Status:    entity.BATCH_REACTION_STATUS_REJECTED,
+					Error:     utils.UserNotFoundError(6),
+				},
+				{
+					TargetID:  7,
+					Type:      entity.REACTION_TYPE_LIKE,
+					ReactedAt: at(6),
+					Status:    entity.BATCH_REACTION_STATUS_REJECTED,
+					Error:     utils.UserNotFoundError(7),
+				},
+			},
+		},
+		{
+			name: "normal case - nothing is saved when every item is rejected",
+			reactions: []entity.BatchReactionItem{
+				{TargetID: 2, Type: entity.REACTION_TYPE_LIKE},
+			},
+			mockCalls: func(ctx context.Context, db *mocksrepo.PostgresRepository, redis *mocksrepo.RedisRepository) {},
+			expectedResult: []entity.BatchReactionResult{
+				{
+					TargetID: 2,
+					Type:     entity.REACTION_TYPE_LIKE,
+					Status:   entity.BATCH_REACTION_STATUS_REJECTED,
+					Error:    utils.BadRequestParamError("Missing reaction time", "reacted_at"),
+				},
+			},
+		},
+		{
+			name: "error case - failed transaction refunds every reserved quota",
+			reactions: []entity.BatchReactionItem{
+				{TargetID: 2, Type: entity.REACTION_TYPE_PASS, ReactedAt: at(0)},
+				{TargetID: 3, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(1)},
+			},
+			mockCalls: func(ctx context.Context, db *mocksrepo.PostgresRepository, redis *mocksrepo.RedisRepository) {
+				for _, targetID := range []uint{2, 3} {
+					db.On("GetUserByID", targetID).Return(&entity.User{ID: targetID}, nil).Once()
+					db.On("GetUserReaction", uint(1), targetID).Return(nil, nil).Once()
+				}
+				redis.On("ReserveQuota", ctx, reactionKey, reactionLimit, untilResetAt).Return(true, nil).Twice()
+				db.On("UpsertUserReactions", []entity.ReactionParams{pass, like}).Return(nil, errors.New("timeout")).Once()
+				redis.On("RefundQuota", ctx, reactionKey).Return(int64(1), nil).Twice()
+			},
+			expectedErr: errors.New("timeout"),
+		},
+		{
+			name: "error case - redis error aborts the batch",
+			reactions: []entity.BatchReactionItem{
+				{TargetID: 2, Type: entity.REACTION_TYPE_PASS, ReactedAt: at(0)},
+				{TargetID: 3, Type: entity.REACTION_TYPE_LIKE, ReactedAt: at(1)},
+			},
+			mockCalls: func(ctx context.Context, db *mocksrepo.PostgresRepository, redis *mocksrepo.RedisRepository) {
+				for _, targetID := range []uint{2, 3} {
+					db.On("GetUserByID", targetID).Return(&entity.User{ID: targetID}, nil).Once()
+					db.On("GetUserReaction", uint(1), targetID).Return(nil, nil).Once()
+				}
+				redis.On("ReserveQuota", ctx, reactionKey, reactionLimit, untilResetAt).Return(true, nil).Once()
+				redis.On("ReserveQuota", ctx, reactionKey, reactionLimit, untilResetAt).Return(false, errors.New("redis down")).Once()
+				redis.On("RefundQuota", ctx, reactionKey).Return(int64(0), nil).Once()
+			},
+			expectedErr: errors.New("redis down"),
+		},
+		{
+			name: "error case - unexpected error when get the target",
+			reactions: []entity.BatchReactionItem{
+				{TargetID: 2, Type: entity.REACTION_TYPE_PASS, ReactedAt: at(0)},
+			},
+			mockCalls: func(ctx context.Context, db *mocksrepo.PostgresRepository, redis *mocksrepo.RedisRepository) {
+				db.On("GetUserByID", uint(2)).Return(nil, errors.New("timeout")).Once()
+			},
+			expectedErr: errors.New("timeout"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cache.On("Get", ctx, "premium:1").Return([]byte("false"), nil)
+			cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+			redis.On("Get", ctx, "blocks:1").Return("[7]", nil)
+			tc.mockCalls(ctx, db, redis)
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.ReactBatch(ctx, entity.BatchReactionParams{UserID: 1, Reactions: tc.reactions})
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestUserUc_UndoReaction(t *testing.T) {
+	now := time.Now()
+	previousType := entity.REACTION_TYPE_LIKE
+	recentReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS, CreatedAt: now, UpdatedAt: now}
+	replacingReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS, PreviousType: &previousType, CreatedAt: now.Add(-time.Hour), UpdatedAt: now}
+	repeatingReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE, PreviousType: &previousType, CreatedAt: now.Add(-time.Hour), UpdatedAt: now}
+	restoredReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE, CreatedAt: now.Add(-time.Hour), UpdatedAt: now}
+	undecidedReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_UNDECIDED, CreatedAt: now, UpdatedAt: now}
+	staleReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS, CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour)}
+	superLikeReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_SUPER_LIKE, CreatedAt: now, UpdatedAt: now}
+	likeReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_LIKE, CreatedAt: now, UpdatedAt: now}
+	endedMatch := &entity.Match{ID: 9, FirstUserID: 1, SecondUserID: 2, CreatedAt: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)}
+	// made a minute before the user's local midnight, so its quota was taken from yesterday's window
+	yesterday := testWindow.ResetAt.Add(-24*time.Hour - time.Minute)
+	yesterdayReaction := &entity.UserReaction{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS, CreatedAt: yesterday, UpdatedAt: yesterday}
+	yesterdayWindow := uc.NewQuotaWindow(testDailyQuota, yesterday, entity.LoadTimezone(entity.DEFAULT_TIMEZONE))
+
+	type shouldMock struct {
+		redisReserveRewind bool
+		dbRevertReaction   bool
+		redisRefundRewind  bool
+		redisRefundLimit   bool
+		redisRefundSuper   bool
+		redisNotifyUnmatch bool
+	}
+
+	type mocked struct {
+		cacheGetPremiumResult  []byte
+		cacheGetTimezoneResult []byte
+		redisReserveRewind     bool
+		dbGetLastResult        *entity.UserReaction
+		dbGetLastError         error
+		dbRevertResult         *entity.Match
+		dbRevertError          error
+	}
+	tests := []struct {
+		name            string
+		rewindWindow    time.Duration
+		freeRewindLimit int
+		shouldMock      shouldMock
+		mocked          mocked
+		expectedLimit   int
+		refundWindow    uc.QuotaWindow
+		expectedResult  *entity.UserReaction
+		expectedErr     error
+	}{
+		{
+			name: "normal case - free user undoes a pass and gets the quota back, with timezone NOT in cache",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+				redisRefundLimit:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("false"),
+				redisReserveRewind:    true,
+				dbGetLastResult:       recentReaction,
+			},
+			freeRewindLimit: 1,
+			expectedLimit:   1,
+			expectedResult:  recentReaction,
+		},
+		{
+			name:         "normal case - free user gets the quota back to the day the reaction was charged",
+			rewindWindow: 48 * time.Hour,
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+				redisRefundLimit:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("false"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        yesterdayReaction,
+			},
+			freeRewindLimit: 1,
+			expectedLimit:   1,
+			refundWindow:    yesterdayWindow,
+			expectedResult:  yesterdayReaction,
+		},
+		{
+			name: "normal case - free user undoes a reaction that replaced a charged one",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("false"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        replacingReaction,
+			},
+			freeRewindLimit: 1,
+			expectedLimit:   1,
+			expectedResult:  replacingReaction,
+		},
+		{
+			name: "normal case - free user undoes an undecided reaction which never used quota",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("false"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        undecidedReaction,
+			},
+			freeRewindLimit: 1,
+			expectedLimit:   1,
+			expectedResult:  undecidedReaction,
+		},
+		{
+			name: "normal case - premium user has a higher limit and no quota to give back",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        recentReaction,
+			},
+			expectedLimit:  10,
+			expectedResult: recentReaction,
+		},
+		{
+			name: "normal case - premium user gets the super like quota back",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+				redisRefundSuper:   true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        superLikeReaction,
+			},
+			expectedLimit:  10,
+			expectedResult: superLikeReaction,
+		},
+		{
+			name: "normal case - undoing the like that made a match ends it and tells the other side",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+				redisNotifyUnmatch: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        likeReaction,
+				dbRevertResult:         endedMatch,
+			},
+			expectedLimit:  10,
+			expectedResult: likeReaction,
+		},
+		{
+			name: "error case - free user has no rewind by default",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("false"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				dbGetLastResult:        recentReaction,
+			},
+			expectedLimit: 0,
+			expectedErr:   errors.New("Error on\ncode: LIMIT_EXCEEDED; error: Rewind is available for premium users; field:"),
+		},
+		{
+			name: "error case - failed to get last reaction",
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				dbGetLastError:         errors.New("Error GetLastReaction"),
+			},
+			expectedLimit: 10,
+			expectedErr:   errors.New("Error GetLastReaction"),
+		},
+		{
+			name: "error case - no reaction to undo",
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+			},
+			expectedLimit: 10,
+			expectedErr:   errors.New("Error on\ncode: NOT FOUND; error: No recent reaction to undo; field:"),
+		},
+		{
+			name: "error case - last reaction is outside the rewind window",
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				dbGetLastResult:        staleReaction,
+			},
+			expectedLimit: 10,
+			expectedErr:   errors.New("Error on\ncode: NOT FOUND; error: No recent reaction to undo; field:"),
+		},
+		{
+			name: "error case - last reaction repeats the type it replaced, the rewind is not charged",
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("false"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				dbGetLastResult:        repeatingReaction,
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: The latest reaction has nothing to undo; field:"),
+		},
+		{
+			name: "error case - last reaction was already undone, an older one is not undone instead",
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("false"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				dbGetLastResult:        restoredReaction,
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: The latest reaction has nothing to undo; field:"),
+		},
+		{
+			name: "error case - failed to revert reaction",
+			shouldMock: shouldMock{
+				redisReserveRewind: true,
+				dbRevertReaction:   true,
+				redisRefundRewind:  true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult:  []byte("true"),
+				cacheGetTimezoneResult: []byte(entity.DEFAULT_TIMEZONE),
+				redisReserveRewind:     true,
+				dbGetLastResult:        recentReaction,
+				dbRevertError:          errors.New("Error RevertUserReaction"),
+			},
+			expectedLimit: 10,
+			expectedErr:   errors.New("Error RevertUserReaction"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cache.On("Get", ctx, "premium:1").Return(tc.mocked.cacheGetPremiumResult, nil)
+			cache.On("Get", ctx, "timezone:1").Return(tc.mocked.cacheGetTimezoneResult, nil)
+			if len(tc.mocked.cacheGetTimezoneResult) == 0 {
+				db.On("GetUserByID", uint(1)).Return(&entity.User{ID: 1, Timezone: entity.DEFAULT_TIMEZONE}, nil)
+				cache.On("Set", ctx, "timezone:1", []byte(entity.DEFAULT_TIMEZONE), 24*time.Hour).Return(nil)
+			}
+			db.On("GetLastReaction", uint(1)).Return(tc.mocked.dbGetLastResult, tc.mocked.dbGetLastError)
+
+			if tc.shouldMock.redisReserveRewind {
+				redis.On("ReserveQuota", ctx, uc.BuildQuotaRedisKey("rewind", testWindow, 1), tc.expectedLimit, untilResetAt).Return(tc.mocked.redisReserveRewind, nil)
+			}
+
+			if tc.shouldMock.dbRevertReaction {
+				db.On("RevertUserReaction", *tc.mocked.dbGetLastResult).Return(tc.mocked.dbRevertResult, tc.mocked.dbRevertError)
+			}
+
+			if tc.shouldMock.redisNotifyUnmatch {
+				redis.On("PublishEvent", ctx, uint(2), `{"type":"unmatch","data":{"id":9,"first_user_id":1,"second_user_id":2,"created_at":"2026-10-18T09:00:00Z"}}`).Return("1-0", nil)
+			}
+
+			if tc.shouldMock.redisRefundRewind {
+				redis.On("RefundQuota", ctx, uc.BuildQuotaRedisKey("rewind", testWindow, 1)).Return(int64(0), nil)
+			}
+
+			refundWindow := testWindow
+			if tc.refundWindow.Period != "" {
+				refundWindow = tc.refundWindow
+			}
+
+			if tc.shouldMock.redisRefundLimit {
+				redis.On("RefundQuota", ctx, uc.BuildQuotaRedisKey("reaction", refundWindow, 1)).Return(int64(2), nil)
+			}
+
+			if tc.shouldMock.redisRefundSuper {
+				redis.On("RefundQuota", ctx, uc.BuildQuotaRedisKey("super_like", refundWindow, 1)).Return(int64(0), nil)
+			}
+
+			settings := defaultSettings
+			if tc.rewindWindow > 0 {
+				settings.Rewind.Window = tc.rewindWindow
+			}
+			// free users have no rewind by default, a policy granting some gives their reaction quota back
+			if tc.freeRewindLimit > 0 {
+				settings.Policy = entity.DefaultPolicy()
+				quotas := settings.Policy.Tiers[entity.TIER_FREE].Quotas
+				for i := range quotas {
+					if quotas[i].Name == entity.QUOTA_REWIND {
+						quotas[i].Limit = tc.freeRewindLimit
+					}
+				}
+			}
+			usecase := uc.NewUserUsecase(defaultAuthConfig, settings, redis, db, cache, &log.Logger{})
+
+			result, err := usecase.UndoReaction(ctx, 1)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestUserUc_UndoReaction_RollingQuota(t *testing.T) {
+	s := miniredis.RunT(t)
+	defer s.Close()
+
+	redisClient, _ := redisconn.NewClient(s.Host(), s.Port(), "1s", "0")
+	redis := repository.NewRedisRepository(redisClient)
+	db := mocksrepo.NewPostgresRepository(t)
+	cache := mocksrepo.NewCacheRepository(t)
+	ctx := context.Background()
+
+	rollingQuota := entity.QuotaPolicy{Name: "reaction", Limit: 2, Window: entity.QUOTA_WINDOW_ROLLING, Period: entity.Duration(6 * time.Hour), Message: "Slow down"}
+	settings := defaultSettings
+	settings.Policy = entity.Policy{
+		Tiers: map[string]entity.TierPolicy{
+			entity.TIER_FREE: {Quotas: []entity.QuotaPolicy{rollingQuota}},
+		},
+	}
+	quotaKey := "reaction:rolling:1"
+
+	cache.On("Get", ctx, "premium:1").Return([]byte("false"), nil)
+	cache.On("Get", ctx, "timezone:1").Return([]byte(entity.DEFAULT_TIMEZONE), nil)
+	s.Set("blocks:1", "[]")
+	db.On("GetUserByID", mock.Anything).Return(testUser, nil)
+	db.On("GetUserReaction", uint(1), mock.Anything).Return(nil, nil)
+	db.On("UpsertUserReaction", mock.Anything).Return(nil, nil)
+
+	usecase := uc.NewUserUsecase(defaultAuthConfig, settings, redis, db, cache, &log.Logger{})
+
+	_, err := usecase.React(ctx, entity.ReactionParams{UserID: 1, TargetID: 2, Type: entity.REACTION_TYPE_PASS})
+	assert.Nil(t, err)
+	firstMembers, _ := s.ZMembers(quotaKey)
+	// the entries are told apart by the time they were taken
+	time.Sleep(2 * time.Millisecond)
+	_, err = usecase.React(ctx, entity.ReactionParams{UserID: 1, TargetID: 3, Type: entity.REACTION_TYPE_PASS})
+	assert.Nil(t, err)
+	_, err = usecase.React(ctx, entity.ReactionParams{UserID: 1, TargetID: 4, Type: entity.REACTION_TYPE_PASS})
+	assert.Equal(t, "Error on\ncode: LIMIT_EXCEEDED; error: Slow down; field:", err.Error())
+
+	// the latest reaction is saved after its quota was taken
+	savedAt := time.Now()
+	lastReaction := &entity.UserReaction{UserID: 1, TargetID: 3, Type: entity.REACTION_TYPE_PASS, CreatedAt: savedAt, UpdatedAt: savedAt}
+	db.On("GetLastReaction", uint(1)).Return(lastReaction, nil)
+	db.On("RevertUserReaction", *lastReaction).Return(nil, nil)
+
+	result, err := usecase.UndoReaction(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, lastReaction, result)
+
+	// only the undone reaction gave its unit back, so one more reaction fits in the quota
+	members, _ := s.ZMembers(quotaKey)
+	assert.Equal(t, firstMembers, members)
+	_, err = usecase.React(ctx, entity.ReactionParams{UserID: 1, TargetID: 4, Type: entity.REACTION_TYPE_PASS})
+	assert.Nil(t, err)
+}
+
+func TestUserUc_ReactionHistory(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	params := entity.ReactionHistoryParams{
+		UserID: 1,
+		Page:   utils.PageParams{Limit: 2},
+	}
+	history := []entity.ReactionHistoryItem{
+		{Type: 2, Target: entity.UserSummary{ID: 4}, CreatedAt: timestamp, UpdatedAt: timestamp.Add(2 * time.Minute)},
+		{Type: 1, Target: entity.UserSummary{ID: 3}, CreatedAt: timestamp.Add(-time.Hour), UpdatedAt: timestamp.Add(time.Minute)},
+		{Type: 2, Target: entity.UserSummary{ID: 2}, CreatedAt: timestamp, UpdatedAt: timestamp},
+	}
+
+	type mocked struct {
+		dbResult []entity.ReactionHistoryItem
+		dbError  error
+	}
+	tests := []struct {
+		name           string
+		mocked         mocked
+		expectedResult []entity.ReactionHistoryItem
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name: "normal case - page with more results",
+			mocked: mocked{
+				dbResult: history,
+			},
+			expectedResult: history[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: history[1].UpdatedAt, ID: 3},
+			},
+		},
+		{
+			name: "normal case - last page",
+			mocked: mocked{
+				dbResult: history[2:],
+			},
+			expectedResult: history[2:],
+		},
+		{
+			name: "error case - error from db",
+			mocked: mocked{
+				dbError: errors.New("Error GetReactionHistory"),
+			},
+			expectedErr: errors.New("Error GetReactionHistory"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetReactionHistory", params).Return(tc.mocked.dbResult, tc.mocked.dbError)
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
+
+			result, page, err := usecase.ReactionHistory(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestUserUc_Matches(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	params := entity.MatchListParams{
+		UserID: 1,
+		Page:   utils.PageParams{Limit: 2},
+	}
+	matches := []entity.MatchListItem{
+		{ID: 7, User: entity.UserSummary{ID: 2}, CreatedAt: timestamp, LastActivityAt: timestamp.Add(3 * time.Minute), UnreadCount: 2},
+		{ID: 9, User: entity.UserSummary{ID: 4}, CreatedAt: timestamp.Add(2 * time.Minute), LastActivityAt: timestamp.Add(2 * time.Minute)},
+		{ID: 8, User: entity.UserSummary{ID: 3}, CreatedAt: timestamp.Add(time.Minute), LastActivityAt: timestamp.Add(time.Minute)},
+	}
+
+	type mocked struct {
+		dbResult []entity.MatchListItem
+		dbError  error
+	}
+	tests := []struct {
+		name           string
+		mocked         mocked
+		expectedResult []entity.MatchListItem
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name: "normal case - page with more results",
+			mocked: mocked{
+				dbResult: matches,
+			},
+			expectedResult: matches[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: matches[1].LastActivityAt, ID: 9},
+			},
+		},
+		{
+			name: "normal case - last page",
+			mocked: mocked{
+				dbResult: matches[2:],
+			},
+			expectedResult: matches[2:],
+		},
+		{
+			name: "error case - error from db",
+			mocked: mocked{
+				dbError: errors.New("Error GetMatches"),
+			},
+			expectedErr: errors.New("Error GetMatches"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetMatches", params).Return(tc.mocked.dbResult, tc.mocked.dbError)
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
+
+			result, page, err := usecase.Matches(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestUserUc_Unmatch(t *testing.T) {
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	notification := `{"type":"unmatch","data":{"id":7,"first_user_id":1,"second_user_id":2,"created_at":"2026-10-18T09:00:00Z"}}`
+
+	tests := []struct {
+		name           string
+		userID         uint
+		dbResult       *entity.Match
+		dbError        error
+		expectedNotify uint
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - first user ends the match and the second is notified",
+			userID:         1,
+			dbResult:       &entity.Match{ID: 7, FirstUserID: 1, SecondUserID: 2, CreatedAt: createdAt},
+			expectedNotify: 2,
+		},
+		{
+			name:           "normal case - second user ends the match and the first is notified",
+			userID:         2,
+			dbResult:       &entity.Match{ID: 7, FirstUserID: 1, SecondUserID: 2, CreatedAt: createdAt},
+			expectedNotify: 1,
+		},
+		{
+			name:        "error case - match not found, not the user's or already ended",
+			userID:      1,
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: Match not found; field:"),
+		},
+		{
+			name:        "error case - error from db",
+			userID:      1,
+			dbError:     errors.New("Error EndMatch"),
+			expectedErr: errors.New("Error EndMatch"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			params := entity.UnmatchParams{MatchID: 7, UserID: tc.userID, Reason: entity.UNMATCH_REASON_SPAM}
+
+			db.On("EndMatch", params).Return(tc.dbResult, tc.dbError)
+			if tc.expectedNotify != 0 {
+				redis.On("PublishEvent", ctx, tc.expectedNotify, notification).Return("1-0", nil).Once()
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, &repository.CacheRepository{}, &log.Logger{})
+
+			err := usecase.Unmatch(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestUserUc_ReceivedLikes(t *testing.T) {
+	timestamp, _ := time.Parse("1/2/2006", "2/2/2025")
+	older := timestamp.Add(-time.Minute)
+	params := entity.ReceivedLikesParams{
+		UserID: 1,
+		Page:   utils.PageParams{Limit: 2},
+	}
+	likes := []entity.ReceivedLike{
+		{User: &entity.UserSummary{ID: 4, Username: "fourth"}, CreatedAt: &timestamp},
+		{User: &entity.UserSummary{ID: 3, Username: "third"}, CreatedAt: &older},
+		{User: &entity.UserSummary{ID: 2, Username: "second"}, CreatedAt: &older},
+	}
+
+	type shouldMock struct {
+		dbGetUserByID      bool
+		dbCountLikes       bool
+		dbGetReceivedLikes bool
+	}
+
+	type mocked struct {
+		cacheGetPremiumResult []byte
+		dbGetUserByIDError    error
+		dbCountLikesResult    int64
+		dbCountLikesError     error
+		dbGetReceivedLikes    []entity.ReceivedLike
+		dbGetReceivedLikesErr error
+	}
+	tests := []struct {
+		name           string
+		shouldMock     shouldMock
+		mocked         mocked
+		expectedResult entity.ReceivedLikes
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name: "normal case - premium user gets the full page",
+			shouldMock: shouldMock{
+				dbCountLikes:       true,
+				dbGetReceivedLikes: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("true"),
+				dbCountLikesResult:    3,
+				dbGetReceivedLikes:    likes,
+			},
+			expectedResult: entity.ReceivedLikes{
+				Count: 3,
+				Likes: likes[:2],
+			},
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: older, ID: 3},
+			},
+		},
+		{
+			name: "normal case - free user only gets count and placeholders",
+			shouldMock: shouldMock{
+				dbCountLikes: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("false"),
+				dbCountLikesResult:    3,
+			},
+			expectedResult: entity.ReceivedLikes{
+				Count: 3,
+				Likes: []entity.ReceivedLike{{Blurred: true}, {Blurred: true}},
+			},
+		},
+		{
+			name: "normal case - free user without likes",
+			shouldMock: shouldMock{
+				dbCountLikes: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("false"),
+			},
+			expectedResult: entity.ReceivedLikes{
+				Likes: []entity.ReceivedLike{},
+			},
+		},
+		{
+			name: "error case - failed to get user data when premium info not in cache",
+			shouldMock: shouldMock{
+				dbGetUserByID: true,
+			},
+			mocked: mocked{
+				dbGetUserByIDError: errors.New("Error GetUserByID"),
+			},
+			expectedErr: errors.New("Error GetUserByID"),
+		},
+		{
+			name: "error case - failed to count likes",
+			shouldMock: shouldMock{
+				dbCountLikes: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("true"),
+				dbCountLikesError:     errors.New("Error CountReceivedLikes"),
+			},
+			expectedErr: errors.New("Error CountReceivedLikes"),
+		},
+		{
+			name: "error case - failed to get likes",
+			shouldMock: shouldMock{
+				dbCountLikes:       true,
+				dbGetReceivedLikes: true,
+			},
+			mocked: mocked{
+				cacheGetPremiumResult: []byte("true"),
+				dbCountLikesResult:    3,
+				dbGetReceivedLikesErr: errors.New("Error GetReceivedLikes"),
+			},
+			expectedErr: errors.New("Error GetReceivedLikes"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cache.On("Get", ctx, "premium:1").Return(tc.mocked.cacheGetPremiumResult, nil)
+
+			if tc.shouldMock.dbGetUserByID {
+				db.On("GetUserByID", params.UserID).Return(nil, tc.mocked.dbGetUserByIDError)
+			}
+
+			if tc.shouldMock.dbCountLikes {
+				db.On("CountReceivedLikes", params.UserID).Return(tc.mocked.dbCountLikesResult, tc.mocked.dbCountLikesError)
+			}
+
+			if tc.shouldMock.dbGetReceivedLikes {
+				db.On("GetReceivedLikes", params).Return(tc.mocked.dbGetReceivedLikes, tc.mocked.dbGetReceivedLikesErr)
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, cache, &log.Logger{})
+
+			result, page, err := usecase.ReceivedLikes(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestUserUc_Profile(t *testing.T) {
+	lastActiveAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	candidate := &entity.Candidate{ID: 2, Username: "second", Bio: "hello", LastActiveAt: lastActiveAt}
+
+	type mocked struct {
+		redisGetBlocksResult string
+		dbGetBlocksResult    []uint
+		dbGetBlocksError     error
+		dbGetCandidateResult *entity.Candidate
+		dbGetCandidateError  error
+	}
+	tests := []struct {
+		name           string
+		mocked         mocked
+		expectedResult *entity.UserProfile
+		expectedErr    error
+	}{
+		{
+			name: "normal case - blocks in cache",
+			mocked: mocked{
+				redisGetBlocksResult: "[5]",
+				dbGetCandidateResult: candidate,
+			},
+			expectedResult: &entity.UserProfile{ID: 2, Username: "second", Bio: "hello", LastActiveAt: lastActiveAt},
+		},
+		{
+			name: "normal case - blocks NOT in cache",
+			mocked: mocked{
+				dbGetBlocksResult:    []uint{},
+				dbGetCandidateResult: candidate,
+			},
+			expectedResult: &entity.UserProfile{ID: 2, Username: "second", Bio: "hello", LastActiveAt: lastActiveAt},
+		},
+		{
+			name: "error case - user hidden by a block",
+			mocked: mocked{
+				redisGetBlocksResult: "[2]",
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - user hidden by reports",
+			mocked: mocked{
+				redisGetBlocksResult: "[]",
+				dbGetCandidateResult: &entity.Candidate{ID: 2, Username: "second", Hidden: true},
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - user not found",
+			mocked: mocked{
+				redisGetBlocksResult: "[]",
+			},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - error when get blocks from db",
+			mocked: mocked{
+				dbGetBlocksError: errors.New("Error GetBlockedUserIDs"),
+			},
+			expectedErr: errors.New("Error GetBlockedUserIDs"),
+		},
+		{
+			name: "error case - error when get candidate from db",
+			mocked: mocked{
+				redisGetBlocksResult: "[]",
+				dbGetCandidateError:  errors.New("Error GetCandidateByID"),
+			},
+			expectedErr: errors.New("Error GetCandidateByID"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			redis.On("Get", ctx, "blocks:1").Return(tc.mocked.redisGetBlocksResult, nil)
+			if tc.mocked.redisGetBlocksResult == "" {
+				db.On("GetBlockedUserIDs", uint(1)).Return(tc.mocked.dbGetBlocksResult, tc.mocked.dbGetBlocksError)
+				if tc.mocked.dbGetBlocksError == nil {
+					redis.On("Set", ctx, "blocks:1", "[]", 24*time.Hour).Return("OK", nil)
+				}
+			}
+			if tc.mocked.dbGetBlocksError == nil && tc.mocked.redisGetBlocksResult != "[2]" {
+				db.On("GetCandidateByID", uint(2)).Return(tc.mocked.dbGetCandidateResult, tc.mocked.dbGetCandidateError)
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, mocksrepo.NewCacheRepository(t), &log.Logger{})
+
+			result, err := usecase.Profile(ctx, 1, 2)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestUserUc_Block(t *testing.T) {
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	params := entity.BlockParams{UserID: 1, TargetID: 2}
+	notification := `{"type":"unmatch","data":{"id":7,"first_user_id":1,"second_user_id":2,"created_at":"2026-10-18T09:00:00Z"}}`
+
+	type mocked struct {
+		dbGetUserByIDResult *entity.User
+		dbGetUserByIDError  error
+		dbInsertBlockResult *entity.Match
+		dbInsertBlockError  error
+	}
+	tests := []struct {
+		name        string
+		mocked      mocked
+		expectedErr error
+	}{
+		{
+			name: "normal case - block without a match",
+			mocked: mocked{
+				dbGetUserByIDResult: &entity.User{ID: 2},
+			},
+		},
+		{
+			name: "normal case - block ending a match notifies the other user",
+			mocked: mocked{
+				dbGetUserByIDResult: &entity.User{ID: 2},
+				dbInsertBlockResult: &entity.Match{ID: 7, FirstUserID: 1, SecondUserID: 2, CreatedAt: createdAt},
+			},
+		},
+		{
+			name:        "error case - target not found",
+			mocked:      mocked{},
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: User not found:2; field:"),
+		},
+		{
+			name: "error case - error when get target from db",
+			mocked: mocked{
+				dbGetUserByIDError: errors.New("Error GetUserByID"),
+			},
+			expectedErr: errors.New("Error GetUserByID"),
+		},
+		{
+			name: "error case - error when insert block",
+			mocked: mocked{
+				dbGetUserByIDResult: &entity.User{ID: 2},
+				dbInsertBlockError:  errors.New("Error InsertBlock"),
+			},
+			expectedErr: errors.New("Error InsertBlock"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		cache := mocksrepo.NewCacheRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetUserByID", uint(2)).Return(tc.mocked.dbGetUserByIDResult, tc.mocked.dbGetUserByIDError)
+			if tc.mocked.dbGetUserByIDResult != nil {
+				db.On("InsertBlock", params).Return(tc.mocked.dbInsertBlockResult, tc.mocked.dbInsertBlockError)
+			}
+			if tc.mocked.dbGetUserByIDResult != nil && tc.mocked.dbInsertBlockError == nil {
+				redis.On("Del", ctx, "blocks:1").Return(int64(1), nil).Once()
+				redis.On("Del", ctx, "blocks:2").Return(int64(1), nil).Once()
+			}
+			if tc.mocked.dbInsertBlockResult != nil {
+				redis.On("PublishEvent", ctx, uint(2), notification).Return("1-0", nil).Once()
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, cache, &log.Logger{})
+
+			err := usecase.Block(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestUserUc_Unblock(t *testing.T) {
+	params := entity.BlockParams{UserID: 1, TargetID: 2}
+
+	tests := []struct {
+		name        string
+		dbResult    bool
+		dbError     error
+		expectedErr error
+	}{
+		{
+			name:     "normal case - block lifted",
+			dbResult: true,
+		},
+		{
+			name:        "error case - no such block",
+			expectedErr: errors.New("Error on\ncode: NOT FOUND; error: Block not found; field:"),
+		},
+		{
+			name:        "error case - error from db",
+			dbError:     errors.New("Error DeleteBlock"),
+			expectedErr: errors.New("Error DeleteBlock"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		redis := mocksrepo.NewRedisRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("DeleteBlock", params).Return(tc.dbResult, tc.dbError)
+			if tc.dbResult {
+				redis.On("Del", ctx, "blocks:1").Return(int64(1), nil).Once()
+				redis.On("Del", ctx, "blocks:2").Return(int64(1), nil).Once()
+			}
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, redis, db, mocksrepo.NewCacheRepository(t), &log.Logger{})
+
+			err := usecase.Unblock(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestUserUc_Blocks(t *testing.T) {
+	timestamp := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	params := entity.BlockListParams{
+		UserID: 1,
+		Page:   utils.PageParams{Limit: 2},
+	}
+	blocks := []entity.BlockListItem{
+		{User: entity.UserSummary{ID: 4}, CreatedAt: timestamp.Add(2 * time.Minute)},
+		{User: entity.UserSummary{ID: 3}, CreatedAt: timestamp.Add(time.Minute)},
+		{User: entity.UserSummary{ID: 2}, CreatedAt: timestamp},
+	}
+
+	tests := []struct {
+		name           string
+		dbResult       []entity.BlockListItem
+		dbError        error
+		expectedResult []entity.BlockListItem
+		expectedPage   utils.Page
+		expectedErr    error
+	}{
+		{
+			name:           "normal case - page with more results",
+			dbResult:       blocks,
+			expectedResult: blocks[:2],
+			expectedPage: utils.Page{
+				HasMore:    true,
+				NextCursor: &utils.Cursor{CreatedAt: blocks[1].CreatedAt, ID: 3},
+			},
+		},
+		{
+			name:           "normal case - last page",
+			dbResult:       blocks[2:],
+			expectedResult: blocks[2:],
+		},
+		{
+			name:        "error case - error from db",
+			dbError:     errors.New("Error GetBlocks"),
+			expectedErr: errors.New("Error GetBlocks"),
+		},
+	}
+	for _, tc := range tests {
+		db := mocksrepo.NewPostgresRepository(t)
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db.On("GetBlocks", params).Return(tc.dbResult, tc.dbError)
+
+			usecase := uc.NewUserUsecase(defaultAuthConfig, defaultSettings, &repository.RedisRepository{}, db, &repository.CacheRepository{}, &log.Logger{})
+
+			result, page, err := usecase.Blocks(ctx, params)
+			if tc.expectedErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+				assert.Equal(t, tc.expectedPage, page)
+			}
 		})
 	}
 }